@@ -31,6 +31,22 @@ const (
 	LoadBalancerServiceSyncFailedReason = "LoadBalancerServiceSyncFailed"
 	// LoadBalancerFailedToOwnReason used when no owned label could be set on a load balancer.
 	LoadBalancerFailedToOwnReason = "LoadBalancerFailedToOwn"
+	// LoadBalancerNotAttachedToNetworkReason used when a shared load balancer is not attached to the network of
+	// the cluster.
+	LoadBalancerNotAttachedToNetworkReason = "LoadBalancerNotAttachedToNetwork"
+)
+
+const (
+	// ControlPlaneFloatingIPReadyCondition reports on whether the IP used as control plane endpoint is assigned to a healthy server.
+	ControlPlaneFloatingIPReadyCondition clusterv1.ConditionType = "ControlPlaneFloatingIPReady"
+	// FloatingIPCreateFailedReason used when an error occurs during creation of the IP.
+	FloatingIPCreateFailedReason = "FloatingIPCreateFailed"
+	// FloatingIPAssignFailedReason used when an error occurs during assignment of the IP.
+	FloatingIPAssignFailedReason = "FloatingIPAssignFailed"
+	// FloatingIPDeleteFailedReason used when an error occurs during deletion of the IP.
+	FloatingIPDeleteFailedReason = "FloatingIPDeleteFailed"
+	// NoHealthyControlPlaneServerReason indicates that no control plane server with a reachable API server exists.
+	NoHealthyControlPlaneServerReason = "NoHealthyControlPlaneServer"
 )
 
 const (
@@ -59,6 +75,8 @@ const (
 	ServerStartingReason = "ServerStarting"
 	// ServerOffReason instance is off.
 	ServerOffReason = "ServerOff"
+	// ServerRecreatingReason indicates that the server has been deleted by remediation and is about to be recreated.
+	ServerRecreatingReason = "ServerRecreating"
 )
 
 const (
@@ -66,6 +84,8 @@ const (
 	NetworkAttachFailedReason = "NetworkAttachFailed"
 	// LoadBalancerAttachFailedReason is used when server could not be attached to network.
 	LoadBalancerAttachFailedReason = "LoadBalancerAttachFailed"
+	// VolumeAttachFailedReason is used when volumes could not be created or attached to the server.
+	VolumeAttachFailedReason = "VolumeAttachFailed"
 )
 
 const (
@@ -82,6 +102,17 @@ const (
 	NetworkReconcileFailedReason = "NetworkReconcileFailed"
 )
 
+const (
+	// RobotVSwitchReadyCondition reports on whether the Robot vSwitch of the cluster is ready.
+	RobotVSwitchReadyCondition clusterv1.ConditionType = "RobotVSwitchReady"
+	// RobotVSwitchReconcileFailedReason indicates that reconciling the Robot vSwitch failed.
+	RobotVSwitchReconcileFailedReason = "RobotVSwitchReconcileFailed"
+	// RobotVSwitchNotReadyReason indicates that the Robot vSwitch of the cluster has not been created yet.
+	RobotVSwitchNotReadyReason = "RobotVSwitchNotReady"
+	// RobotVSwitchAttachFailedReason indicates that a bare metal server could not be added to the Robot vSwitch.
+	RobotVSwitchAttachFailedReason = "RobotVSwitchAttachFailed"
+)
+
 const (
 	// PlacementGroupsSyncedCondition reports on whether the placement groups are successfully synced.
 	PlacementGroupsSyncedCondition clusterv1.ConditionType = "PlacementGroupsSynced"
@@ -89,6 +120,13 @@ const (
 	PlacementGroupsSyncFailedReason = "PlacementGroupsSyncFailed"
 )
 
+const (
+	// FirewallsSyncedCondition reports on whether the firewalls are successfully synced.
+	FirewallsSyncedCondition clusterv1.ConditionType = "FirewallsSynced"
+	// FirewallsSyncFailedReason indicates that syncing the firewalls failed.
+	FirewallsSyncFailedReason = "FirewallsSyncFailed"
+)
+
 const (
 	// HCloudTokenAvailableCondition reports on whether the HCloud Token is available.
 	HCloudTokenAvailableCondition clusterv1.ConditionType = "HCloudTokenAvailable"
@@ -123,6 +161,15 @@ const (
 	TargetSecretSyncFailedReason = "TargetSecretSyncFailed"
 )
 
+const (
+	// CredentialsRotatedCondition reports on whether the cluster uses the next credentials of the Hetzner secret.
+	CredentialsRotatedCondition clusterv1.ConditionType = "CredentialsRotated"
+	// NextHCloudTokenInvalidReason indicates that the next HCloud token of the Hetzner secret is invalid.
+	NextHCloudTokenInvalidReason = "NextHCloudTokenInvalid" // #nosec
+	// NextRobotCredentialsInvalidReason indicates that the next Robot credentials of the Hetzner secret are invalid.
+	NextRobotCredentialsInvalidReason = "NextRobotCredentialsInvalid" // #nosec
+)
+
 const (
 	// HetznerAPIReachableCondition reports whether the Hetzner APIs are reachable.
 	HetznerAPIReachableCondition clusterv1.ConditionType = "HetznerAPIReachable"
@@ -143,6 +190,10 @@ const (
 	OSSSHSecretMissingReason = "OSSSHSecretMissing"
 	// RescueSSHSecretMissingReason indicates that secret with the rescue ssh key is missing.
 	RescueSSHSecretMissingReason = "RescueSSHSecretMissing"
+	// SSHBastionSecretMissingReason indicates that secret with the ssh key of the bastion host is missing.
+	SSHBastionSecretMissingReason = "SSHBastionSecretMissing"
+	// EncryptionSecretMissingReason indicates that secret with the passphrase of the encrypted partitions is missing.
+	EncryptionSecretMissingReason = "EncryptionSecretMissing"
 )
 
 const (
@@ -164,6 +215,22 @@ const (
 	ServerNotFoundReason = "ServerNotFound"
 )
 
+const (
+	// SSHHostKeyVerifiedCondition reports on whether the SSH host keys presented by a host match the recorded ones.
+	SSHHostKeyVerifiedCondition clusterv1.ConditionType = "SSHHostKeyVerified"
+	// SSHHostKeyMismatchReason indicates that a host presented an SSH host key that differs from the recorded one.
+	SSHHostKeyMismatchReason = "SSHHostKeyMismatch"
+)
+
+const (
+	// DiskWipeSucceededCondition reports on whether the disks of a host have been wiped after deprovisioning.
+	DiskWipeSucceededCondition clusterv1.ConditionType = "DiskWipeSucceeded"
+	// DiskWipeInProgressReason indicates that the disks of a host are being wiped.
+	DiskWipeInProgressReason = "DiskWipeInProgress"
+	// DiskWipeFailedReason indicates that the disks of a host could not be wiped.
+	DiskWipeFailedReason = "DiskWipeFailed"
+)
+
 const (
 	// HostAssociateSucceededCondition indicates that a host has been associated.
 	HostAssociateSucceededCondition clusterv1.ConditionType = "HostAssociateSucceeded"
@@ -173,6 +240,13 @@ const (
 	HostAssociateFailedReason = "HostAssociateFailed"
 )
 
+const (
+	// HostCapacityAvailableCondition indicates that the capacity of a HetznerBareMetalMachineTemplate could be computed from matching hosts.
+	HostCapacityAvailableCondition clusterv1.ConditionType = "HostCapacityAvailable"
+	// HardwareDetailsMissingReason indicates that none of the matching hosts has reported hardware details yet.
+	HardwareDetailsMissingReason = "HardwareDetailsMissing"
+)
+
 // deprecated conditions.
 
 const (
@@ -41,8 +41,13 @@ type HCloudMachineSpec struct {
 	Type HCloudMachineType `json:"type"`
 
 	// ImageName is the reference to the Machine Image from which to create the machine instance.
-	// +kubebuilder:validation:MinLength=1
-	ImageName string `json:"imageName"`
+	// Either ImageName or ImageSelector has to be set.
+	// +optional
+	ImageName string `json:"imageName,omitempty"`
+
+	// ImageSelector selects the Machine Image from which to create the machine instance by ID or by labels.
+	// +optional
+	ImageSelector *ImageSelector `json:"imageSelector,omitempty"`
 
 	// define Machine specific SSH keys, overrides cluster wide SSH keys
 	// +optional
@@ -54,6 +59,86 @@ type HCloudMachineSpec struct {
 	// PublicNetwork specifies information for public networks
 	// +optional
 	PublicNetwork *PublicNetworkSpec `json:"publicNetwork,omitempty"`
+
+	// Volumes are HCloud Volumes that are created in the location of the server and attached to it
+	// before the node bootstraps.
+	// +optional
+	Volumes []HCloudVolumeSpec `json:"volumes,omitempty"`
+
+	// HCloudLabels are added to the HCloud server of the machine. They take precedence over the HCloudLabels
+	// of the HetznerCluster and are kept in sync on the existing server.
+	// +optional
+	HCloudLabels map[string]string `json:"hcloudLabels,omitempty"`
+}
+
+// ImageSelectionPolicy defines which image is used if several images match an ImageSelector.
+type ImageSelectionPolicy string
+
+const (
+	// ImageSelectionPolicyNewest uses the most recently created image.
+	ImageSelectionPolicyNewest ImageSelectionPolicy = "Newest"
+	// ImageSelectionPolicyHighestSemver uses the image with the highest semantic version in the SemverLabel.
+	ImageSelectionPolicyHighestSemver ImageSelectionPolicy = "HighestSemver"
+)
+
+// ImageSelector selects an HCloud image by ID or by labels.
+type ImageSelector struct {
+	// ID is the ID of the image. If it is set, the other fields are ignored.
+	// +optional
+	ID *int64 `json:"id,omitempty"`
+
+	// LabelSelector is an HCloud label selector for the image, e.g. "os==ubuntu,version in (1.28.2,1.28.3)".
+	// +optional
+	LabelSelector string `json:"labelSelector,omitempty"`
+
+	// Policy defines which image is used if several images match the LabelSelector.
+	// +kubebuilder:validation:Enum=Newest;HighestSemver
+	// +kubebuilder:default=Newest
+	// +optional
+	Policy ImageSelectionPolicy `json:"policy,omitempty"`
+
+	// SemverLabel is the key of the label that contains the semantic version of the image.
+	// It is required for the policy HighestSemver.
+	// +optional
+	SemverLabel string `json:"semverLabel,omitempty"`
+}
+
+// HCloudVolumeSpec defines an HCloud Volume that is attached to the server of an HCloudMachine.
+type HCloudVolumeSpec struct {
+	// Name of the volume. The HCloud Volume is named after the machine with this name as suffix.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Size of the volume in GB.
+	// +kubebuilder:validation:Minimum=10
+	// +kubebuilder:validation:Maximum=10240
+	Size int `json:"size"`
+
+	// Format is the filesystem the volume is formatted with. The volume is not formatted if left empty.
+	// +kubebuilder:validation:Enum=ext4;xfs
+	// +optional
+	Format *string `json:"format,omitempty"`
+
+	// MountPath is a hint where the volume should be mounted. It is reported in the status together
+	// with the device path, so that it can be used by the bootstrap configuration.
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+
+	// DeleteOnMachineDelete defines whether the volume is deleted together with the machine.
+	// If set to false, the volume is kept and reused by a machine with the same name.
+	// +optional
+	// +kubebuilder:default=true
+	DeleteOnMachineDelete bool `json:"deleteOnMachineDelete"`
+}
+
+// HCloudVolumeStatus defines the observed state of an HCloud Volume attached to the server.
+type HCloudVolumeStatus struct {
+	Name        string `json:"name"`
+	ID          int64  `json:"id,omitempty"`
+	LinuxDevice string `json:"linuxDevice,omitempty"`
+	MountPath   string `json:"mountPath,omitempty"`
 }
 
 // HCloudMachineStatus defines the observed state of HCloudMachine.
@@ -72,6 +157,14 @@ type HCloudMachineStatus struct {
 	// +optional
 	InstanceState *hcloud.ServerStatus `json:"instanceState,omitempty"`
 
+	// ImageID is the ID of the image the server was created from.
+	// +optional
+	ImageID int64 `json:"imageID,omitempty"`
+
+	// Volumes contains the HCloud Volumes attached to the server and their device paths.
+	// +optional
+	Volumes []HCloudVolumeStatus `json:"volumes,omitempty"`
+
 	// FailureReason will be set in the event that there is a terminal problem
 	// reconciling the Machine and will contain a succinct value suitable
 	// for machine interpretation.
@@ -70,9 +70,47 @@ func (r *HCloudMachine) ValidateCreate() (admission.Warnings, error) {
 	hcloudmachinelog.V(1).Info("validate create", "name", r.Name)
 	var allErrs field.ErrorList
 
+	// Volume names have to be unique, as they identify the HCloud Volumes of the machine
+	volumeNames := make(map[string]struct{}, len(r.Spec.Volumes))
+	for i, volume := range r.Spec.Volumes {
+		if _, found := volumeNames[volume.Name]; found {
+			allErrs = append(allErrs, field.Duplicate(field.NewPath("spec", "volumes").Index(i).Child("name"), volume.Name))
+		}
+		volumeNames[volume.Name] = struct{}{}
+	}
+
+	allErrs = append(allErrs, validateImage(&r.Spec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateHCloudLabels(r.Spec.HCloudLabels, field.NewPath("spec", "hcloudLabels"))...)
+
 	return nil, aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
 
+// validateImage checks that the image of an HCloudMachine is specified either by name or by selector.
+func validateImage(spec *HCloudMachineSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if (spec.ImageName == "") == (spec.ImageSelector == nil) {
+		allErrs = append(allErrs,
+			field.Invalid(path.Child("imageName"), spec.ImageName, "either imageName or imageSelector has to be set"),
+		)
+	}
+
+	if selector := spec.ImageSelector; selector != nil && selector.ID == nil {
+		if selector.LabelSelector == "" {
+			allErrs = append(allErrs,
+				field.Required(path.Child("imageSelector", "labelSelector"), "either id or labelSelector has to be set"),
+			)
+		}
+		if selector.Policy == ImageSelectionPolicyHighestSemver && selector.SemverLabel == "" {
+			allErrs = append(allErrs,
+				field.Required(path.Child("imageSelector", "semverLabel"), "semverLabel is required for policy HighestSemver"),
+			)
+		}
+	}
+
+	return allErrs
+}
+
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
 func (r *HCloudMachine) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	hcloudmachinelog.V(1).Info("validate update", "name", r.Name)
@@ -98,6 +136,13 @@ func (r *HCloudMachine) ValidateUpdate(old runtime.Object) (admission.Warnings,
 		)
 	}
 
+	// ImageSelector is immutable
+	if !reflect.DeepEqual(oldM.Spec.ImageSelector, r.Spec.ImageSelector) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "imageSelector"), r.Spec.ImageSelector, "field is immutable"),
+		)
+	}
+
 	// SSHKeys is immutable
 	if !reflect.DeepEqual(oldM.Spec.SSHKeys, r.Spec.SSHKeys) {
 		allErrs = append(allErrs,
@@ -112,6 +157,15 @@ func (r *HCloudMachine) ValidateUpdate(old runtime.Object) (admission.Warnings,
 		)
 	}
 
+	// Volumes are immutable
+	if !reflect.DeepEqual(oldM.Spec.Volumes, r.Spec.Volumes) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "volumes"), r.Spec.Volumes, "field is immutable"),
+		)
+	}
+
+	allErrs = append(allErrs, validateHCloudLabels(r.Spec.HCloudLabels, field.NewPath("spec", "hcloudLabels"))...)
+
 	return nil, aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
 
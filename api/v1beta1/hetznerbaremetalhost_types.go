@@ -86,6 +86,10 @@ const (
 	// ErrorTypeHardwareRebootTriggered is an error condition that the hardware reboot is triggered.
 	ErrorTypeHardwareRebootTriggered ErrorType = "hardware reboot triggered"
 
+	// ErrorTypeDisksUnlocked is an error condition that the encrypted partitions have been unlocked in the initramfs and
+	// the operating system is booting.
+	ErrorTypeDisksUnlocked ErrorType = "disks unlocked"
+
 	// ErrorTypeConnectionError ErrorType is an error condition indicating that ssh command returned a connection refused error.
 	ErrorTypeConnectionError ErrorType = "connection refused error of SSH command"
 
@@ -114,6 +118,10 @@ const (
 	ErrorMessageMissingRescueSSHSecret string = "could not find RescueSSHSecret"
 	// ErrorMessageMissingOSSSHSecret specifies the error message when no OSSSH secret was found.
 	ErrorMessageMissingOSSSHSecret string = "could not find OSSSHSecret"
+	// ErrorMessageMissingSSHBastionSecret specifies the error message when no SSH bastion secret was found.
+	ErrorMessageMissingSSHBastionSecret string = "could not find SSHBastionSecret"
+	// ErrorMessageMissingEncryptionSecret specifies the error message when no encryption secret was found.
+	ErrorMessageMissingEncryptionSecret string = "could not find EncryptionSecret"
 	// ErrorMessageMissingOrInvalidSecretData specifies the error message when no data in secret is missing or invalid.
 	ErrorMessageMissingOrInvalidSecretData string = "invalid or not specified information in secret"
 )
@@ -146,6 +154,9 @@ const (
 	// StateDeprovisioning means we are removing all machine-specific information from host.
 	StateDeprovisioning ProvisioningState = "deprovisioning"
 
+	// StateWipingDisks means we are erasing all disks of the host in the rescue system after deprovisioning.
+	StateWipingDisks ProvisioningState = "wiping-disks"
+
 	// StateDeleting means we are deleting the host.
 	StateDeleting ProvisioningState = "deleting"
 )
@@ -192,6 +203,21 @@ type HetznerBareMetalHostSpec struct {
 	// +optional
 	Description string `json:"description,omitempty"`
 
+	// PreferenceScore is used to choose between hosts that the host selection policy of a
+	// HetznerBareMetalMachine considers equally suitable. Hosts with a higher score are chosen first.
+	// +optional
+	PreferenceScore int `json:"preferenceScore,omitempty"`
+
+	// SSHBastion is a bastion host that SSH connections to the server are tunneled through. It overrides the
+	// bastion host of the HetznerCluster.
+	// +optional
+	SSHBastion *SSHBastionSpec `json:"sshBastion,omitempty"`
+
+	// WipeDisks enables a secure erase of all disks of the host when it is deprovisioned. The host boots
+	// the rescue system to wipe its disks and only returns to the pool when all of them have been wiped.
+	// +optional
+	WipeDisks bool `json:"wipeDisks,omitempty"`
+
 	// Status contains all status information. DO NOT EDIT!!!
 	// +optional
 	Status ControllerGeneratedStatus `json:"status,omitempty"`
@@ -231,10 +257,19 @@ type ControllerGeneratedStatus struct {
 	// SSHSpec defines specs for SSH.
 	SSHSpec *SSHSpec `json:"sshSpec,omitempty"`
 
+	// RobotVSwitch is the vSwitch of the cluster the host has been added to. Its VLAN has to be
+	// configured on the host, it is available as "vswitch_vlan" in the cloud-init meta data.
+	// +optional
+	RobotVSwitch *RobotVSwitchStatus `json:"robotVSwitch,omitempty"`
+
 	// HetznerRobotSSHKey contains name and fingerprint of the in HetznerCluster spec specified SSH key.
 	// +optional
 	SSHStatus SSHStatus `json:"sshStatus,omitempty"`
 
+	// DiskWipe tracks the wipe of the disks of the host after the last deprovisioning.
+	// +optional
+	DiskWipe *DiskWipeStatus `json:"diskWipe,omitempty"`
+
 	// ErrorType indicates the type of failure encountered when the
 	// OperationalStatus is OperationalStatusError
 	// +optional
@@ -256,6 +291,11 @@ type ControllerGeneratedStatus struct {
 	// +optional
 	LastUpdated *metav1.Time `json:"lastUpdated,omitempty"`
 
+	// LastReleased is the time when the host has last been released by a HetznerBareMetalMachine.
+	// It is used by the LeastRecentlyUsed host selection strategy.
+	// +optional
+	LastReleased *metav1.Time `json:"lastReleased,omitempty"`
+
 	// Rebooted shows whether the server is currently being rebooted.
 	Rebooted bool `json:"rebooted,omitempty"`
 
@@ -282,6 +322,74 @@ func (host *HetznerBareMetalHost) SetConditions(conditions clusterv1.Conditions)
 	host.Spec.Status.Conditions = conditions
 }
 
+// DiskWipePhase is the phase of a disk wipe.
+type DiskWipePhase string
+
+const (
+	// DiskWipePhasePending means that the host has not been rebooted into the rescue system yet.
+	DiskWipePhasePending DiskWipePhase = ""
+	// DiskWipePhaseRebooting means that the host reboots into the rescue system.
+	DiskWipePhaseRebooting DiskWipePhase = "rebooting"
+	// DiskWipePhaseWiping means that the disks are wiped in the rescue system.
+	DiskWipePhaseWiping DiskWipePhase = "wiping"
+	// DiskWipePhaseSucceeded means that all disks have been wiped.
+	DiskWipePhaseSucceeded DiskWipePhase = "succeeded"
+)
+
+// DiskWipeState is the state of the wipe of a single disk.
+type DiskWipeState string
+
+const (
+	// DiskWipeStatePending means that the wipe of the disk has not started yet.
+	DiskWipeStatePending DiskWipeState = "pending"
+	// DiskWipeStateRunning means that the disk is being wiped.
+	DiskWipeStateRunning DiskWipeState = "running"
+	// DiskWipeStateWiped means that the disk has been wiped.
+	DiskWipeStateWiped DiskWipeState = "wiped"
+	// DiskWipeStateFailed means that none of the wipe methods succeeded for the disk.
+	DiskWipeStateFailed DiskWipeState = "failed"
+)
+
+// DiskWipeStatus contains the status of the wipe of the disks of a host.
+type DiskWipeStatus struct {
+	// Phase of the disk wipe.
+	// +optional
+	Phase DiskWipePhase `json:"phase,omitempty"`
+
+	// FailedAttempts is the number of attempts that failed. Every attempt boots a fresh rescue system.
+	// +optional
+	FailedAttempts int `json:"failedAttempts,omitempty"`
+
+	// StartedAt is the time the wipe has been started in the rescue system.
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+
+	// FinishedAt is the time all disks have been wiped.
+	// +optional
+	FinishedAt *metav1.Time `json:"finishedAt,omitempty"`
+
+	// Disks are the disks that are wiped.
+	// +optional
+	Disks []WipedDisk `json:"disks,omitempty"`
+}
+
+// WipedDisk contains the status of the wipe of a single disk.
+type WipedDisk struct {
+	// Name is the Linux device name of the disk in the rescue system, e.g. "sda".
+	Name string `json:"name"`
+
+	// WWN of the disk.
+	// +optional
+	WWN string `json:"wwn,omitempty"`
+
+	// State of the wipe of the disk.
+	State DiskWipeState `json:"state"`
+
+	// Method that wiped the disk: "nvme-format", "blkdiscard" or "overwrite".
+	// +optional
+	Method string `json:"method,omitempty"`
+}
+
 // SSHStatus contains all status information about SSHStatus.
 type SSHStatus struct {
 	// CurrentRescue gives information about the secret where the rescue ssh key is stored.
@@ -292,6 +400,15 @@ type SSHStatus struct {
 	OSKey *SSHKey `json:"osKey,omitempty"`
 	// RescueKey contains name and fingerprint of the in HetznerCluster spec specified SSH key.
 	RescueKey *SSHKey `json:"rescueKey,omitempty"`
+	// RescueHostKey is the SSH host key of the rescue system in authorized_keys format. It is recorded on first
+	// use after the rescue system has been activated and verified on all further connections to the rescue system.
+	// +optional
+	RescueHostKey string `json:"rescueHostKey,omitempty"`
+	// OSHostKey is the SSH host key of the installed operating system in authorized_keys format. It is generated
+	// by the controller and installed together with the image, so that it is known before the first boot. Remove
+	// it to trust the host key of the operating system on first use again.
+	// +optional
+	OSHostKey string `json:"osHostKey,omitempty"`
 }
 
 // SecretStatus contains the reference and version of the last secret that was used.
@@ -369,6 +486,17 @@ type Storage struct {
 	Rota bool `json:"rota,omitempty"`
 }
 
+// DiskType returns the type of the storage device. NVMe devices are recognized by their name.
+func (s Storage) DiskType() DiskType {
+	if s.Rota {
+		return DiskTypeHDD
+	}
+	if strings.HasPrefix(strings.TrimPrefix(s.Name, "/dev/"), "nvme") {
+		return DiskTypeNVMe
+	}
+	return DiskTypeSSD
+}
+
 // NIC describes one network interface on the host.
 type NIC struct {
 	// The name of the network interface, e.g. "en0"
@@ -561,6 +689,17 @@ func isRebootAnnotation(annotation string) bool {
 	return strings.HasPrefix(annotation, RebootAnnotation+"/") || annotation == RebootAnnotation
 }
 
+// HasReprovisionAnnotation checks whether the host has the reprovision annotation.
+func (host *HetznerBareMetalHost) HasReprovisionAnnotation() bool {
+	_, found := host.GetAnnotations()[ReprovisionAnnotation]
+	return found
+}
+
+// ClearReprovisionAnnotation deletes the reprovision annotation from the host.
+func (host *HetznerBareMetalHost) ClearReprovisionAnnotation() {
+	delete(host.Annotations, ReprovisionAnnotation)
+}
+
 //+kubebuilder:object:root=true
 
 // HetznerBareMetalHostList contains a list of HetznerBareMetalHost.
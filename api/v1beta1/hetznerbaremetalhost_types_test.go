@@ -178,6 +178,18 @@ var _ = Describe("Test RootDeviceHints.ListOfWWN", func() {
 	)
 })
 
+var _ = Describe("Test Storage.DiskType", func() {
+	DescribeTable("Test Storage.DiskType",
+		func(storage Storage, expectDiskType DiskType) {
+			Expect(storage.DiskType()).Should(Equal(expectDiskType))
+		},
+		Entry("nvme", Storage{Name: "nvme0n1"}, DiskTypeNVMe),
+		Entry("nvme with device path", Storage{Name: "/dev/nvme0n1"}, DiskTypeNVMe),
+		Entry("ssd", Storage{Name: "sda"}, DiskTypeSSD),
+		Entry("hdd", Storage{Name: "sda", Rota: true}, DiskTypeHDD),
+	)
+})
+
 var _ = Describe("Test HasSoftwareReboot", func() {
 	type testCaseHasSoftwareReboot struct {
 		rebootTypes []RebootType
@@ -485,3 +497,31 @@ var _ = Describe("Test ClearRebootAnnotations", func() {
 		}),
 	)
 })
+
+var _ = Describe("Test HasReprovisionAnnotation", func() {
+	type testCaseHasReprovisionAnnotation struct {
+		annotations map[string]string
+		expectBool  bool
+	}
+
+	DescribeTable("Test HasReprovisionAnnotation",
+		func(tc testCaseHasReprovisionAnnotation) {
+			host := HetznerBareMetalHost{}
+			host.SetAnnotations(tc.annotations)
+
+			Expect(host.HasReprovisionAnnotation()).Should(Equal(tc.expectBool))
+		},
+		Entry("has reprovision annotation", testCaseHasReprovisionAnnotation{
+			annotations: map[string]string{"other": "annotation", ReprovisionAnnotation: ""},
+			expectBool:  true,
+		}),
+		Entry("has only reboot annotation", testCaseHasReprovisionAnnotation{
+			annotations: map[string]string{RebootAnnotation: "reboot"},
+			expectBool:  false,
+		}),
+		Entry("has no annotations", testCaseHasReprovisionAnnotation{
+			annotations: nil,
+			expectBool:  false,
+		}),
+	)
+})
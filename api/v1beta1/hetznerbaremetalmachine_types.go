@@ -76,6 +76,10 @@ type HetznerBareMetalMachineSpec struct {
 	// +optional
 	HostSelector HostSelector `json:"hostSelector,omitempty"`
 
+	// HostSelectionPolicy defines how a host is chosen among all available hosts that match the HostSelector.
+	// +optional
+	HostSelectionPolicy HostSelectionPolicy `json:"hostSelectionPolicy,omitempty"`
+
 	// SSHSpec gives a reference on the secret where SSH details are specified as well as ports for ssh.
 	SSHSpec SSHSpec `json:"sshSpec,omitempty"`
 }
@@ -91,6 +95,11 @@ type HostSelector struct {
 	// Label match expressions that must be true on a chosen BareMetalHost
 	// +optional
 	MatchExpressions []HostSelectorRequirement `json:"matchExpressions,omitempty"`
+
+	// HardwareRequirements are requirements on the hardware details of a chosen BareMetalHost.
+	// Hosts without hardware details never match if requirements are set.
+	// +optional
+	HardwareRequirements *HardwareRequirements `json:"hardwareRequirements,omitempty"`
 }
 
 // HostSelectorRequirement defines a requirement used for MatchExpressions to select host machines.
@@ -100,6 +109,153 @@ type HostSelectorRequirement struct {
 	Values   []string           `json:"values"`
 }
 
+// HardwareRequirements defines requirements on the hardware details of a host. All of them have to be met.
+type HardwareRequirements struct {
+	// MinRAMGB is the minimum memory of the host in GB.
+	// +optional
+	MinRAMGB int `json:"minRAMGB,omitempty"`
+
+	// MinCPUCores is the minimum number of CPU cores of the host.
+	// +optional
+	MinCPUCores int `json:"minCPUCores,omitempty"`
+
+	// MinCPUThreads is the minimum number of CPU threads of the host.
+	// +optional
+	MinCPUThreads int `json:"minCPUThreads,omitempty"`
+
+	// CPUArch is the CPU architecture of the host as reported by lscpu, e.g. x86_64 or aarch64.
+	// +optional
+	CPUArch string `json:"cpuArch,omitempty"`
+
+	// Disks are requirements on the storage devices of the host.
+	// +optional
+	Disks []DiskRequirement `json:"disks,omitempty"`
+
+	// MinNICSpeedMbps is the minimum speed of at least one NIC of the host in Mbps.
+	// +optional
+	MinNICSpeedMbps int `json:"minNICSpeedMbps,omitempty"`
+}
+
+// DiskType defines the type of a storage device.
+type DiskType string
+
+const (
+	// DiskTypeNVMe is a NVMe SSD.
+	DiskTypeNVMe DiskType = "NVMe"
+	// DiskTypeSSD is a SSD that is not connected via NVMe.
+	DiskTypeSSD DiskType = "SSD"
+	// DiskTypeHDD is a rotational disk.
+	DiskTypeHDD DiskType = "HDD"
+)
+
+// DiskRequirement requires a number of storage devices of a certain type and size.
+type DiskRequirement struct {
+	// Type of the storage devices. If not set, any type matches.
+	// +kubebuilder:validation:Enum=NVMe;SSD;HDD
+	// +optional
+	Type DiskType `json:"type,omitempty"`
+
+	// MinSizeGB is the minimum size of each of the storage devices in GB.
+	// +optional
+	MinSizeGB int `json:"minSizeGB,omitempty"`
+
+	// Count is the minimum number of storage devices that match type and size. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Count int `json:"count,omitempty"`
+}
+
+// Matches returns whether the hardware details fulfil all requirements. If there are no requirements,
+// every host matches. If there are requirements, hosts without hardware details do not match.
+func (r *HardwareRequirements) Matches(details *HardwareDetails) bool {
+	if r == nil {
+		return true
+	}
+	if details == nil {
+		return false
+	}
+
+	if details.RAMGB < r.MinRAMGB {
+		return false
+	}
+	if details.CPU.Cores < r.MinCPUCores {
+		return false
+	}
+	if details.CPU.Threads < r.MinCPUThreads {
+		return false
+	}
+	if r.CPUArch != "" && !strings.EqualFold(details.CPU.Arch, r.CPUArch) {
+		return false
+	}
+
+	for _, disk := range r.Disks {
+		if !disk.matches(details.Storage) {
+			return false
+		}
+	}
+
+	if r.MinNICSpeedMbps > 0 {
+		var found bool
+		for _, nic := range details.NIC {
+			if nic.SpeedMbps >= r.MinNICSpeedMbps {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (r DiskRequirement) matches(storage []Storage) bool {
+	count := r.Count
+	if count == 0 {
+		count = 1
+	}
+
+	var matching int
+	for _, s := range storage {
+		if r.Type != "" && s.DiskType() != r.Type {
+			continue
+		}
+		if int(s.SizeGB) < r.MinSizeGB {
+			continue
+		}
+		matching++
+	}
+	return matching >= count
+}
+
+// HostSelectionStrategy defines the strategy used to choose a host.
+type HostSelectionStrategy string
+
+const (
+	// HostSelectionStrategyRandom chooses a random host.
+	HostSelectionStrategyRandom HostSelectionStrategy = "Random"
+	// HostSelectionStrategySpread chooses a host in the topology domain with the fewest hosts of the cluster.
+	HostSelectionStrategySpread HostSelectionStrategy = "Spread"
+	// HostSelectionStrategyLeastRecentlyUsed chooses the host that has been released the longest time ago.
+	HostSelectionStrategyLeastRecentlyUsed HostSelectionStrategy = "LeastRecentlyUsed"
+	// HostSelectionStrategySmallestFit chooses the host with the least CPU threads and memory.
+	HostSelectionStrategySmallestFit HostSelectionStrategy = "SmallestFit"
+)
+
+// HostSelectionPolicy defines how a host is chosen among all available hosts. Hosts that the strategy
+// considers equally suitable are ordered by their preference score, remaining ties are broken randomly.
+type HostSelectionPolicy struct {
+	// Strategy is the strategy used to choose a host. Defaults to Random.
+	// +kubebuilder:validation:Enum=Random;Spread;LeastRecentlyUsed;SmallestFit
+	// +optional
+	Strategy HostSelectionStrategy `json:"strategy,omitempty"`
+
+	// TopologyKey is the key of the HetznerBareMetalHost label that defines the topology domain,
+	// e.g. a datacenter or a rack. It is required for the Spread strategy.
+	// +optional
+	TopologyKey string `json:"topologyKey,omitempty"`
+}
+
 // SSHSpec defines specs for SSH.
 type SSHSpec struct {
 	// SecretRef gives reference to the secret.
@@ -159,6 +315,70 @@ type InstallImage struct {
 	// +kubebuilder:default=1
 	// +kubebuilder:validation:Enum=0;1;5;6;10;
 	SwraidLevel int `json:"swraidLevel,omitempty"`
+
+	// Encryption encrypts all partitions except /boot with LUKS. It requires a separate /boot partition.
+	// +optional
+	Encryption *DiskEncryption `json:"encryption,omitempty"`
+}
+
+// DiskUnlockMethod defines how the encrypted partitions are unlocked at boot.
+type DiskUnlockMethod string
+
+const (
+	// DiskUnlockMethodDropbear means that the controller unlocks the partitions through an SSH server in the initramfs.
+	DiskUnlockMethodDropbear DiskUnlockMethod = "dropbear"
+	// DiskUnlockMethodTPM means that clevis unlocks the partitions with the TPM of the server. If that fails, the
+	// controller unlocks them through the SSH server in the initramfs.
+	DiskUnlockMethodTPM DiskUnlockMethod = "tpm"
+)
+
+// DefaultDropbearPort is the default port of the SSH server in the initramfs.
+const DefaultDropbearPort = 2222
+
+// DiskEncryption defines the LUKS encryption of the partitions.
+type DiskEncryption struct {
+	// SecretRef references the secret that contains the passphrase of the encrypted partitions.
+	SecretRef EncryptionSecretRef `json:"secretRef"`
+
+	// Unlock defines how the encrypted partitions are unlocked at boot.
+	// +optional
+	// +kubebuilder:default=dropbear
+	// +kubebuilder:validation:Enum=dropbear;tpm
+	Unlock DiskUnlockMethod `json:"unlock,omitempty"`
+
+	// DropbearPort is the port of the SSH server in the initramfs. It has to differ from the SSH ports of the OS.
+	// +optional
+	// +kubebuilder:default=2222
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	DropbearPort int `json:"dropbearPort,omitempty"`
+}
+
+// GetDropbearPort returns the port of the SSH server in the initramfs.
+func (encryption *DiskEncryption) GetDropbearPort() int {
+	if encryption.DropbearPort == 0 {
+		return DefaultDropbearPort
+	}
+	return encryption.DropbearPort
+}
+
+// EncryptionSecretRef defines the secret that contains the passphrase of the encrypted partitions.
+type EncryptionSecretRef struct {
+	// Name of the secret.
+	Name string `json:"name"`
+
+	// Key of the passphrase in the secret. The passphrase must not contain whitespace.
+	// +optional
+	// +kubebuilder:default=passphrase
+	Key string `json:"key,omitempty"`
+}
+
+// GetKey returns the key of the passphrase in the secret.
+func (ref EncryptionSecretRef) GetKey() string {
+	if ref.Key == "" {
+		return "passphrase"
+	}
+	return ref.Key
 }
 
 // Image defines the properties for the autosetup config.
@@ -235,3 +235,91 @@ var _ = Describe("Test HasHostAnnotation", func() {
 		}),
 	)
 })
+
+var _ = Describe("Test HardwareRequirements.Matches", func() {
+	details := &HardwareDetails{
+		RAMGB: 64,
+		CPU: CPU{
+			Arch:    "x86_64",
+			Cores:   8,
+			Threads: 16,
+		},
+		Storage: []Storage{
+			{Name: "nvme0n1", SizeGB: 512},
+			{Name: "nvme1n1", SizeGB: 512},
+			{Name: "sda", SizeGB: 4000, Rota: true},
+		},
+		NIC: []NIC{
+			{Name: "eth0", SpeedMbps: 1000},
+		},
+	}
+
+	type testCaseHardwareRequirementsMatches struct {
+		requirements *HardwareRequirements
+		details      *HardwareDetails
+		expectBool   bool
+	}
+
+	DescribeTable("Test HardwareRequirements.Matches",
+		func(tc testCaseHardwareRequirementsMatches) {
+			Expect(tc.requirements.Matches(tc.details)).Should(Equal(tc.expectBool))
+		},
+		Entry("no requirements", testCaseHardwareRequirementsMatches{
+			requirements: nil,
+			details:      nil,
+			expectBool:   true,
+		}),
+		Entry("no hardware details", testCaseHardwareRequirementsMatches{
+			requirements: &HardwareRequirements{MinRAMGB: 32},
+			details:      nil,
+			expectBool:   false,
+		}),
+		Entry("all requirements met", testCaseHardwareRequirementsMatches{
+			requirements: &HardwareRequirements{
+				MinRAMGB:        64,
+				MinCPUCores:     8,
+				MinCPUThreads:   16,
+				CPUArch:         "X86_64",
+				Disks:           []DiskRequirement{{Type: DiskTypeNVMe, MinSizeGB: 500, Count: 2}, {Type: DiskTypeHDD}},
+				MinNICSpeedMbps: 1000,
+			},
+			details:    details,
+			expectBool: true,
+		}),
+		Entry("too little RAM", testCaseHardwareRequirementsMatches{
+			requirements: &HardwareRequirements{MinRAMGB: 128},
+			details:      details,
+			expectBool:   false,
+		}),
+		Entry("too few cores", testCaseHardwareRequirementsMatches{
+			requirements: &HardwareRequirements{MinCPUCores: 16},
+			details:      details,
+			expectBool:   false,
+		}),
+		Entry("wrong architecture", testCaseHardwareRequirementsMatches{
+			requirements: &HardwareRequirements{CPUArch: "aarch64"},
+			details:      details,
+			expectBool:   false,
+		}),
+		Entry("too few large NVMe disks", testCaseHardwareRequirementsMatches{
+			requirements: &HardwareRequirements{Disks: []DiskRequirement{{Type: DiskTypeNVMe, MinSizeGB: 1000}}},
+			details:      details,
+			expectBool:   false,
+		}),
+		Entry("no SSD", testCaseHardwareRequirementsMatches{
+			requirements: &HardwareRequirements{Disks: []DiskRequirement{{Type: DiskTypeSSD}}},
+			details:      details,
+			expectBool:   false,
+		}),
+		Entry("any disk type", testCaseHardwareRequirementsMatches{
+			requirements: &HardwareRequirements{Disks: []DiskRequirement{{MinSizeGB: 500, Count: 3}}},
+			details:      details,
+			expectBool:   true,
+		}),
+		Entry("NIC too slow", testCaseHardwareRequirementsMatches{
+			requirements: &HardwareRequirements{MinNICSpeedMbps: 10000},
+			details:      details,
+			expectBool:   false,
+		}),
+	)
+})
@@ -73,6 +73,10 @@ func (bmMachine *HetznerBareMetalMachine) ValidateCreate() (admission.Warnings,
 		}
 	}
 
+	if encryption := bmMachine.Spec.InstallImage.Encryption; encryption != nil {
+		allErrs = append(allErrs, validateEncryption(encryption, bmMachine.Spec.InstallImage.Partitions, bmMachine.Spec.SSHSpec)...)
+	}
+
 	// validate host selector
 	for labelKey, labelVal := range bmMachine.Spec.HostSelector.MatchLabels {
 		if _, err := labels.NewRequirement(labelKey, selection.Equals, []string{labelVal}); err != nil {
@@ -92,9 +96,42 @@ func (bmMachine *HetznerBareMetalMachine) ValidateCreate() (admission.Warnings,
 		}
 	}
 
+	if bmMachine.Spec.HostSelectionPolicy.Strategy == HostSelectionStrategySpread && bmMachine.Spec.HostSelectionPolicy.TopologyKey == "" {
+		allErrs = append(allErrs, field.Required(
+			field.NewPath("spec", "hostSelectionPolicy", "topologyKey"),
+			"topologyKey is required for the Spread strategy",
+		))
+	}
+
 	return nil, aggregateObjErrors(bmMachine.GroupVersionKind().GroupKind(), bmMachine.Name, allErrs)
 }
 
+func validateEncryption(encryption *DiskEncryption, partitions []Partition, sshSpec SSHSpec) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// the kernel and the initramfs that unlock the partitions cannot be encrypted
+	hasBootPartition := false
+	for _, partition := range partitions {
+		if partition.Mount == "/boot" {
+			hasBootPartition = true
+		}
+	}
+	if !hasBootPartition {
+		allErrs = append(allErrs, field.Invalid(
+			field.NewPath("spec", "installImage", "partitions"), partitions,
+			"encryption requires a separate /boot partition",
+		))
+	}
+
+	if port := encryption.GetDropbearPort(); port == sshSpec.PortAfterInstallImage || port == sshSpec.PortAfterCloudInit {
+		allErrs = append(allErrs, field.Invalid(
+			field.NewPath("spec", "installImage", "encryption", "dropbearPort"), port,
+			"dropbearPort has to differ from the ssh ports of the operating system",
+		))
+	}
+	return allErrs
+}
+
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
 func (bmMachine *HetznerBareMetalMachine) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	var allErrs field.ErrorList
@@ -17,7 +17,9 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 // HetznerBareMetalMachineTemplateSpec defines the desired state of HetznerBareMetalMachineTemplate.
@@ -25,9 +27,48 @@ type HetznerBareMetalMachineTemplateSpec struct {
 	Template HetznerBareMetalMachineTemplateResource `json:"template"`
 }
 
+// HetznerBareMetalMachineTemplateStatus defines the observed state of HetznerBareMetalMachineTemplate.
+type HetznerBareMetalMachineTemplateStatus struct {
+	// Capacity defines the resource capacity for this machine. It is computed from the hardware details
+	// of the smallest unclaimed HetznerBareMetalHost that matches the host selector of the template.
+	// This value is used for autoscaling from zero operations as defined in:
+	// https://github.com/kubernetes-sigs/cluster-api/blob/main/docs/proposals/20210310-opt-in-autoscaling-from-zero.md
+	// +optional
+	Capacity corev1.ResourceList `json:"capacity,omitempty"`
+
+	// NodeInfo contains information about the node that is created from this template.
+	// +optional
+	NodeInfo *NodeInfo `json:"nodeInfo,omitempty"`
+
+	// AvailableHosts is the number of unclaimed HetznerBareMetalHosts that match the host selector of the template.
+	// +optional
+	AvailableHosts int `json:"availableHosts"`
+
+	// Conditions defines current service state of the HetznerBareMetalMachineTemplate.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+
+	// OwnerType is the type of object that owns the HetznerBareMetalMachineTemplate.
+	// +optional
+	OwnerType string `json:"ownerType,omitempty"`
+}
+
+// NodeInfo contains information about the node that is created from a machine template.
+type NodeInfo struct {
+	// Architecture is the CPU architecture of the node in the format of the kubernetes.io/arch label, e.g. amd64 or arm64.
+	// +optional
+	Architecture string `json:"architecture,omitempty"`
+
+	// OperatingSystem is the operating system of the node in the format of the kubernetes.io/os label.
+	// +optional
+	OperatingSystem string `json:"operatingSystem,omitempty"`
+}
+
 // HetznerBareMetalMachineTemplate is the Schema for the hetznerbaremetalmachinetemplates API.
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Available",type="integer",JSONPath=".status.availableHosts",description="Number of available hosts matching the host selector"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of HetznerBareMetalMachineTemplate"
 // +kubebuilder:resource:path=hetznerbaremetalmachinetemplates,scope=Namespaced,categories=cluster-api,shortName=hbmt;hbmmtemplate;hetznerbaremetalmachinetemplates;hetznerbaremetalmachinetemplate
 // +kubebuilder:storageversion
@@ -38,6 +79,18 @@ type HetznerBareMetalMachineTemplate struct {
 
 	// +optional
 	Spec HetznerBareMetalMachineTemplateSpec `json:"spec,omitempty"`
+	// +optional
+	Status HetznerBareMetalMachineTemplateStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the observations of the operational state of the HetznerBareMetalMachineTemplate resource.
+func (r *HetznerBareMetalMachineTemplate) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the HetznerBareMetalMachineTemplate to the predescribed clusterv1.Conditions.
+func (r *HetznerBareMetalMachineTemplate) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
 }
 
 //+kubebuilder:object:root=true
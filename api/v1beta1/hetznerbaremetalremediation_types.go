@@ -27,6 +27,9 @@ const (
 
 	// RebootAnnotation indicates that a bare metal host object should be rebooted.
 	RebootAnnotation = "reboot.hetznerbaremetalhost.infrastructure.cluster.x-k8s.io"
+
+	// ReprovisionAnnotation indicates that a bare metal host object should be provisioned again.
+	ReprovisionAnnotation = "reprovision.hetznerbaremetalhost.infrastructure.cluster.x-k8s.io"
 )
 
 // HetznerBareMetalRemediationSpec defines the desired state of HetznerBareMetalRemediation.
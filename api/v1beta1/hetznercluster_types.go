@@ -41,6 +41,12 @@ type HetznerClusterSpec struct {
 
 	// SSHKeys are cluster wide. Valid values are a valid SSH key name.
 	SSHKeys HetznerSSHKeys `json:"sshKeys"`
+
+	// SSHBastion is a bastion host that all SSH connections to the bare metal servers of the cluster are
+	// tunneled through. It can be overridden per HetznerBareMetalHost.
+	// +optional
+	SSHBastion *SSHBastionSpec `json:"sshBastion,omitempty"`
+
 	// ControlPlaneEndpoint represents the endpoint used to communicate with the control plane.
 	// +optional
 	ControlPlaneEndpoint *clusterv1.APIEndpoint `json:"controlPlaneEndpoint"`
@@ -51,9 +57,28 @@ type HetznerClusterSpec struct {
 	// +optional
 	HCloudPlacementGroups []HCloudPlacementGroupSpec `json:"hcloudPlacementGroups,omitempty"`
 
+	// HCloudFirewalls are created and owned by the cluster and applied to its HCloud servers.
+	// +optional
+	HCloudFirewalls []HCloudFirewallSpec `json:"hcloudFirewalls,omitempty"`
+
+	// RobotVSwitch is a Robot vSwitch that is created and owned by the cluster. It is coupled with the HCloud
+	// Private Network and all bare metal hosts of the cluster are added to it when they are provisioned.
+	// +optional
+	RobotVSwitch *RobotVSwitchSpec `json:"robotVSwitch,omitempty"`
+
 	// HetznerSecretRef is a reference to a token to be used when reconciling this cluster.
 	// This is generated in the security section under API TOKENS. Read & write is necessary.
 	HetznerSecret HetznerSecretRef `json:"hetznerSecretRef"`
+
+	// TargetSecret defines the secret in the kube-system namespace of the workload cluster that is kept
+	// in sync with the HetznerSecret, e.g. for the cloud controller manager and the CSI driver.
+	// +optional
+	TargetSecret TargetSecretSpec `json:"targetSecret,omitempty"`
+
+	// HCloudLabels are added to all HCloud servers, load balancers, networks and placement groups of the
+	// cluster. They are kept in sync on existing resources.
+	// +optional
+	HCloudLabels map[string]string `json:"hcloudLabels,omitempty"`
 }
 
 // HetznerClusterStatus defines the observed state of HetznerCluster.
@@ -66,9 +91,18 @@ type HetznerClusterStatus struct {
 
 	ControlPlaneLoadBalancer *LoadBalancerStatus `json:"controlPlaneLoadBalancer,omitempty"`
 	// +optional
+	ControlPlaneFloatingIP *ControlPlaneFloatingIPStatus `json:"controlPlaneFloatingIP,omitempty"`
+	// +optional
 	HCloudPlacementGroups []HCloudPlacementGroupStatus `json:"hcloudPlacementGroups,omitempty"`
-	FailureDomains        clusterv1.FailureDomains     `json:"failureDomains,omitempty"`
-	Conditions            clusterv1.Conditions         `json:"conditions,omitempty"`
+	// +optional
+	HCloudFirewalls []HCloudFirewallStatus `json:"hcloudFirewalls,omitempty"`
+	// +optional
+	RobotVSwitch *RobotVSwitchStatus `json:"robotVSwitch,omitempty"`
+	// Credentials shows which credentials of the Hetzner secret are in use.
+	// +optional
+	Credentials    *CredentialsStatus       `json:"credentials,omitempty"`
+	FailureDomains clusterv1.FailureDomains `json:"failureDomains,omitempty"`
+	Conditions     clusterv1.Conditions     `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
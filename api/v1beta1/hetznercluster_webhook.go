@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	"fmt"
+	"net"
 	"reflect"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -91,7 +92,8 @@ func (r *HetznerCluster) ValidateCreate() (admission.Warnings, error) {
 		}
 	}
 
-	if r.Spec.ControlPlaneLoadBalancer.Enabled {
+	// the region is only needed to create a load balancer, not to use an existing one
+	if r.Spec.ControlPlaneLoadBalancer.Enabled && r.Spec.ControlPlaneLoadBalancer.ID == nil && len(r.Spec.ControlPlaneLoadBalancer.Selector) == 0 {
 		if r.Spec.ControlPlaneLoadBalancer.Region == Region("") {
 			allErrs = append(allErrs, field.Invalid(
 				field.NewPath("spec", "controlPlaneLoadBalancer", "region"),
@@ -108,8 +110,27 @@ func (r *HetznerCluster) ValidateCreate() (admission.Warnings, error) {
 		}
 	}
 
+	if r.Spec.ControlPlaneLoadBalancer.Private && !r.Spec.HCloudNetwork.Enabled {
+		allErrs = append(allErrs, field.Invalid(
+			field.NewPath("spec", "controlPlaneLoadBalancer", "private"),
+			r.Spec.ControlPlaneLoadBalancer.Private,
+			"private load balancer requires hcloudNetwork to be enabled"),
+		)
+	}
+
+	if r.Spec.ControlPlaneLoadBalancer.Enabled && r.Spec.ControlPlaneLoadBalancer.FloatingIP != nil {
+		allErrs = append(allErrs, field.Invalid(
+			field.NewPath("spec", "controlPlaneLoadBalancer", "floatingIP"),
+			r.Spec.ControlPlaneLoadBalancer.FloatingIP,
+			"floatingIP can only be used if load balancer is disabled"),
+		)
+	}
+
+	allErrs = append(allErrs, r.validateControlPlaneFloatingIP()...)
+
 	// Check whether controlPlaneEndpoint is specified if controlPlaneLoadBalancer is not enabled
-	if !r.Spec.ControlPlaneLoadBalancer.Enabled {
+	// and the endpoint is not provided by a floating IP
+	if !r.Spec.ControlPlaneLoadBalancer.Enabled && r.Spec.ControlPlaneLoadBalancer.FloatingIP == nil {
 		if r.Spec.ControlPlaneEndpoint == nil ||
 			r.Spec.ControlPlaneEndpoint.Host == "" ||
 			r.Spec.ControlPlaneEndpoint.Port == 0 {
@@ -127,6 +148,13 @@ func (r *HetznerCluster) ValidateCreate() (admission.Warnings, error) {
 		allErrs = append(allErrs, err)
 	}
 
+	allErrs = append(allErrs, r.validateHCloudNetwork()...)
+	allErrs = append(allErrs, r.validateRobotVSwitch()...)
+	allErrs = append(allErrs, r.validateHCloudFirewalls()...)
+	allErrs = append(allErrs, r.validateHCloudLabels()...)
+	allErrs = append(allErrs, r.validateLoadBalancerServices()...)
+	allErrs = append(allErrs, r.validateExistingLoadBalancer()...)
+
 	return nil, aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
 
@@ -157,8 +185,12 @@ func (r *HetznerCluster) ValidateUpdate(old runtime.Object) (admission.Warnings,
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected an HetznerCluster but got a %T", old))
 	}
 
-	// Network settings are immutable
-	if !reflect.DeepEqual(oldC.Spec.HCloudNetwork, r.Spec.HCloudNetwork) {
+	// Network settings are immutable, except for subnets and routes which are reconciled in place
+	oldNetwork := oldC.Spec.HCloudNetwork.DeepCopy()
+	newNetwork := r.Spec.HCloudNetwork.DeepCopy()
+	oldNetwork.Subnets, newNetwork.Subnets = nil, nil
+	oldNetwork.Routes, newNetwork.Routes = nil, nil
+	if !reflect.DeepEqual(oldNetwork, newNetwork) {
 		allErrs = append(allErrs,
 			field.Invalid(field.NewPath("spec", "hcloudNetwork"), r.Spec.HCloudNetwork, "field is immutable"),
 		)
@@ -196,13 +228,202 @@ func (r *HetznerCluster) ValidateUpdate(old runtime.Object) (admission.Warnings,
 		)
 	}
 
+	// The load balancer cannot be exchanged, as it is the control plane endpoint
+	if !reflect.DeepEqual(oldC.Spec.ControlPlaneLoadBalancer.ID, r.Spec.ControlPlaneLoadBalancer.ID) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "id"), r.Spec.ControlPlaneLoadBalancer.ID, "field is immutable"),
+		)
+	}
+	if !reflect.DeepEqual(oldC.Spec.ControlPlaneLoadBalancer.Selector, r.Spec.ControlPlaneLoadBalancer.Selector) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "selector"), r.Spec.ControlPlaneLoadBalancer.Selector, "field is immutable"),
+		)
+	}
+	if oldC.Spec.ControlPlaneLoadBalancer.Shared != r.Spec.ControlPlaneLoadBalancer.Shared {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "shared"), r.Spec.ControlPlaneLoadBalancer.Shared, "field is immutable"),
+		)
+	}
+
+	// The control plane endpoint depends on whether the load balancer is private, so it is immutable
+	if oldC.Spec.ControlPlaneLoadBalancer.Private != r.Spec.ControlPlaneLoadBalancer.Private {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "private"), r.Spec.ControlPlaneLoadBalancer.Private, "field is immutable"),
+		)
+	}
+
+	// The control plane endpoint depends on the floating IP, so it is immutable
+	if !reflect.DeepEqual(oldC.Spec.ControlPlaneLoadBalancer.FloatingIP, r.Spec.ControlPlaneLoadBalancer.FloatingIP) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "floatingIP"), r.Spec.ControlPlaneLoadBalancer.FloatingIP, "field is immutable"),
+		)
+	}
+
+	// The vSwitch is coupled with the network, so it is immutable as well
+	if !reflect.DeepEqual(oldC.Spec.RobotVSwitch, r.Spec.RobotVSwitch) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "robotVSwitch"), r.Spec.RobotVSwitch, "field is immutable"),
+		)
+	}
+
 	if err := r.validateHetznerSecretKey(); err != nil {
 		allErrs = append(allErrs, err)
 	}
 
+	allErrs = append(allErrs, r.validateHCloudNetwork()...)
+	allErrs = append(allErrs, r.validateRobotVSwitch()...)
+	allErrs = append(allErrs, r.validateHCloudFirewalls()...)
+	allErrs = append(allErrs, r.validateHCloudLabels()...)
+	allErrs = append(allErrs, r.validateLoadBalancerServices()...)
+	allErrs = append(allErrs, r.validateExistingLoadBalancer()...)
+
 	return nil, aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
 
+// validateHCloudLabels validates the labels of the cluster, its load balancer and its network.
+func (r *HetznerCluster) validateHCloudLabels() field.ErrorList {
+	allErrs := validateHCloudLabels(r.Spec.HCloudLabels, field.NewPath("spec", "hcloudLabels"))
+	allErrs = append(allErrs, validateHCloudLabels(
+		r.Spec.ControlPlaneLoadBalancer.HCloudLabels,
+		field.NewPath("spec", "controlPlaneLoadBalancer", "hcloudLabels"),
+	)...)
+	allErrs = append(allErrs, validateHCloudLabels(
+		r.Spec.HCloudNetwork.HCloudLabels,
+		field.NewPath("spec", "hcloudNetwork", "hcloudLabels"),
+	)...)
+	return allErrs
+}
+
+// validateLoadBalancerServices validates the services of the load balancer and the health check of the
+// API server service.
+func (r *HetznerCluster) validateLoadBalancerServices() field.ErrorList {
+	lbPath := field.NewPath("spec", "controlPlaneLoadBalancer")
+	lbSpec := r.Spec.ControlPlaneLoadBalancer
+
+	allErrs := validateLoadBalancerHealthCheck(lbSpec.APIServerHealthCheck, lbPath.Child("apiServerHealthCheck"))
+
+	listenPorts := make(map[int]struct{}, len(lbSpec.ExtraServices)+1)
+	if r.Spec.ControlPlaneEndpoint != nil && r.Spec.ControlPlaneEndpoint.Port != 0 {
+		listenPorts[int(r.Spec.ControlPlaneEndpoint.Port)] = struct{}{}
+	}
+
+	if len(lbSpec.ExtraTargetSelector) > 0 && len(lbSpec.ExtraServices) == 0 {
+		allErrs = append(allErrs, field.Required(lbPath.Child("extraServices"), "extra services are required if extraTargetSelector is set"))
+	}
+
+	for i, service := range lbSpec.ExtraServices {
+		servicePath := lbPath.Child("extraServices").Index(i)
+
+		if _, found := listenPorts[service.ListenPort]; found {
+			allErrs = append(allErrs, field.Duplicate(servicePath.Child("listenPort"), service.ListenPort))
+		}
+		listenPorts[service.ListenPort] = struct{}{}
+
+		allErrs = append(allErrs, validateLoadBalancerHealthCheck(service.HealthCheck, servicePath.Child("healthCheck"))...)
+
+		// all services are routed to all targets that pass their health check, so the health check decides
+		// whether a service reaches the control planes or the extra targets
+		if len(lbSpec.ExtraTargetSelector) > 0 {
+			if service.HealthCheck == nil {
+				allErrs = append(allErrs, field.Required(servicePath.Child("healthCheck"), "health check is required if extraTargetSelector is set"))
+			}
+			if service.DestinationPort == lbSpec.Port {
+				allErrs = append(allErrs, field.Invalid(servicePath.Child("destinationPort"), service.DestinationPort, "destination port cannot be the API server port if extraTargetSelector is set"))
+			}
+		}
+
+		if service.HTTP == nil {
+			if service.Protocol == "https" {
+				allErrs = append(allErrs, field.Required(servicePath.Child("http", "certificates"), "certificates are required for protocol https"))
+			}
+			continue
+		}
+
+		httpPath := servicePath.Child("http")
+		if service.Protocol != "http" && service.Protocol != "https" {
+			allErrs = append(allErrs, field.Invalid(httpPath, service.HTTP, "http can only be set for protocols http and https"))
+			continue
+		}
+		if service.Protocol == "http" {
+			if len(service.HTTP.Certificates) > 0 {
+				allErrs = append(allErrs, field.Invalid(httpPath.Child("certificates"), service.HTTP.Certificates, "certificates can only be set for protocol https"))
+			}
+			if service.HTTP.RedirectHTTP {
+				allErrs = append(allErrs, field.Invalid(httpPath.Child("redirectHTTP"), service.HTTP.RedirectHTTP, "redirectHTTP can only be set for protocol https"))
+			}
+		}
+		if service.Protocol == "https" && len(service.HTTP.Certificates) == 0 {
+			allErrs = append(allErrs, field.Required(httpPath.Child("certificates"), "certificates are required for protocol https"))
+		}
+
+		for j, certificate := range service.HTTP.Certificates {
+			set := 0
+			if certificate.ID != nil {
+				set++
+			}
+			if len(certificate.DomainNames) > 0 {
+				set++
+			}
+			if certificate.SecretName != "" {
+				set++
+			}
+			if set != 1 {
+				allErrs = append(allErrs, field.Invalid(
+					httpPath.Child("certificates").Index(j),
+					certificate,
+					"exactly one of id, domainNames and secretName has to be set",
+				))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateExistingLoadBalancer validates the reference of an existing load balancer.
+func (r *HetznerCluster) validateExistingLoadBalancer() field.ErrorList {
+	var allErrs field.ErrorList
+
+	lbPath := field.NewPath("spec", "controlPlaneLoadBalancer")
+	lbSpec := r.Spec.ControlPlaneLoadBalancer
+
+	if lbSpec.ID != nil && len(lbSpec.Selector) > 0 {
+		allErrs = append(allErrs, field.Invalid(lbPath.Child("selector"), lbSpec.Selector, "id and selector are mutually exclusive"))
+	}
+	if lbSpec.Name != nil && (lbSpec.ID != nil || len(lbSpec.Selector) > 0) {
+		allErrs = append(allErrs, field.Invalid(lbPath.Child("name"), lbSpec.Name, "name cannot be used together with id or selector"))
+	}
+
+	if lbSpec.Shared {
+		if lbSpec.ID == nil && len(lbSpec.Selector) == 0 {
+			allErrs = append(allErrs, field.Required(lbPath.Child("id"), "id or selector is required for a shared load balancer"))
+		}
+		// services that are not part of the spec are kept on a shared load balancer, so they could not be removed
+		if len(lbSpec.ExtraServices) > 0 {
+			allErrs = append(allErrs, field.Invalid(lbPath.Child("extraServices"), lbSpec.ExtraServices, "extraServices cannot be used with a shared load balancer"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateLoadBalancerHealthCheck(healthCheck *LoadBalancerHealthCheckSpec, path *field.Path) field.ErrorList {
+	if healthCheck == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+
+	if healthCheck.HTTP != nil && healthCheck.Protocol != LoadBalancerHealthCheckProtocolHTTP {
+		allErrs = append(allErrs, field.Invalid(path.Child("http"), healthCheck.HTTP, "http can only be set for protocol http"))
+	}
+	if healthCheck.IntervalSeconds != 0 && healthCheck.TimeoutSeconds > healthCheck.IntervalSeconds {
+		allErrs = append(allErrs, field.Invalid(path.Child("timeoutSeconds"), healthCheck.TimeoutSeconds, "timeout must not be longer than the interval"))
+	}
+
+	return allErrs
+}
+
 func (r *HetznerCluster) validateHetznerSecretKey() *field.Error {
 	// Hetzner secret key needs to contain either HCloud or Hrobot credentials
 	if r.Spec.HetznerSecret.Key.HCloudToken == "" &&
@@ -213,9 +434,158 @@ func (r *HetznerCluster) validateHetznerSecretKey() *field.Error {
 			"need to specify credentials for either HCloud or Hetzner robot",
 		)
 	}
+	// the next Robot credentials can only be used together
+	if (r.Spec.HetznerSecret.Key.NextHetznerRobotUser == "") != (r.Spec.HetznerSecret.Key.NextHetznerRobotPassword == "") {
+		return field.Invalid(
+			field.NewPath("spec", "hetznerSecret", "key"),
+			r.Spec.HetznerSecret.Key,
+			"need to specify both nextHetznerRobotUser and nextHetznerRobotPassword",
+		)
+	}
 	return nil
 }
 
+func (r *HetznerCluster) validateHCloudNetwork() field.ErrorList {
+	var allErrs field.ErrorList
+
+	networkPath := field.NewPath("spec", "hcloudNetwork")
+	network := r.Spec.HCloudNetwork
+
+	if network.ID != nil && len(network.Selector) > 0 {
+		allErrs = append(allErrs, field.Invalid(networkPath.Child("selector"), network.Selector, "id and selector are mutually exclusive"))
+	}
+
+	ipRanges := make(map[string]struct{}, len(network.Subnets))
+	for i, subnet := range network.Subnets {
+		subnetPath := networkPath.Child("subnets").Index(i)
+
+		if _, _, err := net.ParseCIDR(subnet.IPRange); err != nil {
+			allErrs = append(allErrs, field.Invalid(subnetPath.Child("ipRange"), subnet.IPRange, "invalid CIDR"))
+		}
+		if _, found := ipRanges[subnet.IPRange]; found {
+			allErrs = append(allErrs, field.Duplicate(subnetPath.Child("ipRange"), subnet.IPRange))
+		}
+		ipRanges[subnet.IPRange] = struct{}{}
+
+		if subnet.Type == HCloudNetworkSubnetTypeVSwitch && subnet.VSwitchID == 0 {
+			allErrs = append(allErrs, field.Required(subnetPath.Child("vSwitchID"), "vSwitchID is required for subnets of type vswitch"))
+		}
+		if subnet.Type != HCloudNetworkSubnetTypeVSwitch && subnet.VSwitchID != 0 {
+			allErrs = append(allErrs, field.Invalid(subnetPath.Child("vSwitchID"), subnet.VSwitchID, "vSwitchID can only be set for subnets of type vswitch"))
+		}
+	}
+
+	for i, route := range network.Routes {
+		routePath := networkPath.Child("routes").Index(i)
+
+		if _, _, err := net.ParseCIDR(route.Destination); err != nil {
+			allErrs = append(allErrs, field.Invalid(routePath.Child("destination"), route.Destination, "invalid CIDR"))
+		}
+		if net.ParseIP(route.Gateway) == nil {
+			allErrs = append(allErrs, field.Invalid(routePath.Child("gateway"), route.Gateway, "invalid IP"))
+		}
+	}
+
+	return allErrs
+}
+
+func (r *HetznerCluster) validateControlPlaneFloatingIP() field.ErrorList {
+	floatingIP := r.Spec.ControlPlaneLoadBalancer.FloatingIP
+	if floatingIP == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	floatingIPPath := field.NewPath("spec", "controlPlaneLoadBalancer", "floatingIP")
+
+	if floatingIP.Type != ControlPlaneFloatingIPTypeFailover {
+		if floatingIP.FailoverIP != "" {
+			allErrs = append(allErrs, field.Invalid(floatingIPPath.Child("failoverIP"), floatingIP.FailoverIP, "failoverIP can only be used with type failover"))
+		}
+		return allErrs
+	}
+
+	if ip := net.ParseIP(floatingIP.FailoverIP); ip == nil || ip.To4() == nil {
+		allErrs = append(allErrs, field.Invalid(floatingIPPath.Child("failoverIP"), floatingIP.FailoverIP, "failoverIP has to be an IPv4 address"))
+	}
+
+	if r.Spec.HetznerSecret.Key.HetznerRobotUser == "" || r.Spec.HetznerSecret.Key.HetznerRobotPassword == "" {
+		allErrs = append(allErrs, field.Invalid(floatingIPPath.Child("type"), floatingIP.Type, "failover IPs require credentials for Hetzner robot"))
+	}
+
+	return allErrs
+}
+
+func (r *HetznerCluster) validateRobotVSwitch() field.ErrorList {
+	if r.Spec.RobotVSwitch == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	vSwitchPath := field.NewPath("spec", "robotVSwitch")
+
+	if !r.Spec.HCloudNetwork.Enabled {
+		allErrs = append(allErrs, field.Invalid(vSwitchPath, r.Spec.RobotVSwitch, "robotVSwitch requires the HCloud network to be enabled"))
+	}
+
+	if r.Spec.HetznerSecret.Key.HetznerRobotUser == "" || r.Spec.HetznerSecret.Key.HetznerRobotPassword == "" {
+		allErrs = append(allErrs, field.Invalid(vSwitchPath, r.Spec.RobotVSwitch, "robotVSwitch requires credentials for Hetzner robot"))
+	}
+
+	if _, _, err := net.ParseCIDR(r.Spec.RobotVSwitch.IPRange); err != nil {
+		allErrs = append(allErrs, field.Invalid(vSwitchPath.Child("ipRange"), r.Spec.RobotVSwitch.IPRange, "invalid CIDR"))
+	}
+
+	for _, subnet := range r.Spec.HCloudNetwork.Subnets {
+		if subnet.IPRange == r.Spec.RobotVSwitch.IPRange {
+			allErrs = append(allErrs, field.Duplicate(vSwitchPath.Child("ipRange"), r.Spec.RobotVSwitch.IPRange))
+		}
+	}
+
+	return allErrs
+}
+
+func (r *HetznerCluster) validateHCloudFirewalls() field.ErrorList {
+	var allErrs field.ErrorList
+
+	names := make(map[string]struct{}, len(r.Spec.HCloudFirewalls))
+	for i, fw := range r.Spec.HCloudFirewalls {
+		fwPath := field.NewPath("spec", "hcloudFirewalls").Index(i)
+
+		if _, found := names[fw.Name]; found {
+			allErrs = append(allErrs, field.Duplicate(fwPath.Child("name"), fw.Name))
+		}
+		names[fw.Name] = struct{}{}
+
+		for j, rule := range fw.Rules {
+			rulePath := fwPath.Child("rules").Index(j)
+
+			if (rule.Protocol == "tcp" || rule.Protocol == "udp") && (rule.Port == nil || *rule.Port == "") {
+				allErrs = append(allErrs, field.Required(rulePath.Child("port"), "port is required for protocols tcp and udp"))
+			}
+			if rule.Direction == "in" && len(rule.SourceIPs) == 0 {
+				allErrs = append(allErrs, field.Required(rulePath.Child("sourceIPs"), "source IPs are required for inbound rules"))
+			}
+			if rule.Direction == "out" && len(rule.DestinationIPs) == 0 {
+				allErrs = append(allErrs, field.Required(rulePath.Child("destinationIPs"), "destination IPs are required for outbound rules"))
+			}
+
+			for _, cidr := range rule.SourceIPs {
+				if _, _, err := net.ParseCIDR(cidr); err != nil {
+					allErrs = append(allErrs, field.Invalid(rulePath.Child("sourceIPs"), cidr, "invalid CIDR"))
+				}
+			}
+			for _, cidr := range rule.DestinationIPs {
+				if _, _, err := net.ParseCIDR(cidr); err != nil {
+					allErrs = append(allErrs, field.Invalid(rulePath.Child("destinationIPs"), cidr, "invalid CIDR"))
+				}
+			}
+		}
+	}
+
+	return allErrs
+}
+
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
 func (r *HetznerCluster) ValidateDelete() (admission.Warnings, error) {
 	hetznerclusterlog.V(1).Info("validate delete", "name", r.Name)
@@ -24,6 +24,12 @@ type RemediationType string
 const (
 	// RemediationTypeReboot sets RemediationType to Reboot.
 	RemediationTypeReboot RemediationType = "Reboot"
+
+	// RemediationTypeRecreate sets RemediationType to Recreate.
+	RemediationTypeRecreate RemediationType = "Recreate"
+
+	// RemediationTypeReprovision sets RemediationType to Reprovision.
+	RemediationTypeReprovision RemediationType = "Reprovision"
 )
 
 const (
@@ -35,11 +41,15 @@ const (
 
 	// PhaseDeleting represents the state where host remediation has failed and the controller is deleting the unhealthy Machine object from the cluster.
 	PhaseDeleting = "Deleting machine"
+
+	// PhaseRecreating represents the state during remediation when the server has been deleted and the controller is waiting for its replacement.
+	PhaseRecreating = "Recreating server"
 )
 
 // RemediationStrategy describes how to remediate machines.
 type RemediationStrategy struct {
 	// Type of remediation.
+	// +kubebuilder:validation:Enum=Reboot;Recreate;Reprovision
 	// +kubebuilder:default=Reboot
 	// +optional
 	Type RemediationType `json:"type,omitempty"`
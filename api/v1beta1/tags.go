@@ -49,6 +49,9 @@ const (
 
 	// MachineNameTagKey tags related MachineNameTag.
 	MachineNameTagKey = "machine." + NameHetznerProviderPrefix + "name"
+
+	// VolumeNameTagKey tags the name of a volume of a machine.
+	VolumeNameTagKey = "volume." + NameHetznerProviderPrefix + "name"
 )
 
 // ClusterHetznerCloudProviderTagKey generates the key for resources associated a cluster's HCloud cloud provider.
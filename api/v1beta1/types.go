@@ -64,6 +64,26 @@ type HetznerSSHKeys struct {
 	RobotRescueSecretRef SSHSecretRef `json:"robotRescueSecretRef,omitempty"`
 }
 
+// SSHBastionSpec defines a bastion host that SSH connections to bare metal servers are tunneled through.
+type SSHBastionSpec struct {
+	// Address of the bastion host, e.g. "bastion.example.com" or "203.0.113.1:2222". The port defaults to 22.
+	// +kubebuilder:validation:MinLength=1
+	Address string `json:"address"`
+
+	// User that logs in to the bastion host.
+	// +kubebuilder:default=root
+	// +optional
+	User string `json:"user,omitempty"`
+
+	// SecretRef references the secret with the SSH key that is used to log in to the bastion host.
+	SecretRef SSHSecretRef `json:"secretRef"`
+
+	// HostKey is the host key of the bastion host in authorized_keys format. If it is not set, the host key of
+	// the bastion host is not verified. The host keys of the bare metal servers are verified either way.
+	// +optional
+	HostKey string `json:"hostKey,omitempty"`
+}
+
 // SSHKey defines the SSHKey for HCloud.
 type SSHKey struct {
 	// Name of SSH key
@@ -97,6 +117,56 @@ type HCloudPlacementGroupStatus struct {
 	Type   string  `json:"type,omitempty"`
 }
 
+// HCloudFirewallSpec defines an HCloud Firewall.
+type HCloudFirewallSpec struct {
+	// Name of the firewall. The HCloud Firewall is named after the cluster with this name as suffix.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Rules of the firewall. HCloud Firewalls block all inbound traffic that is not allowed by a rule.
+	// +optional
+	Rules []HCloudFirewallRule `json:"rules,omitempty"`
+
+	// ApplyTo selects the servers of the cluster the firewall is applied to via their HCloud labels.
+	// The firewall is applied to all servers of the cluster if left empty.
+	// +optional
+	ApplyTo map[string]string `json:"applyTo,omitempty"`
+}
+
+// HCloudFirewallRule defines a rule of an HCloud Firewall.
+type HCloudFirewallRule struct {
+	// Direction of the traffic the rule applies to.
+	// +kubebuilder:validation:Enum=in;out
+	Direction string `json:"direction"`
+
+	// Protocol of the traffic the rule applies to.
+	// +kubebuilder:validation:Enum=tcp;udp;icmp;esp;gre
+	Protocol string `json:"protocol"`
+
+	// Port or port range, e.g. "443" or "30000-32767". Required for the protocols tcp and udp.
+	// +optional
+	Port *string `json:"port,omitempty"`
+
+	// SourceIPs are the CIDRs the traffic is allowed from. Required for inbound rules.
+	// +optional
+	SourceIPs []string `json:"sourceIPs,omitempty"`
+
+	// DestinationIPs are the CIDRs the traffic is allowed to. Required for outbound rules.
+	// +optional
+	DestinationIPs []string `json:"destinationIPs,omitempty"`
+
+	// Description of the rule.
+	// +optional
+	Description *string `json:"description,omitempty"`
+}
+
+// HCloudFirewallStatus returns the status of a Firewall.
+type HCloudFirewallStatus struct {
+	ID            int64  `json:"id,omitempty"`
+	Name          string `json:"name,omitempty"`
+	LabelSelector string `json:"labelSelector,omitempty"`
+}
+
 // HetznerSecretRef defines all the name of the secret and the relevant keys needed to access Hetzner API.
 type HetznerSecretRef struct {
 	Name string              `json:"name"`
@@ -112,6 +182,58 @@ type HetznerSecretKeyRef struct {
 	HetznerRobotUser string `json:"hetznerRobotUser"`
 	// +optional
 	HetznerRobotPassword string `json:"hetznerRobotPassword"`
+
+	// NextHCloudToken is the key of a token that replaces the HCloud token. It is validated by the controller
+	// and used for the whole cluster as soon as it is valid.
+	// +optional
+	NextHCloudToken string `json:"nextHCloudToken,omitempty"`
+	// NextHetznerRobotUser is the key of a Robot user that replaces the Robot user, together with NextHetznerRobotPassword.
+	// +optional
+	NextHetznerRobotUser string `json:"nextHetznerRobotUser,omitempty"`
+	// NextHetznerRobotPassword is the key of a Robot password that replaces the Robot password, together with NextHetznerRobotUser.
+	// +optional
+	NextHetznerRobotPassword string `json:"nextHetznerRobotPassword,omitempty"`
+}
+
+// CredentialsStatus defines the status of the credentials of the Hetzner secret.
+type CredentialsStatus struct {
+	// HCloudTokenHash is the HMAC of the validated next HCloud token that is used instead of the HCloud token.
+	// It is keyed with the HCloud token.
+	// +optional
+	HCloudTokenHash string `json:"hcloudTokenHash,omitempty"`
+
+	// RobotCredentialsHash is the HMAC of the validated next Robot credentials that are used instead of the Robot
+	// credentials. It is keyed with the Robot credentials or, if there are none, with the HCloud token.
+	// +optional
+	RobotCredentialsHash string `json:"robotCredentialsHash,omitempty"`
+}
+
+// TargetSecretSpec defines the secret in the workload cluster.
+type TargetSecretSpec struct {
+	// Name is the name of the secret in the workload cluster. Defaults to the name of the HetznerSecret.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Key defines the key names of the secret in the workload cluster.
+	// +optional
+	Key TargetSecretKeyRef `json:"key,omitempty"`
+}
+
+// TargetSecretKeyRef defines the key names of the secret in the workload cluster. The credentials default to
+// the key names of the HetznerSecret, the other keys to "network", "apiserver-host" and "apiserver-port".
+type TargetSecretKeyRef struct {
+	// +optional
+	HCloudToken string `json:"hcloudToken,omitempty"`
+	// +optional
+	HetznerRobotUser string `json:"hetznerRobotUser,omitempty"`
+	// +optional
+	HetznerRobotPassword string `json:"hetznerRobotPassword,omitempty"`
+	// +optional
+	Network string `json:"network,omitempty"`
+	// +optional
+	APIServerHost string `json:"apiServerHost,omitempty"`
+	// +optional
+	APIServerPort string `json:"apiServerPort,omitempty"`
 }
 
 // PublicNetworkSpec contains specs about public network spec of an HCloud server.
@@ -133,6 +255,24 @@ type LoadBalancerSpec struct {
 	// +optional
 	Name *string `json:"name,omitempty"`
 
+	// ID of an existing load balancer that is used instead of creating a new one. Unless the load balancer
+	// is shared, it is adopted like a load balancer that is referenced by name.
+	// +optional
+	ID *int64 `json:"id,omitempty"`
+
+	// Selector selects an existing load balancer via its HCloud labels instead of creating a new one. Exactly
+	// one load balancer has to match. Unless the load balancer is shared, it is adopted like a load balancer
+	// that is referenced by name.
+	// +optional
+	Selector map[string]string `json:"selector,omitempty"`
+
+	// Shared uses the load balancer referenced by ID or Selector without adopting it, e.g. if it also fronts
+	// an ingress. Only the API server service and the targets of the cluster are managed. The load balancer
+	// is neither labeled, changed nor deleted and services that are not part of the cluster are kept. If the
+	// cluster has a network, the load balancer has to be attached to it by its owner.
+	// +optional
+	Shared bool `json:"shared,omitempty"`
+
 	// Could be round_robin or least_connection. The default value is "round_robin".
 	// +optional
 	// +kubebuilder:validation:Enum=round_robin;least_connections
@@ -156,8 +296,79 @@ type LoadBalancerSpec struct {
 	// +optional
 	ExtraServices []LoadBalancerServiceSpec `json:"extraServices,omitempty"`
 
+	// ExtraTargetSelector selects machines via their labels that are added as targets in addition to the
+	// control planes, e.g. the workers of a MachineDeployment that serve the ingress traffic of ExtraServices.
+	// HCloud routes every service to all targets that pass the health check of the service, so every extra
+	// service requires a health check and cannot use the API server port as destination port. Machines that
+	// are not selected anymore are removed as targets.
+	// +optional
+	ExtraTargetSelector map[string]string `json:"extraTargetSelector,omitempty"`
+
+	// APIServerHealthCheck defines the health check of the API server service. If omitted, HCloud checks
+	// whether a TCP connection to the API server port can be established.
+	// +optional
+	APIServerHealthCheck *LoadBalancerHealthCheckSpec `json:"apiServerHealthCheck,omitempty"`
+
 	// Region contains the name of the HCloud location the load balancer is running.
 	Region Region `json:"region,omitempty"`
+
+	// Private disables the public interface of the load balancer. The control plane endpoint is then the IP of
+	// the load balancer in the HCloud network and servers are added as targets with their private IPs. It
+	// requires the HCloud network to be enabled.
+	// +optional
+	Private bool `json:"private,omitempty"`
+
+	// FloatingIP configures a Floating IP, Primary IP or Robot failover IP as control plane endpoint instead
+	// of a load balancer. It can only be used if the load balancer is disabled. The IP is kept assigned to a
+	// control plane server whose API server is ready.
+	// +optional
+	FloatingIP *ControlPlaneFloatingIPSpec `json:"floatingIP,omitempty"`
+
+	// HCloudLabels are added to the load balancer. They take precedence over the HCloudLabels of the
+	// HetznerCluster and are kept in sync on the existing load balancer.
+	// +optional
+	HCloudLabels map[string]string `json:"hcloudLabels,omitempty"`
+}
+
+// ControlPlaneFloatingIPType defines the type of the IP used as control plane endpoint.
+// +kubebuilder:validation:Enum=floating;primary;failover
+type ControlPlaneFloatingIPType string
+
+const (
+	// ControlPlaneFloatingIPTypeFloating uses an HCloud Floating IP, which can be reassigned to running servers.
+	ControlPlaneFloatingIPTypeFloating = ControlPlaneFloatingIPType("floating")
+
+	// ControlPlaneFloatingIPTypePrimary uses an HCloud Primary IP, which can only be assigned to servers that are powered off.
+	ControlPlaneFloatingIPTypePrimary = ControlPlaneFloatingIPType("primary")
+
+	// ControlPlaneFloatingIPTypeFailover uses an existing Robot failover IP, which can be routed to bare metal servers.
+	ControlPlaneFloatingIPTypeFailover = ControlPlaneFloatingIPType("failover")
+)
+
+// ControlPlaneFloatingIPSpec defines the IP used as control plane endpoint.
+type ControlPlaneFloatingIPSpec struct {
+	// Type of the IP. Floating IPs are reassigned to a healthy control plane server. Primary IPs are
+	// released by unhealthy servers that are powered off and assigned to the next control plane server
+	// that is created. Both can only be used with HCloud control planes. Failover IPs are routed to a
+	// healthy bare metal control plane server and can only be used with bare metal control planes.
+	// +optional
+	// +kubebuilder:default=floating
+	Type ControlPlaneFloatingIPType `json:"type,omitempty"`
+
+	// FailoverIP is the Robot failover IP that is used if the type is failover. Failover IPs cannot be
+	// ordered via the API, so the IP has to be ordered in Robot beforehand. It is not released when the
+	// cluster is deleted.
+	// +optional
+	FailoverIP string `json:"failoverIP,omitempty"`
+}
+
+// ControlPlaneFloatingIPStatus defines the observed state of the IP used as control plane endpoint.
+type ControlPlaneFloatingIPStatus struct {
+	ID         int64                      `json:"id,omitempty"`
+	Type       ControlPlaneFloatingIPType `json:"type,omitempty"`
+	IP         string                     `json:"ip,omitempty"`
+	ServerID   int64                      `json:"serverID,omitempty"`
+	Datacenter string                     `json:"datacenter,omitempty"`
 }
 
 // LoadBalancerServiceSpec defines a Loadbalancer Target.
@@ -175,6 +386,136 @@ type LoadBalancerServiceSpec struct {
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=65535
 	DestinationPort int `json:"destinationPort,omitempty"`
+
+	// ProxyProtocol enables the PROXY protocol, which passes the address of the client to the targets.
+	// The targets have to support it.
+	// +optional
+	ProxyProtocol bool `json:"proxyProtocol,omitempty"`
+
+	// HealthCheck defines how the load balancer checks whether the targets are healthy. If omitted, HCloud
+	// checks the destination port with the protocol of the service.
+	// +optional
+	HealthCheck *LoadBalancerHealthCheckSpec `json:"healthCheck,omitempty"`
+
+	// HTTP defines the settings of services with protocol http or https.
+	// +optional
+	HTTP *LoadBalancerServiceHTTPSpec `json:"http,omitempty"`
+}
+
+// LoadBalancerHealthCheckProtocol defines the protocol of a load balancer health check.
+// +kubebuilder:validation:Enum=tcp;http
+type LoadBalancerHealthCheckProtocol string
+
+const (
+	// LoadBalancerHealthCheckProtocolTCP checks whether a TCP connection can be established.
+	LoadBalancerHealthCheckProtocolTCP = LoadBalancerHealthCheckProtocol("tcp")
+
+	// LoadBalancerHealthCheckProtocolHTTP checks the response to an HTTP request.
+	LoadBalancerHealthCheckProtocolHTTP = LoadBalancerHealthCheckProtocol("http")
+)
+
+// LoadBalancerHealthCheckSpec defines the health check of a load balancer service.
+type LoadBalancerHealthCheckSpec struct {
+	// Protocol of the health check.
+	// +optional
+	// +kubebuilder:default=tcp
+	Protocol LoadBalancerHealthCheckProtocol `json:"protocol,omitempty"`
+
+	// Port that is checked. If omitted, the destination port of the service is checked.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int `json:"port,omitempty"`
+
+	// IntervalSeconds is the time between two checks.
+	// +optional
+	// +kubebuilder:validation:Minimum=3
+	// +kubebuilder:default=15
+	IntervalSeconds int `json:"intervalSeconds,omitempty"`
+
+	// TimeoutSeconds is the time after which a check fails.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=10
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+
+	// Retries is the number of failed checks after which a target is considered unhealthy.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=5
+	// +kubebuilder:default=3
+	Retries *int `json:"retries,omitempty"`
+
+	// HTTP defines the request of health checks with protocol http.
+	// +optional
+	HTTP *LoadBalancerHealthCheckHTTPSpec `json:"http,omitempty"`
+}
+
+// LoadBalancerHealthCheckHTTPSpec defines the request of an HTTP health check.
+type LoadBalancerHealthCheckHTTPSpec struct {
+	// Domain is sent as host header. If omitted, the IP of the target is used.
+	// +optional
+	Domain string `json:"domain,omitempty"`
+
+	// Path of the request.
+	// +optional
+	// +kubebuilder:default=/
+	Path string `json:"path,omitempty"`
+
+	// Response is a string that the response body has to contain.
+	// +optional
+	Response string `json:"response,omitempty"`
+
+	// StatusCodes are the expected status codes of the response, e.g. 200 or 2??. If omitted, HCloud expects
+	// 2?? and 3??.
+	// +optional
+	StatusCodes []string `json:"statusCodes,omitempty"`
+
+	// TLS sends the request via HTTPS. The certificate of the target is not verified.
+	// +optional
+	TLS bool `json:"tls,omitempty"`
+}
+
+// LoadBalancerServiceHTTPSpec defines the settings of HTTP and HTTPS services.
+type LoadBalancerServiceHTTPSpec struct {
+	// StickySessions sends the requests of a client to the same target, based on a cookie.
+	// +optional
+	StickySessions bool `json:"stickySessions,omitempty"`
+
+	// CookieName is the name of the cookie of sticky sessions. If omitted, HCloud uses HCLBSTICKY.
+	// +optional
+	CookieName string `json:"cookieName,omitempty"`
+
+	// CookieLifetimeSeconds is the lifetime of the cookie of sticky sessions. If omitted, HCloud uses 300 seconds.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	CookieLifetimeSeconds int `json:"cookieLifetimeSeconds,omitempty"`
+
+	// RedirectHTTP redirects requests on port 80 to this HTTPS service.
+	// +optional
+	RedirectHTTP bool `json:"redirectHTTP,omitempty"`
+
+	// Certificates are used to terminate TLS of HTTPS services.
+	// +optional
+	Certificates []LoadBalancerCertificateSpec `json:"certificates,omitempty"`
+}
+
+// LoadBalancerCertificateSpec references an HCloud certificate. Exactly one of ID, DomainNames and SecretName
+// has to be set.
+type LoadBalancerCertificateSpec struct {
+	// ID of an existing HCloud certificate. The certificate is neither modified nor deleted.
+	// +optional
+	ID *int64 `json:"id,omitempty"`
+
+	// DomainNames of a certificate that is issued and renewed by HCloud with Let's Encrypt. The certificate
+	// is created and deleted by the controller. The domains have to be served by HCloud DNS.
+	// +optional
+	DomainNames []string `json:"domainNames,omitempty"`
+
+	// SecretName is the name of a Secret of type kubernetes.io/tls in the namespace of the HetznerCluster.
+	// Its certificate is uploaded to HCloud and replaced whenever the Secret changes.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
 }
 
 // LoadBalancerStatus defines the obeserved state of the control plane loadbalancer.
@@ -214,6 +555,91 @@ type HCloudNetworkSpec struct {
 	// +kubebuilder:default=eu-central
 	// +optional
 	NetworkZone HCloudNetworkZone `json:"networkZone,omitempty"`
+
+	// Subnets of the HCloud Network. If specified, they are used instead of SubnetCIDRBlock.
+	// Subnets can be added and removed without recreating the network.
+	// +optional
+	Subnets []HCloudNetworkSubnetSpec `json:"subnets,omitempty"`
+
+	// Routes of the HCloud Network. Routes can be added and removed without recreating the network.
+	// +optional
+	Routes []HCloudNetworkRouteSpec `json:"routes,omitempty"`
+
+	// ID of an existing network that is used instead of creating a new one. The network is not owned
+	// by the cluster, so it is not deleted and only missing subnets and routes are added to it.
+	// +optional
+	ID *int64 `json:"id,omitempty"`
+
+	// Selector selects an existing network via its HCloud labels instead of creating a new one. Exactly
+	// one network has to match. The network is not owned by the cluster, so it is not deleted and only
+	// missing subnets and routes are added to it.
+	// +optional
+	Selector map[string]string `json:"selector,omitempty"`
+
+	// HCloudLabels are added to the network. They take precedence over the HCloudLabels of the HetznerCluster
+	// and are kept in sync on the existing network. They are not added to existing networks that are not
+	// owned by the cluster.
+	// +optional
+	HCloudLabels map[string]string `json:"hcloudLabels,omitempty"`
+}
+
+// HCloudNetworkSubnetType defines the type of a subnet.
+// +kubebuilder:validation:Enum=cloud;vswitch
+type HCloudNetworkSubnetType string
+
+const (
+	// HCloudNetworkSubnetTypeCloud is a subnet for HCloud servers and load balancers.
+	HCloudNetworkSubnetTypeCloud = HCloudNetworkSubnetType("cloud")
+
+	// HCloudNetworkSubnetTypeVSwitch is a subnet that connects the network with a Robot vSwitch.
+	HCloudNetworkSubnetTypeVSwitch = HCloudNetworkSubnetType("vswitch")
+)
+
+// HCloudNetworkSubnetSpec defines a subnet of the HCloud Private Network.
+type HCloudNetworkSubnetSpec struct {
+	// IPRange of the subnet. It has to be part of the IP range of the network.
+	IPRange string `json:"ipRange"`
+
+	// Type of the subnet.
+	// +kubebuilder:default=cloud
+	// +optional
+	Type HCloudNetworkSubnetType `json:"type,omitempty"`
+
+	// NetworkZone of the subnet. Defaults to the network zone of the network.
+	// +kubebuilder:validation:Enum=eu-central;us-east;us-west
+	// +optional
+	NetworkZone HCloudNetworkZone `json:"networkZone,omitempty"`
+
+	// VSwitchID is the ID of the Robot vSwitch. Required for subnets of type vswitch.
+	// +optional
+	VSwitchID int64 `json:"vSwitchID,omitempty"`
+}
+
+// HCloudNetworkRouteSpec defines a static route of the HCloud Private Network.
+type HCloudNetworkRouteSpec struct {
+	// Destination of the route as CIDR.
+	Destination string `json:"destination"`
+
+	// Gateway of the route. It has to be an IP of a subnet of the network.
+	Gateway string `json:"gateway"`
+}
+
+// RobotVSwitchSpec defines the Robot vSwitch that connects bare metal servers with the HCloud Private Network.
+type RobotVSwitchSpec struct {
+	// VLAN ID of the vSwitch. Bare metal servers have to configure a VLAN interface with this ID.
+	// +kubebuilder:validation:Minimum=4000
+	// +kubebuilder:validation:Maximum=4091
+	VLAN int `json:"vlan"`
+
+	// IPRange of the vSwitch subnet in the HCloud Private Network. It has to be part of the IP range of the network.
+	IPRange string `json:"ipRange"`
+}
+
+// RobotVSwitchStatus defines the observed state of the Robot vSwitch.
+type RobotVSwitchStatus struct {
+	ID   int    `json:"id"`
+	Name string `json:"name,omitempty"`
+	VLAN int    `json:"vlan"`
 }
 
 // NetworkStatus defines the observed state of the HCloud Private Network.
@@ -240,3 +666,8 @@ func (s *HCloudNetworkSpec) IsZero() bool {
 	}
 	return true
 }
+
+// IsExisting returns true if an existing network is used instead of creating one.
+func (s *HCloudNetworkSpec) IsExisting() bool {
+	return s.ID != nil || len(s.Selector) > 0
+}
@@ -17,8 +17,12 @@ limitations under the License.
 package v1beta1
 
 import (
+	"sort"
+	"strings"
+
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -33,3 +37,31 @@ func aggregateObjErrors(gk schema.GroupKind, name string, allErrs field.ErrorLis
 		allErrs,
 	)
 }
+
+// validateHCloudLabels checks that labels are valid HCloud labels and do not use the prefix of the labels
+// that are set by the controller, e.g. caph-cluster-<name> or machine.caph-name.
+func validateHCloudLabels(labels map[string]string, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if strings.Contains(key, NameHetznerProviderPrefix) {
+			allErrs = append(allErrs,
+				field.Invalid(path.Key(key), key, "labels containing "+NameHetznerProviderPrefix+" are reserved for the controller"),
+			)
+		}
+		for _, msg := range validation.IsQualifiedName(key) {
+			allErrs = append(allErrs, field.Invalid(path.Key(key), key, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(labels[key]) {
+			allErrs = append(allErrs, field.Invalid(path.Key(key), labels[key], msg))
+		}
+	}
+
+	return allErrs
+}
@@ -65,6 +65,36 @@ func (in *CPU) DeepCopy() *CPU {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneFloatingIPSpec) DeepCopyInto(out *ControlPlaneFloatingIPSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneFloatingIPSpec.
+func (in *ControlPlaneFloatingIPSpec) DeepCopy() *ControlPlaneFloatingIPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneFloatingIPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneFloatingIPStatus) DeepCopyInto(out *ControlPlaneFloatingIPStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneFloatingIPStatus.
+func (in *ControlPlaneFloatingIPStatus) DeepCopy() *ControlPlaneFloatingIPStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneFloatingIPStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerGeneratedStatus) DeepCopyInto(out *ControllerGeneratedStatus) {
 	*out = *in
@@ -93,11 +123,25 @@ func (in *ControllerGeneratedStatus) DeepCopyInto(out *ControllerGeneratedStatus
 		*out = new(SSHSpec)
 		**out = **in
 	}
+	if in.RobotVSwitch != nil {
+		in, out := &in.RobotVSwitch, &out.RobotVSwitch
+		*out = new(RobotVSwitchStatus)
+		**out = **in
+	}
 	in.SSHStatus.DeepCopyInto(&out.SSHStatus)
+	if in.DiskWipe != nil {
+		in, out := &in.DiskWipe, &out.DiskWipe
+		*out = new(DiskWipeStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.LastUpdated != nil {
 		in, out := &in.LastUpdated, &out.LastUpdated
 		*out = (*in).DeepCopy()
 	}
+	if in.LastReleased != nil {
+		in, out := &in.LastReleased, &out.LastReleased
+		*out = (*in).DeepCopy()
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(apiv1beta1.Conditions, len(*in))
@@ -117,6 +161,174 @@ func (in *ControllerGeneratedStatus) DeepCopy() *ControllerGeneratedStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialsStatus) DeepCopyInto(out *CredentialsStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsStatus.
+func (in *CredentialsStatus) DeepCopy() *CredentialsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskEncryption) DeepCopyInto(out *DiskEncryption) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskEncryption.
+func (in *DiskEncryption) DeepCopy() *DiskEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskRequirement) DeepCopyInto(out *DiskRequirement) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskRequirement.
+func (in *DiskRequirement) DeepCopy() *DiskRequirement {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskRequirement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskWipeStatus) DeepCopyInto(out *DiskWipeStatus) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.FinishedAt != nil {
+		in, out := &in.FinishedAt, &out.FinishedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Disks != nil {
+		in, out := &in.Disks, &out.Disks
+		*out = make([]WipedDisk, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskWipeStatus.
+func (in *DiskWipeStatus) DeepCopy() *DiskWipeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskWipeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionSecretRef) DeepCopyInto(out *EncryptionSecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionSecretRef.
+func (in *EncryptionSecretRef) DeepCopy() *EncryptionSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HCloudFirewallRule) DeepCopyInto(out *HCloudFirewallRule) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(string)
+		**out = **in
+	}
+	if in.SourceIPs != nil {
+		in, out := &in.SourceIPs, &out.SourceIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DestinationIPs != nil {
+		in, out := &in.DestinationIPs, &out.DestinationIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HCloudFirewallRule.
+func (in *HCloudFirewallRule) DeepCopy() *HCloudFirewallRule {
+	if in == nil {
+		return nil
+	}
+	out := new(HCloudFirewallRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HCloudFirewallSpec) DeepCopyInto(out *HCloudFirewallSpec) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]HCloudFirewallRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ApplyTo != nil {
+		in, out := &in.ApplyTo, &out.ApplyTo
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HCloudFirewallSpec.
+func (in *HCloudFirewallSpec) DeepCopy() *HCloudFirewallSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HCloudFirewallSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HCloudFirewallStatus) DeepCopyInto(out *HCloudFirewallStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HCloudFirewallStatus.
+func (in *HCloudFirewallStatus) DeepCopy() *HCloudFirewallStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HCloudFirewallStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HCloudMachine) DeepCopyInto(out *HCloudMachine) {
 	*out = *in
@@ -184,6 +396,11 @@ func (in *HCloudMachineSpec) DeepCopyInto(out *HCloudMachineSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ImageSelector != nil {
+		in, out := &in.ImageSelector, &out.ImageSelector
+		*out = new(ImageSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SSHKeys != nil {
 		in, out := &in.SSHKeys, &out.SSHKeys
 		*out = make([]SSHKey, len(*in))
@@ -199,6 +416,20 @@ func (in *HCloudMachineSpec) DeepCopyInto(out *HCloudMachineSpec) {
 		*out = new(PublicNetworkSpec)
 		**out = **in
 	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]HCloudVolumeSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HCloudLabels != nil {
+		in, out := &in.HCloudLabels, &out.HCloudLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HCloudMachineSpec.
@@ -224,6 +455,11 @@ func (in *HCloudMachineStatus) DeepCopyInto(out *HCloudMachineStatus) {
 		*out = new(hcloud.ServerStatus)
 		**out = **in
 	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]HCloudVolumeStatus, len(*in))
+		copy(*out, *in)
+	}
 	if in.FailureReason != nil {
 		in, out := &in.FailureReason, &out.FailureReason
 		*out = new(errors.MachineStatusError)
@@ -374,9 +610,53 @@ func (in *HCloudMachineTemplateStatus) DeepCopy() *HCloudMachineTemplateStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HCloudNetworkRouteSpec) DeepCopyInto(out *HCloudNetworkRouteSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HCloudNetworkRouteSpec.
+func (in *HCloudNetworkRouteSpec) DeepCopy() *HCloudNetworkRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HCloudNetworkRouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HCloudNetworkSpec) DeepCopyInto(out *HCloudNetworkSpec) {
 	*out = *in
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]HCloudNetworkSubnetSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]HCloudNetworkRouteSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.HCloudLabels != nil {
+		in, out := &in.HCloudLabels, &out.HCloudLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HCloudNetworkSpec.
@@ -389,6 +669,21 @@ func (in *HCloudNetworkSpec) DeepCopy() *HCloudNetworkSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HCloudNetworkSubnetSpec) DeepCopyInto(out *HCloudNetworkSubnetSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HCloudNetworkSubnetSpec.
+func (in *HCloudNetworkSubnetSpec) DeepCopy() *HCloudNetworkSubnetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HCloudNetworkSubnetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HCloudPlacementGroupSpec) DeepCopyInto(out *HCloudPlacementGroupSpec) {
 	*out = *in
@@ -636,6 +931,41 @@ func (in *HCloudRemediationTemplateStatus) DeepCopy() *HCloudRemediationTemplate
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HCloudVolumeSpec) DeepCopyInto(out *HCloudVolumeSpec) {
+	*out = *in
+	if in.Format != nil {
+		in, out := &in.Format, &out.Format
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HCloudVolumeSpec.
+func (in *HCloudVolumeSpec) DeepCopy() *HCloudVolumeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HCloudVolumeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HCloudVolumeStatus) DeepCopyInto(out *HCloudVolumeStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HCloudVolumeStatus.
+func (in *HCloudVolumeStatus) DeepCopy() *HCloudVolumeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HCloudVolumeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HardwareDetails) DeepCopyInto(out *HardwareDetails) {
 	*out = *in
@@ -662,6 +992,26 @@ func (in *HardwareDetails) DeepCopy() *HardwareDetails {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HardwareRequirements) DeepCopyInto(out *HardwareRequirements) {
+	*out = *in
+	if in.Disks != nil {
+		in, out := &in.Disks, &out.Disks
+		*out = make([]DiskRequirement, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareRequirements.
+func (in *HardwareRequirements) DeepCopy() *HardwareRequirements {
+	if in == nil {
+		return nil
+	}
+	out := new(HardwareRequirements)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HetznerBareMetalHost) DeepCopyInto(out *HetznerBareMetalHost) {
 	*out = *in
@@ -739,6 +1089,11 @@ func (in *HetznerBareMetalHostSpec) DeepCopyInto(out *HetznerBareMetalHostSpec)
 		*out = new(bool)
 		**out = **in
 	}
+	if in.SSHBastion != nil {
+		in, out := &in.SSHBastion, &out.SSHBastion
+		*out = new(SSHBastionSpec)
+		**out = **in
+	}
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -836,6 +1191,7 @@ func (in *HetznerBareMetalMachineSpec) DeepCopyInto(out *HetznerBareMetalMachine
 	}
 	in.InstallImage.DeepCopyInto(&out.InstallImage)
 	in.HostSelector.DeepCopyInto(&out.HostSelector)
+	out.HostSelectionPolicy = in.HostSelectionPolicy
 	out.SSHSpec = in.SSHSpec
 }
 
@@ -896,6 +1252,7 @@ func (in *HetznerBareMetalMachineTemplate) DeepCopyInto(out *HetznerBareMetalMac
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HetznerBareMetalMachineTemplate.
@@ -980,6 +1337,40 @@ func (in *HetznerBareMetalMachineTemplateSpec) DeepCopy() *HetznerBareMetalMachi
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HetznerBareMetalMachineTemplateStatus) DeepCopyInto(out *HetznerBareMetalMachineTemplateStatus) {
+	*out = *in
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.NodeInfo != nil {
+		in, out := &in.NodeInfo, &out.NodeInfo
+		*out = new(NodeInfo)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(apiv1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HetznerBareMetalMachineTemplateStatus.
+func (in *HetznerBareMetalMachineTemplateStatus) DeepCopy() *HetznerBareMetalMachineTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HetznerBareMetalMachineTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HetznerBareMetalRemediation) DeepCopyInto(out *HetznerBareMetalRemediation) {
 	*out = *in
@@ -1247,13 +1638,18 @@ func (in *HetznerClusterList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HetznerClusterSpec) DeepCopyInto(out *HetznerClusterSpec) {
 	*out = *in
-	out.HCloudNetwork = in.HCloudNetwork
+	in.HCloudNetwork.DeepCopyInto(&out.HCloudNetwork)
 	if in.ControlPlaneRegions != nil {
 		in, out := &in.ControlPlaneRegions, &out.ControlPlaneRegions
 		*out = make([]Region, len(*in))
 		copy(*out, *in)
 	}
 	in.SSHKeys.DeepCopyInto(&out.SSHKeys)
+	if in.SSHBastion != nil {
+		in, out := &in.SSHBastion, &out.SSHBastion
+		*out = new(SSHBastionSpec)
+		**out = **in
+	}
 	if in.ControlPlaneEndpoint != nil {
 		in, out := &in.ControlPlaneEndpoint, &out.ControlPlaneEndpoint
 		*out = new(apiv1beta1.APIEndpoint)
@@ -1265,7 +1661,27 @@ func (in *HetznerClusterSpec) DeepCopyInto(out *HetznerClusterSpec) {
 		*out = make([]HCloudPlacementGroupSpec, len(*in))
 		copy(*out, *in)
 	}
+	if in.HCloudFirewalls != nil {
+		in, out := &in.HCloudFirewalls, &out.HCloudFirewalls
+		*out = make([]HCloudFirewallSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RobotVSwitch != nil {
+		in, out := &in.RobotVSwitch, &out.RobotVSwitch
+		*out = new(RobotVSwitchSpec)
+		**out = **in
+	}
 	out.HetznerSecret = in.HetznerSecret
+	out.TargetSecret = in.TargetSecret
+	if in.HCloudLabels != nil {
+		in, out := &in.HCloudLabels, &out.HCloudLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HetznerClusterSpec.
@@ -1291,6 +1707,11 @@ func (in *HetznerClusterStatus) DeepCopyInto(out *HetznerClusterStatus) {
 		*out = new(LoadBalancerStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ControlPlaneFloatingIP != nil {
+		in, out := &in.ControlPlaneFloatingIP, &out.ControlPlaneFloatingIP
+		*out = new(ControlPlaneFloatingIPStatus)
+		**out = **in
+	}
 	if in.HCloudPlacementGroups != nil {
 		in, out := &in.HCloudPlacementGroups, &out.HCloudPlacementGroups
 		*out = make([]HCloudPlacementGroupStatus, len(*in))
@@ -1298,6 +1719,21 @@ func (in *HetznerClusterStatus) DeepCopyInto(out *HetznerClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HCloudFirewalls != nil {
+		in, out := &in.HCloudFirewalls, &out.HCloudFirewalls
+		*out = make([]HCloudFirewallStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.RobotVSwitch != nil {
+		in, out := &in.RobotVSwitch, &out.RobotVSwitch
+		*out = new(RobotVSwitchStatus)
+		**out = **in
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(CredentialsStatus)
+		**out = **in
+	}
 	if in.FailureDomains != nil {
 		in, out := &in.FailureDomains, &out.FailureDomains
 		*out = make(apiv1beta1.FailureDomains, len(*in))
@@ -1467,6 +1903,21 @@ func (in *HetznerSecretRef) DeepCopy() *HetznerSecretRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostSelectionPolicy) DeepCopyInto(out *HostSelectionPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostSelectionPolicy.
+func (in *HostSelectionPolicy) DeepCopy() *HostSelectionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(HostSelectionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostSelector) DeepCopyInto(out *HostSelector) {
 	*out = *in
@@ -1484,6 +1935,11 @@ func (in *HostSelector) DeepCopyInto(out *HostSelector) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HardwareRequirements != nil {
+		in, out := &in.HardwareRequirements, &out.HardwareRequirements
+		*out = new(HardwareRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostSelector.
@@ -1531,6 +1987,26 @@ func (in *Image) DeepCopy() *Image {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSelector) DeepCopyInto(out *ImageSelector) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSelector.
+func (in *ImageSelector) DeepCopy() *ImageSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstallImage) DeepCopyInto(out *InstallImage) {
 	*out = *in
@@ -1550,6 +2026,11 @@ func (in *InstallImage) DeepCopyInto(out *InstallImage) {
 		*out = make([]BTRFSDefinition, len(*in))
 		copy(*out, *in)
 	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(DiskEncryption)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallImage.
@@ -1577,9 +2058,111 @@ func (in *LVMDefinition) DeepCopy() *LVMDefinition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerCertificateSpec) DeepCopyInto(out *LoadBalancerCertificateSpec) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DomainNames != nil {
+		in, out := &in.DomainNames, &out.DomainNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerCertificateSpec.
+func (in *LoadBalancerCertificateSpec) DeepCopy() *LoadBalancerCertificateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerCertificateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerHealthCheckHTTPSpec) DeepCopyInto(out *LoadBalancerHealthCheckHTTPSpec) {
+	*out = *in
+	if in.StatusCodes != nil {
+		in, out := &in.StatusCodes, &out.StatusCodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerHealthCheckHTTPSpec.
+func (in *LoadBalancerHealthCheckHTTPSpec) DeepCopy() *LoadBalancerHealthCheckHTTPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerHealthCheckHTTPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerHealthCheckSpec) DeepCopyInto(out *LoadBalancerHealthCheckSpec) {
+	*out = *in
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(LoadBalancerHealthCheckHTTPSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerHealthCheckSpec.
+func (in *LoadBalancerHealthCheckSpec) DeepCopy() *LoadBalancerHealthCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerHealthCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerServiceHTTPSpec) DeepCopyInto(out *LoadBalancerServiceHTTPSpec) {
+	*out = *in
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]LoadBalancerCertificateSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerServiceHTTPSpec.
+func (in *LoadBalancerServiceHTTPSpec) DeepCopy() *LoadBalancerServiceHTTPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerServiceHTTPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoadBalancerServiceSpec) DeepCopyInto(out *LoadBalancerServiceSpec) {
 	*out = *in
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(LoadBalancerHealthCheckSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(LoadBalancerServiceHTTPSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerServiceSpec.
@@ -1600,10 +2183,48 @@ func (in *LoadBalancerSpec) DeepCopyInto(out *LoadBalancerSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.ExtraServices != nil {
 		in, out := &in.ExtraServices, &out.ExtraServices
 		*out = make([]LoadBalancerServiceSpec, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraTargetSelector != nil {
+		in, out := &in.ExtraTargetSelector, &out.ExtraTargetSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.APIServerHealthCheck != nil {
+		in, out := &in.APIServerHealthCheck, &out.APIServerHealthCheck
+		*out = new(LoadBalancerHealthCheckSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FloatingIP != nil {
+		in, out := &in.FloatingIP, &out.FloatingIP
+		*out = new(ControlPlaneFloatingIPSpec)
+		**out = **in
+	}
+	if in.HCloudLabels != nil {
+		in, out := &in.HCloudLabels, &out.HCloudLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 }
 
@@ -1694,6 +2315,21 @@ func (in *NetworkStatus) DeepCopy() *NetworkStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeInfo) DeepCopyInto(out *NodeInfo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeInfo.
+func (in *NodeInfo) DeepCopy() *NodeInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Partition) DeepCopyInto(out *Partition) {
 	*out = *in
@@ -1779,6 +2415,36 @@ func (in *RemediationStrategy) DeepCopy() *RemediationStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RobotVSwitchSpec) DeepCopyInto(out *RobotVSwitchSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RobotVSwitchSpec.
+func (in *RobotVSwitchSpec) DeepCopy() *RobotVSwitchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RobotVSwitchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RobotVSwitchStatus) DeepCopyInto(out *RobotVSwitchStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RobotVSwitchStatus.
+func (in *RobotVSwitchStatus) DeepCopy() *RobotVSwitchStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RobotVSwitchStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RootDeviceHints) DeepCopyInto(out *RootDeviceHints) {
 	*out = *in
@@ -1795,6 +2461,22 @@ func (in *RootDeviceHints) DeepCopy() *RootDeviceHints {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHBastionSpec) DeepCopyInto(out *SSHBastionSpec) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHBastionSpec.
+func (in *SSHBastionSpec) DeepCopy() *SSHBastionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHBastionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SSHKey) DeepCopyInto(out *SSHKey) {
 	*out = *in
@@ -1931,3 +2613,49 @@ func (in *Storage) DeepCopy() *Storage {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetSecretKeyRef) DeepCopyInto(out *TargetSecretKeyRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetSecretKeyRef.
+func (in *TargetSecretKeyRef) DeepCopy() *TargetSecretKeyRef {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetSecretKeyRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetSecretSpec) DeepCopyInto(out *TargetSecretSpec) {
+	*out = *in
+	out.Key = in.Key
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetSecretSpec.
+func (in *TargetSecretSpec) DeepCopy() *TargetSecretSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetSecretSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WipedDisk) DeepCopyInto(out *WipedDisk) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WipedDisk.
+func (in *WipedDisk) DeepCopy() *WipedDisk {
+	if in == nil {
+		return nil
+	}
+	out := new(WipedDisk)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -72,6 +72,7 @@ var _ = BeforeSuite(func() {
 		APIReader:                      testEnv.Manager.GetAPIReader(),
 		RateLimitWaitTime:              5 * time.Minute,
 		HCloudClientFactory:            testEnv.HCloudClientFactory,
+		RobotClientFactory:             testEnv.RobotClientFactory,
 		TargetClusterManagersWaitGroup: &wg,
 	}).SetupWithManager(ctx, testEnv.Manager, controller.Options{})).To(Succeed())
 
@@ -100,6 +101,10 @@ var _ = BeforeSuite(func() {
 		HCloudClientFactory: testEnv.HCloudClientFactory,
 	}).SetupWithManager(ctx, testEnv.Manager, controller.Options{})).To(Succeed())
 
+	Expect((&HetznerBareMetalMachineTemplateReconciler{
+		Client: testEnv.Manager.GetClient(),
+	}).SetupWithManager(ctx, testEnv.Manager, controller.Options{})).To(Succeed())
+
 	go func() {
 		defer GinkgoRecover()
 		Expect(testEnv.StartManager(ctx)).To(Succeed())
@@ -167,6 +167,11 @@ func (r *HCloudMachineReconciler) Reconcile(ctx context.Context, req reconcile.R
 		return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
 	}
 
+	// control planes and deletions are prioritized when the rate limit budget runs low
+	if machineScope.IsControlPlane() || !hcloudMachine.ObjectMeta.DeletionTimestamp.IsZero() {
+		ctx = hcloudclient.WithPriority(ctx, hcloudclient.PriorityHigh)
+	}
+
 	if !hcloudMachine.ObjectMeta.DeletionTimestamp.IsZero() {
 		return r.reconcileDelete(ctx, machineScope)
 	}
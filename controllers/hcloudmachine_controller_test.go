@@ -354,6 +354,28 @@ var _ = Describe("HCloudMachineReconciler", func() {
 			})
 		})
 
+		Context("with image selector", func() {
+			BeforeEach(func() {
+				hcloudMachine.Spec.ImageName = ""
+				hcloudMachine.Spec.ImageSelector = &infrav1.ImageSelector{ID: ptr.To[int64](42)}
+				Expect(testEnv.Create(ctx, hetznerCluster)).To(Succeed())
+				Expect(testEnv.Create(ctx, hcloudMachine)).To(Succeed())
+			})
+
+			AfterEach(func() {
+				Expect(testEnv.Cleanup(ctx, hetznerCluster, hcloudMachine)).To(Succeed())
+			})
+
+			It("records the ID of the image in the status", func() {
+				Eventually(func() int64 {
+					if err := testEnv.Get(ctx, key, hcloudMachine); err != nil {
+						return 0
+					}
+					return hcloudMachine.Status.ImageID
+				}, timeout, time.Second).Should(Equal(int64(42)))
+			})
+		})
+
 		Context("with public network specs", func() {
 			BeforeEach(func() {
 				hcloudMachine.Spec.PublicNetwork = &infrav1.PublicNetworkSpec{
@@ -581,6 +603,36 @@ var _ = Describe("HCloudMachine validation", func() {
 		hcloudMachine.Spec.ImageName = ""
 		Expect(testEnv.Create(ctx, hcloudMachine)).ToNot(Succeed())
 	})
+
+	It("should succeed with imageSelector instead of imageName", func() {
+		hcloudMachine.Spec.ImageName = ""
+		hcloudMachine.Spec.ImageSelector = &infrav1.ImageSelector{LabelSelector: "os==fedora"}
+		Expect(testEnv.Create(ctx, hcloudMachine)).To(Succeed())
+	})
+
+	It("should fail with imageName and imageSelector", func() {
+		hcloudMachine.Spec.ImageSelector = &infrav1.ImageSelector{ID: ptr.To[int64](42)}
+		Expect(testEnv.Create(ctx, hcloudMachine)).ToNot(Succeed())
+	})
+
+	It("should fail with reserved hcloud labels", func() {
+		hcloudMachine.Spec.HCloudLabels = map[string]string{infrav1.MachineNameTagKey: "other"}
+		Expect(testEnv.Create(ctx, hcloudMachine)).ToNot(Succeed())
+	})
+
+	It("should fail with invalid hcloud labels", func() {
+		hcloudMachine.Spec.HCloudLabels = map[string]string{"cost-center": "not a valid value"}
+		Expect(testEnv.Create(ctx, hcloudMachine)).ToNot(Succeed())
+	})
+
+	It("should fail with policy HighestSemver without semverLabel", func() {
+		hcloudMachine.Spec.ImageName = ""
+		hcloudMachine.Spec.ImageSelector = &infrav1.ImageSelector{
+			LabelSelector: "os==fedora",
+			Policy:        infrav1.ImageSelectionPolicyHighestSemver,
+		}
+		Expect(testEnv.Create(ctx, hcloudMachine)).ToNot(Succeed())
+	})
 })
 
 var _ = Describe("IgnoreHetznerClusterConditionUpdates Predicate", func() {
@@ -139,6 +139,7 @@ func (r *HCloudRemediationReconciler) Reconcile(ctx context.Context, req reconci
 
 	remediationScope, err := scope.NewHCloudRemediationScope(scope.HCloudRemediationScopeParams{
 		Client:            r.Client,
+		APIReader:         r.APIReader,
 		Logger:            log,
 		Machine:           machine,
 		HCloudMachine:     hcloudMachine,
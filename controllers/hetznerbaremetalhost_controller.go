@@ -122,6 +122,14 @@ func (r *HetznerBareMetalHostReconciler) Reconcile(ctx context.Context, req ctrl
 	if err != nil || res != emptyResult {
 		return res, err
 	}
+	sshBastionSecret, res, err := r.getSSHBastionSecret(ctx, *secretManager, bmHost, hetznerCluster)
+	if err != nil || res != emptyResult {
+		return res, err
+	}
+	encryptionSecret, res, err := r.getEncryptionSecret(ctx, *secretManager, bmHost)
+	if err != nil || res != emptyResult {
+		return res, err
+	}
 	// Create the scope.
 	hostScope, err := scope.NewBareMetalHostScope(scope.BareMetalHostScopeParams{
 		Logger:               log,
@@ -132,6 +140,8 @@ func (r *HetznerBareMetalHostReconciler) Reconcile(ctx context.Context, req ctrl
 		SSHClientFactory:     r.SSHClientFactory,
 		OSSSHSecret:          osSSHSecret,
 		RescueSSHSecret:      rescueSSHSecret,
+		SSHBastionSecret:     sshBastionSecret,
+		EncryptionSecret:     encryptionSecret,
 		SecretManager:        secretManager,
 	})
 	if err != nil {
@@ -179,6 +189,28 @@ func (r *HetznerBareMetalHostReconciler) reconcileSelectedStates(ctx context.Con
 
 		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
 
+		// Handle StateWipingDisks: a released host does not need its cluster anymore if the disk wipe is cancelled or
+		// has been given up before the host is deleted. The cluster might be gone already.
+	case infrav1.StateWipingDisks:
+		if bmHost.Spec.ConsumerRef != nil {
+			return res, nil
+		}
+		switch {
+		case !bmHost.Spec.WipeDisks:
+			host.CancelDiskWipe(bmHost)
+			bmHost.Spec.Status.SSHSpec = nil
+			bmHost.Spec.Status.HetznerClusterRef = ""
+			bmHost.Spec.Status.ProvisioningState = infrav1.StateNone
+		case !bmHost.DeletionTimestamp.IsZero() && host.DiskWipeFailedPermanently(bmHost):
+			bmHost.Spec.Status.ProvisioningState = infrav1.StateDeleting
+		default:
+			return res, nil
+		}
+		if err := r.Update(ctx, bmHost); err != nil {
+			return res, fmt.Errorf("failed to update host: %w", err)
+		}
+		return ctrl.Result{Requeue: true}, nil
+
 		// Handle StateDeleting
 	case infrav1.StateDeleting:
 		if !utils.StringInList(bmHost.Finalizers, infrav1.BareMetalHostFinalizer) {
@@ -258,6 +290,84 @@ func (r *HetznerBareMetalHostReconciler) getSecrets(
 	return osSSHSecret, rescueSSHSecret, res, nil
 }
 
+// getSSHBastionSecret returns the secret with the SSH key of the bastion host of the host or of the cluster. It
+// returns nil if there is no bastion host.
+func (r *HetznerBareMetalHostReconciler) getSSHBastionSecret(
+	ctx context.Context,
+	secretManager secretutil.SecretManager,
+	bmHost *infrav1.HetznerBareMetalHost,
+	hetznerCluster *infrav1.HetznerCluster,
+) (*corev1.Secret, ctrl.Result, error) {
+	bastion := bmHost.Spec.SSHBastion
+	if bastion == nil {
+		bastion = hetznerCluster.Spec.SSHBastion
+	}
+	if bmHost.Spec.Status.SSHSpec == nil || bastion == nil {
+		return nil, ctrl.Result{}, nil
+	}
+
+	secretNamespacedName := types.NamespacedName{Namespace: bmHost.Namespace, Name: bastion.SecretRef.Name}
+	secret, err := secretManager.ObtainSecret(ctx, secretNamespacedName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			conditions.MarkFalse(
+				bmHost,
+				infrav1.CredentialsAvailableCondition,
+				infrav1.SSHBastionSecretMissingReason,
+				clusterv1.ConditionSeverityError,
+				infrav1.ErrorMessageMissingSSHBastionSecret,
+			)
+			record.Warnf(bmHost, infrav1.SSHBastionSecretMissingReason, infrav1.ErrorMessageMissingSSHBastionSecret)
+			conditions.SetSummary(bmHost)
+			result, err := host.SaveHostAndReturn(ctx, r.Client, bmHost)
+			if result != (ctrl.Result{}) || err != nil {
+				return nil, result, err
+			}
+
+			return nil, reconcile.Result{RequeueAfter: 5 * time.Minute}, nil
+		}
+		return nil, ctrl.Result{}, fmt.Errorf("failed to get secret: %w", err)
+	}
+	return secret, ctrl.Result{}, nil
+}
+
+// getEncryptionSecret returns the secret with the passphrase of the encrypted partitions of the host. It returns nil
+// if the partitions are not encrypted.
+func (r *HetznerBareMetalHostReconciler) getEncryptionSecret(
+	ctx context.Context,
+	secretManager secretutil.SecretManager,
+	bmHost *infrav1.HetznerBareMetalHost,
+) (*corev1.Secret, ctrl.Result, error) {
+	installImage := bmHost.Spec.Status.InstallImage
+	if installImage == nil || installImage.Encryption == nil {
+		return nil, ctrl.Result{}, nil
+	}
+
+	secretNamespacedName := types.NamespacedName{Namespace: bmHost.Namespace, Name: installImage.Encryption.SecretRef.Name}
+	secret, err := secretManager.ObtainSecret(ctx, secretNamespacedName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			conditions.MarkFalse(
+				bmHost,
+				infrav1.CredentialsAvailableCondition,
+				infrav1.EncryptionSecretMissingReason,
+				clusterv1.ConditionSeverityError,
+				infrav1.ErrorMessageMissingEncryptionSecret,
+			)
+			record.Warnf(bmHost, infrav1.EncryptionSecretMissingReason, infrav1.ErrorMessageMissingEncryptionSecret)
+			conditions.SetSummary(bmHost)
+			result, err := host.SaveHostAndReturn(ctx, r.Client, bmHost)
+			if result != (ctrl.Result{}) || err != nil {
+				return nil, result, err
+			}
+
+			return nil, reconcile.Result{RequeueAfter: 5 * time.Minute}, nil
+		}
+		return nil, ctrl.Result{}, fmt.Errorf("failed to get secret: %w", err)
+	}
+	return secret, ctrl.Result{}, nil
+}
+
 func getAndValidateRobotCredentials(
 	ctx context.Context,
 	namespace string,
@@ -281,10 +391,7 @@ func getAndValidateRobotCredentials(
 		return robotclient.Credentials{}, err
 	}
 
-	creds := robotclient.Credentials{
-		Username: string(hetznerSecret.Data[hetznerCluster.Spec.HetznerSecret.Key.HetznerRobotUser]),
-		Password: string(hetznerSecret.Data[hetznerCluster.Spec.HetznerSecret.Key.HetznerRobotPassword]),
-	}
+	creds := activeRobotCredentials(hetznerCluster, hetznerSecret)
 
 	// Validate token
 	if creds.Username == "" {
@@ -162,7 +162,7 @@ var _ = Describe("HetznerBareMetalHostReconciler", func() {
 
 		osSSHClientAfterInstallImage.On("Reboot").Return(sshclient.Output{})
 		osSSHClientAfterInstallImage.On("CreateNoCloudDirectory").Return(sshclient.Output{})
-		osSSHClientAfterInstallImage.On("CreateMetaData", mock.Anything).Return(sshclient.Output{})
+		osSSHClientAfterInstallImage.On("CreateMetaData", mock.Anything, mock.Anything).Return(sshclient.Output{})
 		osSSHClientAfterInstallImage.On("CreateUserData", mock.Anything).Return(sshclient.Output{})
 		osSSHClientAfterInstallImage.On("EnsureCloudInit").Return(sshclient.Output{StdOut: "cloud-init"})
 		osSSHClientAfterInstallImage.On("CloudInitStatus").Return(sshclient.Output{StdOut: "status: done"})
@@ -155,6 +155,11 @@ func (r *HetznerBareMetalMachineReconciler) Reconcile(ctx context.Context, req r
 		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
 	}
 
+	// control planes and deletions are prioritized when the rate limit budget runs low
+	if machineScope.IsControlPlane() || !hbmMachine.ObjectMeta.DeletionTimestamp.IsZero() {
+		ctx = hcloudclient.WithPriority(ctx, hcloudclient.PriorityHigh)
+	}
+
 	if !hbmMachine.ObjectMeta.DeletionTimestamp.IsZero() {
 		return r.reconcileDelete(ctx, machineScope)
 	}
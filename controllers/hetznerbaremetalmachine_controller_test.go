@@ -148,7 +148,7 @@ var _ = Describe("HetznerBareMetalMachineReconciler", func() {
 
 		osSSHClient.On("Reboot").Return(sshclient.Output{})
 		osSSHClient.On("CreateNoCloudDirectory").Return(sshclient.Output{})
-		osSSHClient.On("CreateMetaData", mock.Anything).Return(sshclient.Output{})
+		osSSHClient.On("CreateMetaData", mock.Anything, mock.Anything).Return(sshclient.Output{})
 		osSSHClient.On("CreateUserData", mock.Anything).Return(sshclient.Output{})
 		osSSHClient.On("EnsureCloudInit").Return(sshclient.Output{StdOut: "cloud-init"})
 		osSSHClient.On("CloudInitStatus").Return(sshclient.Output{StdOut: "status: done"})
@@ -0,0 +1,148 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
+	"github.com/syself/cluster-api-provider-hetzner/pkg/scope"
+	"github.com/syself/cluster-api-provider-hetzner/pkg/services/baremetal/machinetemplate"
+)
+
+// HetznerBareMetalMachineTemplateReconciler reconciles a HetznerBareMetalMachineTemplate object.
+type HetznerBareMetalMachineTemplateReconciler struct {
+	client.Client
+	WatchFilterValue string
+}
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=hetznerbaremetalmachinetemplates,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=hetznerbaremetalmachinetemplates/status,verbs=get;update;patch
+
+// Reconcile computes the capacity of a HetznerBareMetalMachineTemplate from the hosts matching its host selector.
+func (r *HetznerBareMetalMachineTemplateReconciler) Reconcile(ctx context.Context, req reconcile.Request) (_ reconcile.Result, reterr error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	machineTemplate := &infrav1.HetznerBareMetalMachineTemplate{}
+	if err := r.Get(ctx, req.NamespacedName, machineTemplate); err != nil {
+		log.Error(err, "unable to fetch HetznerBareMetalMachineTemplate")
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+
+	log = log.WithValues("HetznerBareMetalMachineTemplate", klog.KObj(machineTemplate))
+
+	patchHelper, err := patch.NewHelper(machineTemplate, r.Client)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to get patch helper: %w", err)
+	}
+
+	defer func() {
+		conditions.SetSummary(machineTemplate)
+		if err := patchHelper.Patch(ctx, machineTemplate); err != nil {
+			log.Error(err, "failed to patch HetznerBareMetalMachineTemplate")
+		}
+	}()
+
+	// Check whether owner is a ClusterClass. In that case there is nothing to do.
+	if hasOwnerClusterClass(machineTemplate.ObjectMeta) {
+		machineTemplate.Status.OwnerType = "ClusterClass"
+		return reconcile.Result{}, nil
+	}
+
+	// The capacity only depends on the hosts in the namespace, so a missing owner cluster is no reason to wait.
+	cluster, err := util.GetOwnerCluster(ctx, r.Client, machineTemplate.ObjectMeta)
+	if err == nil && cluster != nil {
+		machineTemplate.Status.OwnerType = cluster.Kind
+		log = log.WithValues("Cluster", klog.KObj(cluster))
+	}
+
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	machineTemplateScope, err := scope.NewBareMetalMachineTemplateScope(scope.BareMetalMachineTemplateScopeParams{
+		Client:                   r.Client,
+		Logger:                   &log,
+		BareMetalMachineTemplate: machineTemplate,
+	})
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to create scope: %w", err)
+	}
+
+	if err := machinetemplate.NewService(machineTemplateScope).Reconcile(ctx); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to reconcile machine template for HetznerBareMetalMachineTemplate %s/%s: %w",
+			machineTemplate.Namespace, machineTemplate.Name, err)
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *HetznerBareMetalMachineTemplateReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	log := ctrl.LoggerFrom(ctx)
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options).
+		For(&infrav1.HetznerBareMetalMachineTemplate{}).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(log, r.WatchFilterValue)).
+		Watches(
+			&infrav1.HetznerBareMetalHost{},
+			handler.EnqueueRequestsFromMapFunc(r.BareMetalHostToBareMetalMachineTemplates(log)),
+		).
+		Complete(r)
+}
+
+// BareMetalHostToBareMetalMachineTemplates will return a reconcile request for all
+// HetznerBareMetalMachineTemplates in the namespace of a HetznerBareMetalHost.
+func (r *HetznerBareMetalMachineTemplateReconciler) BareMetalHostToBareMetalMachineTemplates(log logr.Logger) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		host, ok := obj.(*infrav1.HetznerBareMetalHost)
+		if !ok {
+			log.Error(fmt.Errorf("expected a BareMetalHost but got a %T", obj),
+				"failed to get BareMetalMachineTemplates for BareMetalHost")
+			return nil
+		}
+
+		machineTemplates := &infrav1.HetznerBareMetalMachineTemplateList{}
+		if err := r.List(ctx, machineTemplates, client.InNamespace(host.Namespace)); err != nil {
+			log.Error(err, "failed to list HetznerBareMetalMachineTemplates", "namespace", host.Namespace)
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(machineTemplates.Items))
+		for _, machineTemplate := range machineTemplates.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: machineTemplate.Namespace,
+					Name:      machineTemplate.Name,
+				},
+			})
+		}
+		return requests
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
+	"github.com/syself/cluster-api-provider-hetzner/test/helpers"
+)
+
+var _ = Describe("HetznerBareMetalMachineTemplateReconciler", func() {
+	var (
+		machineTemplate *infrav1.HetznerBareMetalMachineTemplate
+		hosts           []*infrav1.HetznerBareMetalHost
+		testNs          *corev1.Namespace
+		key             client.ObjectKey
+	)
+
+	withHardwareDetails := func(threads, ramGB int) helpers.HostOpts {
+		return func(host *infrav1.HetznerBareMetalHost) {
+			host.Labels = map[string]string{"role": "worker"}
+			host.Spec.Status.HardwareDetails = &infrav1.HardwareDetails{
+				RAMGB: ramGB,
+				CPU:   infrav1.CPU{Arch: "x86_64", Threads: threads},
+			}
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		testNs, err = testEnv.CreateNamespace(ctx, "baremetalmachinetemplate-reconciler")
+		Expect(err).NotTo(HaveOccurred())
+
+		hosts = []*infrav1.HetznerBareMetalHost{
+			helpers.BareMetalHost("host-small", testNs.Name, withHardwareDetails(8, 32)),
+			helpers.BareMetalHost("host-big", testNs.Name, withHardwareDetails(16, 64)),
+		}
+		for _, host := range hosts {
+			Expect(testEnv.Create(ctx, host)).To(Succeed())
+		}
+
+		machineTemplateSpec := getDefaultHetznerBareMetalMachineSpec()
+		machineTemplateSpec.HostSelector = infrav1.HostSelector{
+			MatchLabels: map[string]string{"role": "worker"},
+		}
+
+		machineTemplate = &infrav1.HetznerBareMetalMachineTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "bm-machine-template",
+				Namespace: testNs.Name,
+			},
+			Spec: infrav1.HetznerBareMetalMachineTemplateSpec{
+				Template: infrav1.HetznerBareMetalMachineTemplateResource{
+					Spec: machineTemplateSpec,
+				},
+			},
+		}
+		Expect(testEnv.Create(ctx, machineTemplate)).To(Succeed())
+
+		key = client.ObjectKeyFromObject(machineTemplate)
+	})
+
+	AfterEach(func() {
+		objects := []client.Object{testNs, machineTemplate}
+		for _, host := range hosts {
+			objects = append(objects, host)
+		}
+		Expect(testEnv.Cleanup(ctx, objects...)).To(Succeed())
+	})
+
+	It("computes the capacity from the matching hosts", func() {
+		Eventually(func() bool {
+			if err := testEnv.Get(ctx, key, machineTemplate); err != nil {
+				return false
+			}
+			return machineTemplate.Status.AvailableHosts == 2 &&
+				machineTemplate.Status.Capacity.Cpu().Equal(resource.MustParse("8")) &&
+				machineTemplate.Status.Capacity.Memory().Equal(resource.MustParse("32G"))
+		}, timeout).Should(BeTrue())
+	})
+
+	It("updates the capacity when a host is no longer available", func() {
+		Eventually(func() int {
+			if err := testEnv.Get(ctx, key, machineTemplate); err != nil {
+				return -1
+			}
+			return machineTemplate.Status.AvailableHosts
+		}, timeout).Should(Equal(2))
+
+		ph, err := patch.NewHelper(hosts[0], testEnv)
+		Expect(err).ShouldNot(HaveOccurred())
+		hosts[0].Spec.MaintenanceMode = ptr.To(true)
+		Expect(ph.Patch(ctx, hosts[0], patch.WithStatusObservedGeneration{})).To(Succeed())
+
+		Eventually(func() bool {
+			if err := testEnv.Get(ctx, key, machineTemplate); err != nil {
+				return false
+			}
+			return machineTemplate.Status.AvailableHosts == 1 &&
+				machineTemplate.Status.Capacity.Cpu().Equal(resource.MustParse("16"))
+		}, timeout).Should(BeTrue())
+	})
+})
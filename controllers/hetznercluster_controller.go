@@ -18,7 +18,11 @@ limitations under the License.
 package controllers
 
 import (
+	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"strconv"
@@ -26,6 +30,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	certificatesv1 "k8s.io/api/certificates/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -33,6 +38,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -54,7 +60,12 @@ import (
 	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
 	"github.com/syself/cluster-api-provider-hetzner/pkg/scope"
 	secretutil "github.com/syself/cluster-api-provider-hetzner/pkg/secrets"
+	robotclient "github.com/syself/cluster-api-provider-hetzner/pkg/services/baremetal/client/robot"
+	"github.com/syself/cluster-api-provider-hetzner/pkg/services/baremetal/host"
+	"github.com/syself/cluster-api-provider-hetzner/pkg/services/baremetal/vswitch"
 	hcloudclient "github.com/syself/cluster-api-provider-hetzner/pkg/services/hcloud/client"
+	"github.com/syself/cluster-api-provider-hetzner/pkg/services/hcloud/firewall"
+	"github.com/syself/cluster-api-provider-hetzner/pkg/services/hcloud/floatingip"
 	"github.com/syself/cluster-api-provider-hetzner/pkg/services/hcloud/loadbalancer"
 	"github.com/syself/cluster-api-provider-hetzner/pkg/services/hcloud/network"
 	"github.com/syself/cluster-api-provider-hetzner/pkg/services/hcloud/placementgroup"
@@ -70,6 +81,7 @@ type HetznerClusterReconciler struct {
 	RateLimitWaitTime              time.Duration
 	APIReader                      client.Reader
 	HCloudClientFactory            hcloudclient.Factory
+	RobotClientFactory             robotclient.Factory
 	targetClusterManagersStopCh    map[types.NamespacedName]chan struct{}
 	targetClusterManagersLock      sync.Mutex
 	TargetClusterManagersWaitGroup *sync.WaitGroup
@@ -106,6 +118,9 @@ func (r *HetznerClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	log = log.WithValues("Cluster", klog.KObj(cluster))
 	ctx = ctrl.LoggerInto(ctx, log)
 
+	// the infrastructure of the cluster is needed by the control plane, so its HCloud calls have high priority
+	ctx = hcloudclient.WithPriority(ctx, hcloudclient.PriorityHigh)
+
 	if cluster == nil {
 		log.Info("Cluster Controller has not yet set OwnerRef")
 		return reconcile.Result{
@@ -126,6 +141,15 @@ func (r *HetznerClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	}
 	hcloudClient := r.HCloudClientFactory.NewClient(hcloudToken)
 
+	// the robot client is only needed for the vSwitch and the failover IP of the cluster
+	var robotClient robotclient.Client
+	if usesRobotAPI(hetznerCluster) && r.RobotClientFactory != nil {
+		robotCreds := activeRobotCredentials(hetznerCluster, hetznerSecret)
+		if robotCreds.Username != "" && robotCreds.Password != "" {
+			robotClient = r.RobotClientFactory.NewClient(robotCreds)
+		}
+	}
+
 	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
 		Client:         r.Client,
 		APIReader:      r.APIReader,
@@ -133,6 +157,7 @@ func (r *HetznerClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		Cluster:        cluster,
 		HetznerCluster: hetznerCluster,
 		HCloudClient:   hcloudClient,
+		RobotClient:    robotClient,
 		HetznerSecret:  hetznerSecret,
 	})
 	if err != nil {
@@ -157,6 +182,19 @@ func (r *HetznerClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
 	}
 
+	// switch the clients over to the next credentials of the Hetzner secret as soon as they are valid
+	if rotated := r.reconcileCredentialsRotation(ctx, hetznerCluster, hetznerSecret); rotated {
+		activeToken := activeHCloudToken(hetznerCluster, hetznerSecret)
+		if activeToken != hcloudToken {
+			// the token that has been used so far is retired
+			r.HCloudClientFactory.ReleaseClient(hcloudToken)
+		}
+		clusterScope.HCloudClient = r.HCloudClientFactory.NewClient(activeToken)
+		if clusterScope.RobotClient != nil {
+			clusterScope.RobotClient = r.RobotClientFactory.NewClient(activeRobotCredentials(hetznerCluster, hetznerSecret))
+		}
+	}
+
 	// Handle deleted clusters
 	if !hetznerCluster.DeletionTimestamp.IsZero() {
 		return r.reconcileDelete(ctx, clusterScope)
@@ -178,6 +216,11 @@ func (r *HetznerClusterReconciler) reconcileNormal(ctx context.Context, clusterS
 	// set failure domains in status using information in spec
 	clusterScope.SetStatusFailureDomain(clusterScope.GetSpecRegion())
 
+	// reconcile the vSwitch before the network, as its subnet is added to the network
+	if err := vswitch.NewService(clusterScope).Reconcile(); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to reconcile vSwitch for HetznerCluster %s/%s: %w", hetznerCluster.Namespace, hetznerCluster.Name, err)
+	}
+
 	// reconcile the network
 	if err := network.NewService(clusterScope).Reconcile(ctx); err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to reconcile network for HetznerCluster %s/%s: %w", hetznerCluster.Namespace, hetznerCluster.Name, err)
@@ -199,9 +242,24 @@ func (r *HetznerClusterReconciler) reconcileNormal(ctx context.Context, clusterS
 		return reconcile.Result{}, fmt.Errorf("failed to reconcile placement groups for HetznerCluster %s/%s: %w", hetznerCluster.Namespace, hetznerCluster.Name, err)
 	}
 
+	// reconcile the firewalls
+	if err := firewall.NewService(clusterScope).Reconcile(ctx); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to reconcile firewalls for HetznerCluster %s/%s: %w", hetznerCluster.Namespace, hetznerCluster.Name, err)
+	}
+
+	// reconcile the floating IP used as control plane endpoint
+	if err := floatingip.NewService(clusterScope).Reconcile(ctx); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to reconcile control plane floating IP for HetznerCluster %s/%s: %w", hetznerCluster.Namespace, hetznerCluster.Name, err)
+	}
+
 	if hetznerCluster.Spec.ControlPlaneLoadBalancer.Enabled {
-		if hetznerCluster.Status.ControlPlaneLoadBalancer.IPv4 != "<nil>" {
-			defaultHost := hetznerCluster.Status.ControlPlaneLoadBalancer.IPv4
+		defaultHost := hetznerCluster.Status.ControlPlaneLoadBalancer.IPv4
+		if hetznerCluster.Spec.ControlPlaneLoadBalancer.Private {
+			// a private load balancer is only reachable via the network
+			defaultHost = hetznerCluster.Status.ControlPlaneLoadBalancer.InternalIP
+		}
+
+		if defaultHost != "" && defaultHost != "<nil>" {
 			defaultPort := int32(hetznerCluster.Spec.ControlPlaneLoadBalancer.Port)
 
 			if hetznerCluster.Spec.ControlPlaneEndpoint == nil {
@@ -218,6 +276,23 @@ func (r *HetznerClusterReconciler) reconcileNormal(ctx context.Context, clusterS
 				}
 			}
 
+			hetznerCluster.Status.Ready = true
+		}
+	} else if hetznerCluster.Spec.ControlPlaneLoadBalancer.FloatingIP != nil {
+		if hetznerCluster.Status.ControlPlaneFloatingIP != nil && hetznerCluster.Status.ControlPlaneFloatingIP.IP != "" {
+			defaultHost := hetznerCluster.Status.ControlPlaneFloatingIP.IP
+			defaultPort := int32(hetznerCluster.Spec.ControlPlaneLoadBalancer.Port)
+
+			if hetznerCluster.Spec.ControlPlaneEndpoint == nil {
+				hetznerCluster.Spec.ControlPlaneEndpoint = &clusterv1.APIEndpoint{}
+			}
+			if hetznerCluster.Spec.ControlPlaneEndpoint.Host == "" {
+				hetznerCluster.Spec.ControlPlaneEndpoint.Host = defaultHost
+			}
+			if hetznerCluster.Spec.ControlPlaneEndpoint.Port == 0 {
+				hetznerCluster.Spec.ControlPlaneEndpoint.Port = defaultPort
+			}
+
 			hetznerCluster.Status.Ready = true
 		}
 	} else if hetznerCluster.Spec.ControlPlaneEndpoint != nil {
@@ -257,9 +332,33 @@ func (r *HetznerClusterReconciler) reconcileNormal(ctx context.Context, clusterS
 	// target cluster secret is ready
 	conditions.MarkTrue(hetznerCluster, infrav1.TargetClusterSecretReadyCondition)
 
+	// the API servers have to be checked regularly to reassign the floating IP if necessary
+	if hetznerCluster.Spec.ControlPlaneLoadBalancer.FloatingIP != nil {
+		return reconcile.Result{RequeueAfter: floatingip.HealthCheckInterval}, nil
+	}
+
 	return reconcile.Result{}, nil
 }
 
+// listHostsWipingDisks returns the names of the hosts of the cluster that wipe their disks and have not given up.
+func (r *HetznerClusterReconciler) listHostsWipingDisks(ctx context.Context, hetznerCluster *infrav1.HetznerCluster) ([]string, error) {
+	hosts := &infrav1.HetznerBareMetalHostList{}
+	if err := r.Client.List(ctx, hosts, client.InNamespace(hetznerCluster.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list hosts: %w", err)
+	}
+
+	var names []string
+	for i := range hosts.Items {
+		h := &hosts.Items[i]
+		if h.Spec.Status.HetznerClusterRef == hetznerCluster.Name &&
+			h.Spec.Status.ProvisioningState == infrav1.StateWipingDisks &&
+			!host.DiskWipeFailedPermanently(h) {
+			names = append(names, fmt.Sprintf("host/%s", h.Name))
+		}
+	}
+	return names, nil
+}
+
 func (r *HetznerClusterReconciler) reconcileDelete(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
 	hetznerCluster := clusterScope.HetznerCluster
 
@@ -282,6 +381,21 @@ func (r *HetznerClusterReconciler) reconcileDelete(ctx context.Context, clusterS
 		return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
 	}
 
+	// wait for released hosts that still wipe their disks, as they need the credentials and the rescue SSH key
+	hostNames, err := r.listHostsWipingDisks(ctx, hetznerCluster)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to list hosts wiping disks for HetznerCluster %s/%s: %w", hetznerCluster.Namespace, hetznerCluster.Name, err)
+	}
+	if len(hostNames) > 0 {
+		record.Eventf(
+			hetznerCluster,
+			"WaitingForDiskWipe",
+			"Hosts %s still wipe their disks, waiting with deletion of HetznerCluster",
+			strings.Join(hostNames, ", "),
+		)
+		return reconcile.Result{RequeueAfter: time.Minute}, nil
+	}
+
 	secretManager := secretutil.NewSecretManager(clusterScope.Logger, r.Client, r.APIReader)
 	// Remove finalizer of secret
 	if err := secretManager.ReleaseSecret(ctx, clusterScope.HetznerSecret(), clusterScope.HetznerCluster); err != nil {
@@ -311,16 +425,31 @@ func (r *HetznerClusterReconciler) reconcileDelete(ctx context.Context, clusterS
 		return reconcile.Result{}, fmt.Errorf("failed to delete load balancers for HetznerCluster %s/%s: %w", hetznerCluster.Namespace, hetznerCluster.Name, err)
 	}
 
+	// delete the floating IP used as control plane endpoint
+	if err := floatingip.NewService(clusterScope).Delete(ctx); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to delete control plane floating IP for HetznerCluster %s/%s: %w", hetznerCluster.Namespace, hetznerCluster.Name, err)
+	}
+
 	// delete the network
 	if err := network.NewService(clusterScope).Delete(ctx); err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to delete network for HetznerCluster %s/%s: %w", hetznerCluster.Namespace, hetznerCluster.Name, err)
 	}
 
+	// delete the vSwitch after the network it is coupled with
+	if err := vswitch.NewService(clusterScope).Delete(); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to delete vSwitch for HetznerCluster %s/%s: %w", hetznerCluster.Namespace, hetznerCluster.Name, err)
+	}
+
 	// delete the placement groups
 	if err := placementgroup.NewService(clusterScope).Delete(ctx); err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to delete placement groups for HetznerCluster %s/%s: %w", hetznerCluster.Namespace, hetznerCluster.Name, err)
 	}
 
+	// delete the firewalls
+	if err := firewall.NewService(clusterScope).Delete(ctx); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to delete firewalls for HetznerCluster %s/%s: %w", hetznerCluster.Namespace, hetznerCluster.Name, err)
+	}
+
 	// Stop CSR manager
 	r.targetClusterManagersLock.Lock()
 	defer r.targetClusterManagersLock.Unlock()
@@ -375,7 +504,7 @@ func getAndValidateHCloudToken(ctx context.Context, namespace string, hetznerClu
 		return "", nil, err
 	}
 
-	hcloudToken := string(hetznerSecret.Data[hetznerCluster.Spec.HetznerSecret.Key.HCloudToken])
+	hcloudToken := activeHCloudToken(hetznerCluster, hetznerSecret)
 
 	// Validate token
 	if hcloudToken == "" {
@@ -385,6 +514,156 @@ func getAndValidateHCloudToken(ctx context.Context, namespace string, hetznerClu
 	return hcloudToken, hetznerSecret, nil
 }
 
+// credentialsHash returns the HMAC of next credentials that is stored in the status of the HetznerCluster. It is
+// keyed with the credentials in use, so that guesses of the next credentials cannot be checked against the status
+// without knowing the credentials in use.
+func credentialsHash(key []byte, values ...[]byte) string {
+	mac := hmac.New(sha256.New, key)
+	for _, value := range values {
+		mac.Write(value)
+		mac.Write([]byte{0})
+	}
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// hcloudTokenHash returns the hash of the next HCloud token, keyed with the HCloud token.
+func hcloudTokenHash(hetznerCluster *infrav1.HetznerCluster, hetznerSecret *corev1.Secret, nextToken []byte) string {
+	return credentialsHash(hetznerSecret.Data[hetznerCluster.Spec.HetznerSecret.Key.HCloudToken], nextToken)
+}
+
+// robotCredentialsHash returns the hash of the next Robot credentials, keyed with the Robot credentials. Without
+// Robot credentials, it is keyed with the HCloud token, which is always set.
+func robotCredentialsHash(hetznerCluster *infrav1.HetznerCluster, hetznerSecret *corev1.Secret, nextUser, nextPassword []byte) string {
+	keys := hetznerCluster.Spec.HetznerSecret.Key
+	user := hetznerSecret.Data[keys.HetznerRobotUser]
+	password := hetznerSecret.Data[keys.HetznerRobotPassword]
+	if len(user) == 0 && len(password) == 0 {
+		return credentialsHash(hetznerSecret.Data[keys.HCloudToken], nextUser, nextPassword)
+	}
+	return credentialsHash(bytes.Join([][]byte{user, password}, []byte{0}), nextUser, nextPassword)
+}
+
+// activeHCloudToken returns the next HCloud token of the Hetzner secret if it has been validated
+// and the HCloud token otherwise.
+func activeHCloudToken(hetznerCluster *infrav1.HetznerCluster, hetznerSecret *corev1.Secret) string {
+	keys := hetznerCluster.Spec.HetznerSecret.Key
+	if keys.NextHCloudToken != "" && hetznerCluster.Status.Credentials != nil {
+		nextToken := hetznerSecret.Data[keys.NextHCloudToken]
+		if len(nextToken) > 0 && hcloudTokenHash(hetznerCluster, hetznerSecret, nextToken) == hetznerCluster.Status.Credentials.HCloudTokenHash {
+			return string(nextToken)
+		}
+	}
+	return string(hetznerSecret.Data[keys.HCloudToken])
+}
+
+// activeRobotCredentials returns the next Robot credentials of the Hetzner secret if they have been validated
+// and the Robot credentials otherwise.
+func activeRobotCredentials(hetznerCluster *infrav1.HetznerCluster, hetznerSecret *corev1.Secret) robotclient.Credentials {
+	keys := hetznerCluster.Spec.HetznerSecret.Key
+	if keys.NextHetznerRobotUser != "" && keys.NextHetznerRobotPassword != "" && hetznerCluster.Status.Credentials != nil {
+		nextUser := hetznerSecret.Data[keys.NextHetznerRobotUser]
+		nextPassword := hetznerSecret.Data[keys.NextHetznerRobotPassword]
+		if len(nextUser) > 0 && len(nextPassword) > 0 &&
+			robotCredentialsHash(hetznerCluster, hetznerSecret, nextUser, nextPassword) == hetznerCluster.Status.Credentials.RobotCredentialsHash {
+			return robotclient.Credentials{Username: string(nextUser), Password: string(nextPassword)}
+		}
+	}
+	return robotclient.Credentials{
+		Username: string(hetznerSecret.Data[keys.HetznerRobotUser]),
+		Password: string(hetznerSecret.Data[keys.HetznerRobotPassword]),
+	}
+}
+
+// usesRobotAPI returns whether the HetznerCluster has resources that are managed via the robot API.
+func usesRobotAPI(hetznerCluster *infrav1.HetznerCluster) bool {
+	floatingIP := hetznerCluster.Spec.ControlPlaneLoadBalancer.FloatingIP
+	return hetznerCluster.Spec.RobotVSwitch != nil ||
+		(floatingIP != nil && floatingIP.Type == infrav1.ControlPlaneFloatingIPTypeFailover)
+}
+
+// reconcileCredentialsRotation validates the next credentials of the Hetzner secret. Valid credentials are recorded
+// in the status of the HetznerCluster, which makes all controllers of the cluster use them. It returns true if the
+// credentials in use changed.
+func (r *HetznerClusterReconciler) reconcileCredentialsRotation(
+	ctx context.Context,
+	hetznerCluster *infrav1.HetznerCluster,
+	hetznerSecret *corev1.Secret,
+) (rotated bool) {
+	keys := hetznerCluster.Spec.HetznerSecret.Key
+
+	var nextToken, nextRobotUser, nextRobotPassword []byte
+	if keys.NextHCloudToken != "" {
+		nextToken = hetznerSecret.Data[keys.NextHCloudToken]
+	}
+	if keys.NextHetznerRobotUser != "" && keys.NextHetznerRobotPassword != "" {
+		nextRobotUser = hetznerSecret.Data[keys.NextHetznerRobotUser]
+		nextRobotPassword = hetznerSecret.Data[keys.NextHetznerRobotPassword]
+	}
+	hasNextRobotCredentials := len(nextRobotUser) > 0 && len(nextRobotPassword) > 0
+
+	// no rotation in progress
+	if len(nextToken) == 0 && !hasNextRobotCredentials {
+		rotated = hetznerCluster.Status.Credentials != nil
+		hetznerCluster.Status.Credentials = nil
+		conditions.Delete(hetznerCluster, infrav1.CredentialsRotatedCondition)
+		return rotated
+	}
+
+	if hetznerCluster.Status.Credentials == nil {
+		hetznerCluster.Status.Credentials = &infrav1.CredentialsStatus{}
+	}
+	status := hetznerCluster.Status.Credentials
+
+	var reason, message string
+
+	switch hash := hcloudTokenHash(hetznerCluster, hetznerSecret, nextToken); {
+	case len(nextToken) == 0:
+		rotated = status.HCloudTokenHash != ""
+		status.HCloudTokenHash = ""
+	case hash != status.HCloudTokenHash:
+		hcloudClient := r.HCloudClientFactory.NewClient(string(nextToken))
+		if _, err := hcloudClient.ListSSHKeys(ctx, hcloud.SSHKeyListOpts{}); err != nil {
+			// do not keep the client of an invalid token
+			r.HCloudClientFactory.ReleaseClient(string(nextToken))
+			reason = infrav1.NextHCloudTokenInvalidReason
+			message = fmt.Sprintf("failed to validate next hcloud token: %s", err)
+			break
+		}
+		status.HCloudTokenHash = hash
+		rotated = true
+	}
+
+	switch hash := robotCredentialsHash(hetznerCluster, hetznerSecret, nextRobotUser, nextRobotPassword); {
+	case !hasNextRobotCredentials:
+		rotated = rotated || status.RobotCredentialsHash != ""
+		status.RobotCredentialsHash = ""
+	case hash != status.RobotCredentialsHash && r.RobotClientFactory != nil:
+		robotClient := r.RobotClientFactory.NewClient(robotclient.Credentials{
+			Username: string(nextRobotUser),
+			Password: string(nextRobotPassword),
+		})
+		if err := robotClient.ValidateCredentials(); err != nil {
+			reason = infrav1.NextRobotCredentialsInvalidReason
+			message = fmt.Sprintf("failed to validate next robot credentials: %s", err)
+			break
+		}
+		status.RobotCredentialsHash = hash
+		rotated = true
+	}
+
+	if reason != "" {
+		record.Warn(hetznerCluster, reason, message)
+		conditions.MarkFalse(hetznerCluster, infrav1.CredentialsRotatedCondition, reason, clusterv1.ConditionSeverityWarning, message)
+	} else {
+		conditions.MarkTrue(hetznerCluster, infrav1.CredentialsRotatedCondition)
+	}
+
+	if rotated {
+		record.Event(hetznerCluster, "CredentialsRotated", "Switched to the next credentials of the Hetzner secret")
+	}
+	return rotated
+}
+
 func hcloudTokenErrorResult(
 	ctx context.Context,
 	err error,
@@ -463,70 +742,129 @@ func reconcileTargetSecret(ctx context.Context, clusterScope *scope.ClusterScope
 		return res, fmt.Errorf("failed to get client set: %w", err)
 	}
 
-	if _, err := clientSet.CoreV1().Secrets(metav1.NamespaceSystem).Get(
-		ctx,
-		clusterScope.HetznerCluster.Spec.HetznerSecret.Name,
-		metav1.GetOptions{},
-	); err != nil {
-		// Set new secret only when no secret was found
-		if !strings.HasSuffix(err.Error(), "not found") {
-			return res, fmt.Errorf("failed to get secret: %w", err)
-		}
+	tokenSecretName := types.NamespacedName{
+		Namespace: clusterScope.HetznerCluster.Namespace,
+		Name:      clusterScope.HetznerCluster.Spec.HetznerSecret.Name,
+	}
+	secretManager := secretutil.NewSecretManager(clusterScope.Logger, clusterScope.Client, clusterScope.APIReader)
+	tokenSecret, err := secretManager.AcquireSecret(ctx, tokenSecretName, clusterScope.HetznerCluster, false, clusterScope.HetznerCluster.DeletionTimestamp.IsZero())
+	if err != nil {
+		return res, fmt.Errorf("failed to acquire secret: %w", err)
+	}
 
-		tokenSecretName := types.NamespacedName{
-			Namespace: clusterScope.HetznerCluster.Namespace,
-			Name:      clusterScope.HetznerCluster.Spec.HetznerSecret.Name,
-		}
-		secretManager := secretutil.NewSecretManager(clusterScope.Logger, clusterScope.Client, clusterScope.APIReader)
-		tokenSecret, err := secretManager.AcquireSecret(ctx, tokenSecretName, clusterScope.HetznerCluster, false, clusterScope.HetznerCluster.DeletionTimestamp.IsZero())
-		if err != nil {
-			return res, fmt.Errorf("failed to acquire secret: %w", err)
-		}
+	data, err := targetSecretData(clusterScope.HetznerCluster, tokenSecret)
+	if err != nil {
+		return res, err
+	}
 
-		hetznerToken, keyExists := tokenSecret.Data[clusterScope.HetznerCluster.Spec.HetznerSecret.Key.HCloudToken]
-		if !keyExists {
-			return res, fmt.Errorf(
-				"error key %s does not exist in secret/%s: %w",
-				clusterScope.HetznerCluster.Spec.HetznerSecret.Key.HCloudToken,
-				tokenSecretName,
-				err,
-			)
+	if err := syncTargetSecret(ctx, clientSet.CoreV1().Secrets(metav1.NamespaceSystem), clusterScope.HetznerCluster, data); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// targetSecretName returns the name of the secret in the workload cluster.
+func targetSecretName(hetznerCluster *infrav1.HetznerCluster) string {
+	if hetznerCluster.Spec.TargetSecret.Name != "" {
+		return hetznerCluster.Spec.TargetSecret.Name
+	}
+	return hetznerCluster.Spec.HetznerSecret.Name
+}
+
+// targetSecretData builds the data of the secret in the workload cluster from the Hetzner secret.
+func targetSecretData(hetznerCluster *infrav1.HetznerCluster, tokenSecret *corev1.Secret) (map[string][]byte, error) {
+	sourceKeys := hetznerCluster.Spec.HetznerSecret.Key
+	targetKeys := hetznerCluster.Spec.TargetSecret.Key
+
+	keyOrDefault := func(key, defaultKey string) string {
+		if key != "" {
+			return key
 		}
+		return defaultKey
+	}
 
-		var immutable bool
-		data := make(map[string][]byte)
-		data[clusterScope.HetznerCluster.Spec.HetznerSecret.Key.HCloudToken] = hetznerToken
-
-		// Save robot credentials if available (even it empty)
-		robotUserName := tokenSecret.Data[clusterScope.HetznerCluster.Spec.HetznerSecret.Key.HetznerRobotUser]
-		data[clusterScope.HetznerCluster.Spec.HetznerSecret.Key.HetznerRobotUser] = robotUserName
-		robotPassword := tokenSecret.Data[clusterScope.HetznerCluster.Spec.HetznerSecret.Key.HetznerRobotPassword]
-		data[clusterScope.HetznerCluster.Spec.HetznerSecret.Key.HetznerRobotPassword] = robotPassword
-
-		// Save network ID in secret
-		if clusterScope.HetznerCluster.Spec.HCloudNetwork.Enabled {
-			data["network"] = []byte(strconv.FormatInt(clusterScope.HetznerCluster.Status.Network.ID, 10))
+	hetznerToken := activeHCloudToken(hetznerCluster, tokenSecret)
+	if hetznerToken == "" {
+		return nil, fmt.Errorf(
+			"error key %s does not exist in secret/%s",
+			sourceKeys.HCloudToken,
+			client.ObjectKeyFromObject(tokenSecret),
+		)
+	}
+
+	data := make(map[string][]byte)
+	data[keyOrDefault(targetKeys.HCloudToken, sourceKeys.HCloudToken)] = []byte(hetznerToken)
+
+	// Save robot credentials if available (even it empty)
+	robotCreds := activeRobotCredentials(hetznerCluster, tokenSecret)
+	data[keyOrDefault(targetKeys.HetznerRobotUser, sourceKeys.HetznerRobotUser)] = []byte(robotCreds.Username)
+	data[keyOrDefault(targetKeys.HetznerRobotPassword, sourceKeys.HetznerRobotPassword)] = []byte(robotCreds.Password)
+
+	// Save network ID in secret
+	if hetznerCluster.Spec.HCloudNetwork.Enabled {
+		data[keyOrDefault(targetKeys.Network, "network")] = []byte(strconv.FormatInt(hetznerCluster.Status.Network.ID, 10))
+	}
+
+	// Save api server information
+	if hetznerCluster.Spec.ControlPlaneEndpoint != nil {
+		data[keyOrDefault(targetKeys.APIServerHost, "apiserver-host")] = []byte(hetznerCluster.Spec.ControlPlaneEndpoint.Host)
+		data[keyOrDefault(targetKeys.APIServerPort, "apiserver-port")] = []byte(strconv.Itoa(int(hetznerCluster.Spec.ControlPlaneEndpoint.Port)))
+	}
+
+	return data, nil
+}
+
+// syncTargetSecret creates the secret in the workload cluster or updates it if its content differs from data.
+func syncTargetSecret(ctx context.Context, secrets typedcorev1.SecretInterface, hetznerCluster *infrav1.HetznerCluster, data map[string][]byte) error {
+	name := targetSecretName(hetznerCluster)
+
+	secret, err := secrets.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get secret: %w", err)
 		}
-		// Save api server information
-		data["apiserver-host"] = []byte(clusterScope.HetznerCluster.Spec.ControlPlaneEndpoint.Host)
-		data["apiserver-port"] = []byte(strconv.Itoa(int(clusterScope.HetznerCluster.Spec.ControlPlaneEndpoint.Port)))
 
+		var immutable bool
 		newSecret := corev1.Secret{
 			Immutable: &immutable,
 			Data:      data,
-			TypeMeta:  metav1.TypeMeta{},
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      clusterScope.HetznerCluster.Spec.HetznerSecret.Name,
+				Name:      name,
 				Namespace: metav1.NamespaceSystem,
 			},
 		}
 
 		// create secret in cluster
-		if _, err := clientSet.CoreV1().Secrets(metav1.NamespaceSystem).Create(ctx, &newSecret, metav1.CreateOptions{}); err != nil {
-			return res, fmt.Errorf("failed to create secret: %w", err)
+		if _, err := secrets.Create(ctx, &newSecret, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create secret: %w", err)
 		}
+		record.Eventf(hetznerCluster, "TargetSecretCreated", "Created secret %s/%s in workload cluster", metav1.NamespaceSystem, name)
+		return nil
 	}
-	return res, nil
+
+	desiredHash, err := infrav1.HashOfSecretData(data)
+	if err != nil {
+		return fmt.Errorf("failed to compute hash of desired secret data: %w", err)
+	}
+	currentHash, err := infrav1.HashOfSecretData(secret.Data)
+	if err != nil {
+		return fmt.Errorf("failed to compute hash of secret data: %w", err)
+	}
+	if bytes.Equal(desiredHash, currentHash) {
+		return nil
+	}
+
+	// the data of immutable secrets cannot be changed
+	if secret.Immutable != nil && *secret.Immutable {
+		return fmt.Errorf("secret %s/%s in workload cluster is immutable and out of sync", metav1.NamespaceSystem, name)
+	}
+
+	secret.Data = data
+	if _, err := secrets.Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update secret: %w", err)
+	}
+	record.Eventf(hetznerCluster, "TargetSecretUpdated", "Updated secret %s/%s in workload cluster", metav1.NamespaceSystem, name)
+	return nil
 }
 
 func (r *HetznerClusterReconciler) reconcileTargetClusterManager(ctx context.Context, clusterScope *scope.ClusterScope) (res reconcile.Result, err error) {
@@ -711,7 +1049,11 @@ func (r *HetznerClusterReconciler) SetupWithManager(ctx context.Context, mgr ctr
 		For(&infrav1.HetznerCluster{}).
 		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(log, r.WatchFilterValue)).
 		WithEventFilter(predicates.ResourceIsNotExternallyManaged(log)).
-		Owns(&corev1.Secret{}).
+		Watches(
+			&corev1.Secret{},
+			// the Hetzner secret is not controlled by the cluster, so all owners have to be considered
+			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &infrav1.HetznerCluster{}),
+		).
 		Build(r)
 	if err != nil {
 		return fmt.Errorf("error creating controller: %w", err)
@@ -24,7 +24,9 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/utils/ptr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -32,6 +34,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
+	hcloudclient "github.com/syself/cluster-api-provider-hetzner/pkg/services/hcloud/client"
 	"github.com/syself/cluster-api-provider-hetzner/pkg/utils"
 	"github.com/syself/cluster-api-provider-hetzner/test/helpers"
 )
@@ -797,6 +800,11 @@ var _ = Describe("HetznerCluster validation", func() {
 			Expect(testEnv.Create(ctx, hetznerCluster)).ToNot(Succeed())
 		})
 
+		It("should fail with a next robot user without next robot password", func() {
+			hetznerCluster.Spec.HetznerSecret.Key.NextHetznerRobotUser = "next-robot-user"
+			Expect(testEnv.Create(ctx, hetznerCluster)).ToNot(Succeed())
+		})
+
 		It("should fail with a wrong placementGroup type", func() {
 			hetznerCluster.Spec.HCloudPlacementGroups = append(hetznerCluster.Spec.HCloudPlacementGroups, infrav1.HCloudPlacementGroupSpec{
 				Name: "newName",
@@ -804,6 +812,46 @@ var _ = Describe("HetznerCluster validation", func() {
 			})
 			Expect(testEnv.Create(ctx, hetznerCluster)).ToNot(Succeed())
 		})
+
+		It("should fail with an https service without certificates", func() {
+			hetznerCluster.Spec.ControlPlaneLoadBalancer.ExtraServices = []infrav1.LoadBalancerServiceSpec{
+				{Protocol: "https", ListenPort: 443, DestinationPort: 8443},
+			}
+			Expect(testEnv.Create(ctx, hetznerCluster)).ToNot(Succeed())
+		})
+
+		It("should fail with a certificate with both id and domain names", func() {
+			hetznerCluster.Spec.ControlPlaneLoadBalancer.ExtraServices = []infrav1.LoadBalancerServiceSpec{
+				{
+					Protocol:        "https",
+					ListenPort:      443,
+					DestinationPort: 8443,
+					HTTP: &infrav1.LoadBalancerServiceHTTPSpec{
+						Certificates: []infrav1.LoadBalancerCertificateSpec{{ID: ptr.To(int64(1)), DomainNames: []string{"example.com"}}},
+					},
+				},
+			}
+			Expect(testEnv.Create(ctx, hetznerCluster)).ToNot(Succeed())
+		})
+
+		It("should fail with http settings of a tcp health check", func() {
+			hetznerCluster.Spec.ControlPlaneLoadBalancer.APIServerHealthCheck = &infrav1.LoadBalancerHealthCheckSpec{
+				Protocol: infrav1.LoadBalancerHealthCheckProtocolTCP,
+				HTTP:     &infrav1.LoadBalancerHealthCheckHTTPSpec{Path: "/readyz"},
+			}
+			Expect(testEnv.Create(ctx, hetznerCluster)).ToNot(Succeed())
+		})
+
+		It("should fail with a shared load balancer without id or selector", func() {
+			hetznerCluster.Spec.ControlPlaneLoadBalancer.Shared = true
+			Expect(testEnv.Create(ctx, hetznerCluster)).ToNot(Succeed())
+		})
+
+		It("should fail with a private load balancer without network", func() {
+			hetznerCluster.Spec.ControlPlaneLoadBalancer.Private = true
+			hetznerCluster.Spec.HCloudNetwork.Enabled = false
+			Expect(testEnv.Create(ctx, hetznerCluster)).ToNot(Succeed())
+		})
 	})
 })
 
@@ -840,3 +888,208 @@ var _ = Describe("reconcileRateLimit", func() {
 		Expect(reconcileRateLimit(hetznerCluster, testEnv.RateLimitWaitTime)).To(BeFalse())
 	})
 })
+
+// fakeSecrets is an in-memory implementation of the parts of typedcorev1.SecretInterface used for the target secret.
+type fakeSecrets struct {
+	typedcorev1.SecretInterface
+	secrets map[string]*corev1.Secret
+	updates int
+}
+
+func (f *fakeSecrets) Get(_ context.Context, name string, _ metav1.GetOptions) (*corev1.Secret, error) {
+	secret, ok := f.secrets[name]
+	if !ok {
+		return nil, apierrors.NewNotFound(corev1.Resource("secrets"), name)
+	}
+	return secret.DeepCopy(), nil
+}
+
+func (f *fakeSecrets) Create(_ context.Context, secret *corev1.Secret, _ metav1.CreateOptions) (*corev1.Secret, error) {
+	f.secrets[secret.Name] = secret.DeepCopy()
+	return secret, nil
+}
+
+func (f *fakeSecrets) Update(_ context.Context, secret *corev1.Secret, _ metav1.UpdateOptions) (*corev1.Secret, error) {
+	f.secrets[secret.Name] = secret.DeepCopy()
+	f.updates++
+	return secret, nil
+}
+
+var _ = Describe("target secret", func() {
+	var (
+		hetznerCluster *infrav1.HetznerCluster
+		tokenSecret    *corev1.Secret
+		secrets        *fakeSecrets
+	)
+
+	BeforeEach(func() {
+		hetznerCluster = &infrav1.HetznerCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "target-secret-cluster",
+				Namespace: "default",
+			},
+			Spec: getDefaultHetznerClusterSpec(),
+		}
+		hetznerCluster.Spec.ControlPlaneEndpoint = &clusterv1.APIEndpoint{Host: "1.2.3.4", Port: 6443}
+		hetznerCluster.Status.Network = &infrav1.NetworkStatus{ID: 42}
+
+		tokenSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "hetzner-secret", Namespace: "default"},
+			Data: map[string][]byte{
+				"hcloud":         []byte("token"),
+				"robot-user":     []byte("user"),
+				"robot-password": []byte("password"),
+			},
+		}
+
+		secrets = &fakeSecrets{secrets: make(map[string]*corev1.Secret)}
+	})
+
+	It("uses the key names of the Hetzner secret by default", func() {
+		data, err := targetSecretData(hetznerCluster, tokenSecret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(Equal(map[string][]byte{
+			"hcloud":         []byte("token"),
+			"robot-user":     []byte("user"),
+			"robot-password": []byte("password"),
+			"network":        []byte("42"),
+			"apiserver-host": []byte("1.2.3.4"),
+			"apiserver-port": []byte("6443"),
+		}))
+	})
+
+	It("uses the configured key names", func() {
+		hetznerCluster.Spec.TargetSecret.Key = infrav1.TargetSecretKeyRef{
+			HCloudToken: "token",
+			Network:     "network-id",
+		}
+		data, err := targetSecretData(hetznerCluster, tokenSecret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(HaveKeyWithValue("token", []byte("token")))
+		Expect(data).To(HaveKeyWithValue("network-id", []byte("42")))
+		Expect(data).ToNot(HaveKey("hcloud"))
+		Expect(data).ToNot(HaveKey("network"))
+	})
+
+	It("fails if the token is missing in the Hetzner secret", func() {
+		delete(tokenSecret.Data, "hcloud")
+		_, err := targetSecretData(hetznerCluster, tokenSecret)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("creates the secret with the configured name", func() {
+		hetznerCluster.Spec.TargetSecret.Name = "hcloud"
+		data, err := targetSecretData(hetznerCluster, tokenSecret)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(syncTargetSecret(ctx, secrets, hetznerCluster, data)).To(Succeed())
+		Expect(secrets.secrets).To(HaveKey("hcloud"))
+		Expect(secrets.secrets["hcloud"].Data).To(Equal(data))
+	})
+
+	It("updates the secret only if the data changed", func() {
+		data, err := targetSecretData(hetznerCluster, tokenSecret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(syncTargetSecret(ctx, secrets, hetznerCluster, data)).To(Succeed())
+
+		Expect(syncTargetSecret(ctx, secrets, hetznerCluster, data)).To(Succeed())
+		Expect(secrets.updates).To(Equal(0))
+
+		tokenSecret.Data["hcloud"] = []byte("rotated-token")
+		data, err = targetSecretData(hetznerCluster, tokenSecret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(syncTargetSecret(ctx, secrets, hetznerCluster, data)).To(Succeed())
+		Expect(secrets.updates).To(Equal(1))
+		Expect(secrets.secrets["hetzner-secret"].Data).To(HaveKeyWithValue("hcloud", []byte("rotated-token")))
+	})
+})
+
+// invalidTokenHCloudClientFactory creates HCloud clients that reject every token.
+type invalidTokenHCloudClientFactory struct{}
+
+func (invalidTokenHCloudClientFactory) NewClient(string) hcloudclient.Client {
+	return invalidTokenHCloudClient{}
+}
+
+func (invalidTokenHCloudClientFactory) ReleaseClient(string) {}
+
+type invalidTokenHCloudClient struct {
+	hcloudclient.Client
+}
+
+func (invalidTokenHCloudClient) ListSSHKeys(context.Context, hcloud.SSHKeyListOpts) ([]*hcloud.SSHKey, error) {
+	return nil, hcloudclient.ErrUnauthorized
+}
+
+var _ = Describe("credentials rotation", func() {
+	var (
+		hetznerCluster *infrav1.HetznerCluster
+		hetznerSecret  *corev1.Secret
+		r              *HetznerClusterReconciler
+	)
+
+	BeforeEach(func() {
+		hetznerCluster = &infrav1.HetznerCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "credentials-rotation-cluster",
+				Namespace: "default",
+			},
+			Spec: getDefaultHetznerClusterSpec(),
+		}
+		hetznerCluster.Spec.HetznerSecret.Key.NextHCloudToken = "hcloud-next"
+
+		hetznerSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "hetzner-secret", Namespace: "default"},
+			Data: map[string][]byte{
+				"hcloud":      []byte("token"),
+				"hcloud-next": []byte("next-token"),
+			},
+		}
+
+		r = &HetznerClusterReconciler{HCloudClientFactory: testEnv.HCloudClientFactory}
+	})
+
+	It("uses the HCloud token as long as the next token is not validated", func() {
+		Expect(activeHCloudToken(hetznerCluster, hetznerSecret)).To(Equal("token"))
+	})
+
+	It("switches to a valid next token", func() {
+		Expect(r.reconcileCredentialsRotation(ctx, hetznerCluster, hetznerSecret)).To(BeTrue())
+		Expect(activeHCloudToken(hetznerCluster, hetznerSecret)).To(Equal("next-token"))
+		Expect(conditions.IsTrue(hetznerCluster, infrav1.CredentialsRotatedCondition)).To(BeTrue())
+
+		// the validated token is not validated again
+		Expect(r.reconcileCredentialsRotation(ctx, hetznerCluster, hetznerSecret)).To(BeFalse())
+	})
+
+	It("keys the hash of the next token with the HCloud token", func() {
+		Expect(r.reconcileCredentialsRotation(ctx, hetznerCluster, hetznerSecret)).To(BeTrue())
+		hash := hetznerCluster.Status.Credentials.HCloudTokenHash
+		Expect(hash).ToNot(Equal(credentialsHash(nil, []byte("next-token"))))
+		Expect(hash).To(Equal(credentialsHash([]byte("token"), []byte("next-token"))))
+	})
+
+	It("falls back to the HCloud token if the next token changes", func() {
+		Expect(r.reconcileCredentialsRotation(ctx, hetznerCluster, hetznerSecret)).To(BeTrue())
+		hetznerSecret.Data["hcloud-next"] = []byte("other-token")
+		Expect(activeHCloudToken(hetznerCluster, hetznerSecret)).To(Equal("token"))
+	})
+
+	It("keeps the HCloud token if the next token is invalid", func() {
+		r.HCloudClientFactory = invalidTokenHCloudClientFactory{}
+		Expect(r.reconcileCredentialsRotation(ctx, hetznerCluster, hetznerSecret)).To(BeFalse())
+		Expect(activeHCloudToken(hetznerCluster, hetznerSecret)).To(Equal("token"))
+		Expect(conditions.GetReason(hetznerCluster, infrav1.CredentialsRotatedCondition)).To(Equal(infrav1.NextHCloudTokenInvalidReason))
+	})
+
+	It("resets the status once the next token is removed", func() {
+		Expect(r.reconcileCredentialsRotation(ctx, hetznerCluster, hetznerSecret)).To(BeTrue())
+
+		hetznerSecret.Data["hcloud"] = hetznerSecret.Data["hcloud-next"]
+		delete(hetznerSecret.Data, "hcloud-next")
+		Expect(r.reconcileCredentialsRotation(ctx, hetznerCluster, hetznerSecret)).To(BeTrue())
+		Expect(hetznerCluster.Status.Credentials).To(BeNil())
+		Expect(conditions.Has(hetznerCluster, infrav1.CredentialsRotatedCondition)).To(BeFalse())
+		Expect(activeHCloudToken(hetznerCluster, hetznerSecret)).To(Equal("next-token"))
+	})
+})
@@ -134,6 +134,7 @@ func main() {
 		APIReader:                      mgr.GetAPIReader(),
 		RateLimitWaitTime:              rateLimitWaitTime,
 		HCloudClientFactory:            hcloudClientFactory,
+		RobotClientFactory:             robotclient.NewFactory(),
 		WatchFilterValue:               watchFilterValue,
 		TargetClusterManagersWaitGroup: &wg,
 	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: hetznerClusterConcurrency}); err != nil {
@@ -186,6 +187,14 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = (&controllers.HetznerBareMetalMachineTemplateReconciler{
+		Client:           mgr.GetClient(),
+		WatchFilterValue: watchFilterValue,
+	}).SetupWithManager(ctx, mgr, controller.Options{}); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "HetznerBareMetalMachineTemplate")
+		os.Exit(1)
+	}
+
 	if err = (&controllers.HetznerBareMetalRemediationReconciler{
 		Client:           mgr.GetClient(),
 		WatchFilterValue: watchFilterValue,
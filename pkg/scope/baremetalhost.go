@@ -43,6 +43,8 @@ type BareMetalHostScopeParams struct {
 	SSHClientFactory     sshclient.Factory
 	OSSSHSecret          *corev1.Secret
 	RescueSSHSecret      *corev1.Secret
+	SSHBastionSecret     *corev1.Secret
+	EncryptionSecret     *corev1.Secret
 	SecretManager        *secretutil.SecretManager
 }
 
@@ -82,6 +84,8 @@ func NewBareMetalHostScope(params BareMetalHostScopeParams) (*BareMetalHostScope
 		HetznerBareMetalHost: params.HetznerBareMetalHost,
 		OSSSHSecret:          params.OSSSHSecret,
 		RescueSSHSecret:      params.RescueSSHSecret,
+		SSHBastionSecret:     params.SSHBastionSecret,
+		EncryptionSecret:     params.EncryptionSecret,
 		SecretManager:        params.SecretManager,
 	}, nil
 }
@@ -97,6 +101,8 @@ type BareMetalHostScope struct {
 	HetznerCluster       *infrav1.HetznerCluster
 	OSSSHSecret          *corev1.Secret
 	RescueSSHSecret      *corev1.Secret
+	SSHBastionSecret     *corev1.Secret
+	EncryptionSecret     *corev1.Secret
 }
 
 // Name returns the HetznerCluster name.
@@ -109,6 +115,15 @@ func (s *BareMetalHostScope) Namespace() string {
 	return s.HetznerBareMetalHost.Namespace
 }
 
+// SSHBastion returns the bastion host that SSH connections to the host are tunneled through or nil. The bastion
+// host of the HetznerBareMetalHost takes precedence over the one of the HetznerCluster.
+func (s *BareMetalHostScope) SSHBastion() *infrav1.SSHBastionSpec {
+	if s.HetznerBareMetalHost.Spec.SSHBastion != nil {
+		return s.HetznerBareMetalHost.Spec.SSHBastion
+	}
+	return s.HetznerCluster.Spec.SSHBastion
+}
+
 // GetRawBootstrapData returns the bootstrap data from the secret in the Machine's bootstrap.dataSecretName.
 func (s *BareMetalHostScope) GetRawBootstrapData(ctx context.Context) ([]byte, error) {
 	if s.HetznerBareMetalHost.Spec.Status.UserData == nil {
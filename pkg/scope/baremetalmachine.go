@@ -119,6 +119,11 @@ func (m *BareMetalMachineScope) IsControlPlane() bool {
 	return util.IsControlPlaneMachine(m.Machine)
 }
 
+// IsLoadBalancerTarget returns true if the machine has to be a target of the load balancer.
+func (m *BareMetalMachineScope) IsLoadBalancerTarget() bool {
+	return isLoadBalancerTarget(m.Machine, m.HetznerCluster)
+}
+
 // IsBootstrapReady checks the readiness of a capi machine's bootstrap data.
 func (m *BareMetalMachineScope) IsBootstrapReady() bool {
 	return m.Machine.Spec.Bootstrap.DataSecretName != nil
@@ -34,6 +34,7 @@ import (
 
 	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
 	secretutil "github.com/syself/cluster-api-provider-hetzner/pkg/secrets"
+	robotclient "github.com/syself/cluster-api-provider-hetzner/pkg/services/baremetal/client/robot"
 	hcloudclient "github.com/syself/cluster-api-provider-hetzner/pkg/services/hcloud/client"
 )
 
@@ -44,6 +45,7 @@ type ClusterScopeParams struct {
 	Logger         logr.Logger
 	HetznerSecret  *corev1.Secret
 	HCloudClient   hcloudclient.Client
+	RobotClient    robotclient.Client
 	Cluster        *clusterv1.Cluster
 	HetznerCluster *infrav1.HetznerCluster
 }
@@ -81,6 +83,7 @@ func NewClusterScope(params ClusterScopeParams) (*ClusterScope, error) {
 		Cluster:        params.Cluster,
 		HetznerCluster: params.HetznerCluster,
 		HCloudClient:   params.HCloudClient,
+		RobotClient:    params.RobotClient,
 		patchHelper:    helper,
 		hetznerSecret:  params.HetznerSecret,
 	}, nil
@@ -95,6 +98,8 @@ type ClusterScope struct {
 	hetznerSecret *corev1.Secret
 
 	HCloudClient hcloudclient.Client
+	// RobotClient is only set if the cluster uses resources of the robot API, e.g. a vSwitch.
+	RobotClient robotclient.Client
 
 	Cluster        *clusterv1.Cluster
 	HetznerCluster *infrav1.HetznerCluster
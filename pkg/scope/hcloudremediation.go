@@ -36,6 +36,7 @@ import (
 type HCloudRemediationScopeParams struct {
 	Logger            logr.Logger
 	Client            client.Client
+	APIReader         client.Reader
 	HCloudClient      hcloudclient.Client
 	Machine           *clusterv1.Machine
 	HCloudMachine     *infrav1.HCloudMachine
@@ -52,6 +53,9 @@ func NewHCloudRemediationScope(params HCloudRemediationScopeParams) (*HCloudReme
 	if params.Client == nil {
 		return nil, errors.New("failed to generate new scope from nil client")
 	}
+	if params.APIReader == nil {
+		return nil, errors.New("failed to generate new scope from nil APIReader")
+	}
 	if params.HCloudClient == nil {
 		return nil, errors.New("failed to generate new scope from nil HCloudClient")
 	}
@@ -77,28 +81,37 @@ func NewHCloudRemediationScope(params HCloudRemediationScopeParams) (*HCloudReme
 		return nil, fmt.Errorf("failed to init machine patch helper: %w", err)
 	}
 
+	hcloudMachinePatchHelper, err := patch.NewHelper(params.HCloudMachine, params.Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init hcloud machine patch helper: %w", err)
+	}
+
 	return &HCloudRemediationScope{
-		Logger:             params.Logger,
-		Client:             params.Client,
-		HCloudClient:       params.HCloudClient,
-		patchHelper:        patchHelper,
-		machinePatchHelper: machinePatchHelper,
-		Machine:            params.Machine,
-		HCloudMachine:      params.HCloudMachine,
-		HCloudRemediation:  params.HCloudRemediation,
+		Logger:                   params.Logger,
+		Client:                   params.Client,
+		APIReader:                params.APIReader,
+		HCloudClient:             params.HCloudClient,
+		patchHelper:              patchHelper,
+		machinePatchHelper:       machinePatchHelper,
+		hcloudMachinePatchHelper: hcloudMachinePatchHelper,
+		Machine:                  params.Machine,
+		HCloudMachine:            params.HCloudMachine,
+		HCloudRemediation:        params.HCloudRemediation,
 	}, nil
 }
 
 // HCloudRemediationScope defines the basic context for an actuator to operate upon.
 type HCloudRemediationScope struct {
 	logr.Logger
-	Client             client.Client
-	patchHelper        *patch.Helper
-	machinePatchHelper *patch.Helper
-	HCloudClient       hcloudclient.Client
-	Machine            *clusterv1.Machine
-	HCloudMachine      *infrav1.HCloudMachine
-	HCloudRemediation  *infrav1.HCloudRemediation
+	Client                   client.Client
+	APIReader                client.Reader
+	patchHelper              *patch.Helper
+	machinePatchHelper       *patch.Helper
+	hcloudMachinePatchHelper *patch.Helper
+	HCloudClient             hcloudclient.Client
+	Machine                  *clusterv1.Machine
+	HCloudMachine            *infrav1.HCloudMachine
+	HCloudRemediation        *infrav1.HCloudRemediation
 }
 
 // Close closes the current scope persisting the cluster configuration and status.
@@ -131,3 +144,14 @@ func (m *HCloudRemediationScope) PatchObject(ctx context.Context, opts ...patch.
 func (m *HCloudRemediationScope) PatchMachine(ctx context.Context, opts ...patch.Option) error {
 	return m.machinePatchHelper.Patch(ctx, m.Machine, opts...)
 }
+
+// PatchHCloudMachine persists the hcloud machine spec and status.
+func (m *HCloudRemediationScope) PatchHCloudMachine(ctx context.Context, opts ...patch.Option) error {
+	return m.hcloudMachinePatchHelper.Patch(ctx, m.HCloudMachine, opts...)
+}
+
+// HasRetriesLeft returns true if the retry limit is greater than retry count.
+func (m *HCloudRemediationScope) HasRetriesLeft() bool {
+	return m.HCloudRemediation.Spec.Strategy.RetryLimit > 0 &&
+		m.HCloudRemediation.Spec.Strategy.RetryLimit > m.HCloudRemediation.Status.RetryCount
+}
@@ -104,6 +104,30 @@ func (m *MachineScope) IsControlPlane() bool {
 	return util.IsControlPlaneMachine(m.Machine)
 }
 
+// IsLoadBalancerTarget returns true if the machine has to be a target of the load balancer.
+func (m *MachineScope) IsLoadBalancerTarget() bool {
+	return isLoadBalancerTarget(m.Machine, m.HetznerCluster)
+}
+
+// isLoadBalancerTarget returns true if the machine is a control plane or is selected by the extra target
+// selector of the load balancer.
+func isLoadBalancerTarget(machine *clusterv1.Machine, hetznerCluster *infrav1.HetznerCluster) bool {
+	if util.IsControlPlaneMachine(machine) {
+		return true
+	}
+
+	selector := hetznerCluster.Spec.ControlPlaneLoadBalancer.ExtraTargetSelector
+	if len(selector) == 0 {
+		return false
+	}
+	for key, value := range selector {
+		if machineValue, found := machine.Labels[key]; !found || machineValue != value {
+			return false
+		}
+	}
+	return true
+}
+
 // Name returns the HCloudMachine name.
 func (m *MachineScope) Name() string {
 	return m.HCloudMachine.Name
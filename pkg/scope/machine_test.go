@@ -19,6 +19,7 @@ package scope
 import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 
 	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
 )
@@ -88,3 +89,29 @@ var _ = Describe("Test ServerIDFromProviderID", func() {
 		}),
 	)
 })
+
+var _ = Describe("isLoadBalancerTarget", func() {
+	DescribeTable("selects control planes and extra targets",
+		func(machineLabels, selector map[string]string, expected bool) {
+			machine := &clusterv1.Machine{}
+			machine.Labels = machineLabels
+
+			hetznerCluster := &infrav1.HetznerCluster{}
+			hetznerCluster.Spec.ControlPlaneLoadBalancer.ExtraTargetSelector = selector
+
+			Expect(isLoadBalancerTarget(machine, hetznerCluster)).To(Equal(expected))
+		},
+		Entry("control plane",
+			map[string]string{clusterv1.MachineControlPlaneLabel: ""}, nil, true),
+		Entry("worker without selector",
+			map[string]string{clusterv1.MachineDeploymentNameLabel: "workers"}, nil, false),
+		Entry("worker matching the selector",
+			map[string]string{clusterv1.MachineDeploymentNameLabel: "workers", "role": "ingress"},
+			map[string]string{clusterv1.MachineDeploymentNameLabel: "workers"}, true),
+		Entry("worker not matching the selector",
+			map[string]string{clusterv1.MachineDeploymentNameLabel: "other"},
+			map[string]string{clusterv1.MachineDeploymentNameLabel: "workers"}, false),
+		Entry("worker without the label of the selector",
+			map[string]string{}, map[string]string{"role": ""}, false),
+	)
+})
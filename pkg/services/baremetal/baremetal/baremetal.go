@@ -19,10 +19,8 @@ package baremetal
 
 import (
 	"context"
-	"crypto/rand"
 	"errors"
 	"fmt"
-	"math/big"
 	"net"
 	"strings"
 	"time"
@@ -135,8 +133,9 @@ func (s *Service) Delete(ctx context.Context) (res reconcile.Result, err error)
 	if host != nil && host.Spec.ConsumerRef != nil {
 		s.scope.BareMetalMachine.Status.Phase = clusterv1.MachinePhaseDeleting
 
-		// remove control plane as load balancer target
-		if s.scope.IsControlPlane() && s.scope.HetznerCluster.Spec.ControlPlaneLoadBalancer.Enabled {
+		// remove control plane or extra target as load balancer target. The host might not be selected anymore,
+		// so it is removed as well if its IPs are targets.
+		if s.scope.HetznerCluster.Spec.ControlPlaneLoadBalancer.Enabled && (s.scope.IsLoadBalancerTarget() || s.isIPTargetOfLoadBalancer(host)) {
 			if err := s.removeAttachedServerOfLoadBalancer(ctx, host); err != nil {
 				return res, fmt.Errorf("failed to delete attached server of load balancer: %w", err)
 			}
@@ -159,19 +158,24 @@ func (s *Service) Delete(ctx context.Context) (res reconcile.Result, err error)
 			return reconcile.Result{Requeue: true}, nil
 		}
 
-		// check if deprovisioning is done
-		if host.Spec.Status.ProvisioningState != infrav1.StateNone {
+		// check if deprovisioning is done. Wiping the disks can take hours, so the machine does not wait for it. The
+		// host is not available before its disks have been wiped anyway.
+		wipingDisks := host.Spec.Status.ProvisioningState == infrav1.StateWipingDisks
+		if host.Spec.Status.ProvisioningState != infrav1.StateNone && !wipingDisks {
 			return reconcile.Result{RequeueAfter: requeueAfter}, nil
 		}
 
-		// remove ssh spec - this was needed for the host to fully deprovision
-		if host.Spec.Status.SSHSpec != nil {
+		// remove ssh spec and cluster reference - these are needed for the host to fully deprovision and are removed
+		// by the host itself once its disks have been wiped
+		if !wipingDisks {
 			host.Spec.Status.SSHSpec = nil
+			host.Spec.Status.HetznerClusterRef = ""
 		}
 
 		// deprovisiong is done - remove all references of host
 		host.Spec.ConsumerRef = nil
-		host.Spec.Status.HetznerClusterRef = ""
+		now := metav1.Now()
+		host.Spec.Status.LastReleased = &now
 		host.SetDeletionTimestamp(nil)
 		host.OwnerReferences = s.removeOwnerRef(host.OwnerReferences)
 
@@ -255,11 +259,16 @@ func (s *Service) update(ctx context.Context) error {
 		return fmt.Errorf("failed to patch host: %w", err)
 	}
 
-	// if machine is a control plane, the host should be set as target of load balancer
-	if s.scope.IsControlPlane() {
+	// if machine is a control plane or selected as extra target, the host should be set as target of load balancer
+	if s.scope.IsLoadBalancerTarget() {
 		if err := s.reconcileLoadBalancerAttachment(ctx, host); err != nil {
 			return fmt.Errorf("failed to reconcile load balancer attachment: %w", err)
 		}
+	} else if s.isIPTargetOfLoadBalancer(host) {
+		// the machine is not selected anymore, e.g. because its labels or the selector changed
+		if err := s.removeAttachedServerOfLoadBalancer(ctx, host); err != nil {
+			return fmt.Errorf("failed to remove host that is not selected anymore as target of load balancer: %w", err)
+		}
 	}
 
 	// ensure annotations are correctly set
@@ -283,7 +292,7 @@ func (s *Service) associate(ctx context.Context) error {
 	}
 
 	// choose new host
-	host, helper, err := s.chooseHost(ctx)
+	host, helper, reason, err := s.chooseHost(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to choose host: %w", err)
 	}
@@ -321,6 +330,10 @@ func (s *Service) associate(ctx context.Context) error {
 		return reterr
 	}
 
+	if reason != "" {
+		record.Eventf(s.scope.BareMetalMachine, "HostChosen", "Chose host %s: %s", host.Name, reason)
+	}
+
 	s.ensureMachineAnnotation(host)
 
 	return nil
@@ -363,7 +376,10 @@ func (s *Service) getAssociatedHost(ctx context.Context) (*infrav1.HetznerBareMe
 	return &host, helper, nil
 }
 
-func (s *Service) chooseHost(ctx context.Context) (*infrav1.HetznerBareMetalHost, *patch.Helper, error) {
+// chooseHost chooses a host for the machine according to its host selection policy. Besides the host,
+// it returns the reason for the choice. If the machine already consumes a host, this host is returned
+// without a reason.
+func (s *Service) chooseHost(ctx context.Context) (*infrav1.HetznerBareMetalHost, *patch.Helper, string, error) {
 	// get list of hosts scoped to namespace of machine
 	hosts := infrav1.HetznerBareMetalHostList{}
 	opts := &client.ListOptions{
@@ -371,63 +387,65 @@ func (s *Service) chooseHost(ctx context.Context) (*infrav1.HetznerBareMetalHost
 	}
 
 	if err := s.scope.Client.List(ctx, &hosts, opts); err != nil {
-		return nil, nil, fmt.Errorf("failed to list hosts: %w", err)
+		return nil, nil, "", fmt.Errorf("failed to list hosts: %w", err)
 	}
 
 	labelSelector := s.getLabelSelector()
 
+	var clusterName string
+	if s.scope.Machine != nil {
+		clusterName = s.scope.Machine.Spec.ClusterName
+	}
+
 	availableHosts := make([]*infrav1.HetznerBareMetalHost, 0, len(hosts.Items))
+	var claimedHosts []*infrav1.HetznerBareMetalHost
 
 	for i, host := range hosts.Items {
 		if host.Spec.ConsumerRef != nil && consumerRefMatches(host.Spec.ConsumerRef, s.scope.BareMetalMachine) {
 			helper, err := patch.NewHelper(&hosts.Items[i], s.scope.Client)
 			if err != nil {
-				return nil, nil, fmt.Errorf("failed to create patch helper: %w", err)
+				return nil, nil, "", fmt.Errorf("failed to create patch helper: %w", err)
 			}
-			return &hosts.Items[i], helper, nil
+			return &hosts.Items[i], helper, "", nil
 		}
-		if host.Spec.ConsumerRef != nil {
-			continue
-		}
-		if host.Spec.MaintenanceMode != nil && *host.Spec.MaintenanceMode {
-			continue
-		}
-		if host.GetDeletionTimestamp() != nil {
+
+		if !labelSelector.Matches(labels.Set(host.ObjectMeta.Labels)) {
 			continue
 		}
-		if host.Spec.Status.ErrorMessage != "" {
+
+		// hosts consumed by the same cluster are needed to spread across topology domains
+		if host.Spec.ConsumerRef != nil {
+			if clusterName != "" && host.Labels[clusterv1.ClusterNameLabel] == clusterName {
+				claimedHosts = append(claimedHosts, &hosts.Items[i])
+			}
 			continue
 		}
 
-		if !labelSelector.Matches(labels.Set(host.ObjectMeta.Labels)) {
+		if !IsHostAvailable(&hosts.Items[i]) {
 			continue
 		}
 
-		if host.Spec.Status.ProvisioningState != infrav1.StateNone {
+		if !s.scope.BareMetalMachine.Spec.HostSelector.HardwareRequirements.Matches(host.Spec.Status.HardwareDetails) {
 			continue
 		}
 
 		availableHosts = append(availableHosts, &hosts.Items[i])
 	}
 
-	if len(availableHosts) == 0 {
-		return nil, nil, nil
-	}
-
-	// choose a host
-	randomNumber, err := rand.Int(rand.Reader, big.NewInt(int64(len(availableHosts))))
+	chosenHost, reason, err := selectHost(availableHosts, claimedHosts, s.scope.BareMetalMachine.Spec.HostSelectionPolicy)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create random number: %w", err)
+		return nil, nil, "", fmt.Errorf("failed to select host: %w", err)
+	}
+	if chosenHost == nil {
+		return nil, nil, "", nil
 	}
-
-	chosenHost := availableHosts[randomNumber.Int64()]
 
 	helper, err := patch.NewHelper(chosenHost, s.scope.Client)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create patch helper: %w", err)
+		return nil, nil, "", fmt.Errorf("failed to create patch helper: %w", err)
 	}
 
-	return chosenHost, helper, nil
+	return chosenHost, helper, reason, nil
 }
 
 func (s *Service) reconcileLoadBalancerAttachment(ctx context.Context, host *infrav1.HetznerBareMetalHost) error {
@@ -494,6 +512,22 @@ func (s *Service) reconcileLoadBalancerAttachment(ctx context.Context, host *inf
 	return nil
 }
 
+// isIPTargetOfLoadBalancer returns true if an IP of the host is a target of the load balancer.
+func (s *Service) isIPTargetOfLoadBalancer(host *infrav1.HetznerBareMetalHost) bool {
+	if s.scope.HetznerCluster.Status.ControlPlaneLoadBalancer == nil {
+		return false
+	}
+	for _, target := range s.scope.HetznerCluster.Status.ControlPlaneLoadBalancer.Target {
+		if target.Type != infrav1.LoadBalancerTargetTypeIP || target.IP == "" {
+			continue
+		}
+		if target.IP == host.Spec.Status.IPv4 || target.IP == host.Spec.Status.IPv6 {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Service) removeAttachedServerOfLoadBalancer(ctx context.Context, host *infrav1.HetznerBareMetalHost) error {
 	lb := &hcloud.LoadBalancer{ID: s.scope.HetznerCluster.Status.ControlPlaneLoadBalancer.ID}
 
@@ -534,16 +568,21 @@ func (s *Service) removeAttachedServerOfLoadBalancer(ctx context.Context, host *
 }
 
 func (s *Service) getLabelSelector() labels.Selector {
+	return LabelSelectorFromHostSelector(s.scope.BareMetalMachine.Spec.HostSelector)
+}
+
+// LabelSelectorFromHostSelector converts a host selector into a label selector. Invalid requirements are ignored.
+func LabelSelectorFromHostSelector(hostSelector infrav1.HostSelector) labels.Selector {
 	labelSelector := labels.NewSelector()
 	var reqs labels.Requirements
 
-	for labelKey, labelVal := range s.scope.BareMetalMachine.Spec.HostSelector.MatchLabels {
+	for labelKey, labelVal := range hostSelector.MatchLabels {
 		r, err := labels.NewRequirement(labelKey, selection.Equals, []string{labelVal})
 		if err == nil { // ignore invalid host selector
 			reqs = append(reqs, *r)
 		}
 	}
-	for _, req := range s.scope.BareMetalMachine.Spec.HostSelector.MatchExpressions {
+	for _, req := range hostSelector.MatchExpressions {
 		lowercaseOperator := selection.Operator(strings.ToLower(string(req.Operator)))
 		r, err := labels.NewRequirement(req.Key, lowercaseOperator, req.Values)
 		if err == nil { // ignore invalid host selector
@@ -554,6 +593,23 @@ func (s *Service) getLabelSelector() labels.Selector {
 	return labelSelector.Add(reqs...)
 }
 
+// IsHostAvailable returns whether a host is unclaimed and can be consumed by a HetznerBareMetalMachine.
+func IsHostAvailable(host *infrav1.HetznerBareMetalHost) bool {
+	if host.Spec.ConsumerRef != nil {
+		return false
+	}
+	if host.Spec.MaintenanceMode != nil && *host.Spec.MaintenanceMode {
+		return false
+	}
+	if host.GetDeletionTimestamp() != nil {
+		return false
+	}
+	if host.Spec.Status.ErrorMessage != "" {
+		return false
+	}
+	return host.Spec.Status.ProvisioningState == infrav1.StateNone
+}
+
 func (s *Service) setProviderID(ctx context.Context) error {
 	// nothing to do if providerID is set
 	if s.scope.BareMetalMachine.Spec.ProviderID != nil {
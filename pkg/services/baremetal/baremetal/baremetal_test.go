@@ -190,6 +190,19 @@ var _ = Describe("chooseHost", func() {
 		},
 	}
 
+	hostWithHardwareDetails := infrav1.HetznerBareMetalHost{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "hostWithHardwareDetails",
+			Namespace: defaultNamespace,
+		},
+		Spec: infrav1.HetznerBareMetalHostSpec{
+			Status: infrav1.ControllerGeneratedStatus{
+				ProvisioningState: infrav1.StateNone,
+				HardwareDetails:   &infrav1.HardwareDetails{RAMGB: 64},
+			},
+		},
+	}
+
 	hostWithLabelAndMaintenanceMode := infrav1.HetznerBareMetalHost{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "hostWithLabelAndMaintenanceMode",
@@ -217,7 +230,7 @@ var _ = Describe("chooseHost", func() {
 			bmMachine.Spec.HostSelector = tc.HostSelector
 			service := newTestService(bmMachine, c)
 
-			host, _, err := service.chooseHost(context.TODO())
+			host, _, _, err := service.chooseHost(context.TODO())
 			Expect(err).To(Succeed())
 			if tc.ExpectedHostName == "" {
 				Expect(host).To(BeNil())
@@ -275,6 +288,24 @@ var _ = Describe("chooseHost", func() {
 				}},
 				ExpectedHostName: "hostWithLabel",
 			}),
+		Entry("Choosing host that fulfils hardware requirements",
+			testCaseChooseHost{
+				Hosts:            []client.Object{&hostWithHardwareDetails, &host},
+				HostSelector:     infrav1.HostSelector{HardwareRequirements: &infrav1.HardwareRequirements{MinRAMGB: 32}},
+				ExpectedHostName: "hostWithHardwareDetails",
+			}),
+		Entry("No host without hardware details if hardware is required",
+			testCaseChooseHost{
+				Hosts:            []client.Object{&host},
+				HostSelector:     infrav1.HostSelector{HardwareRequirements: &infrav1.HardwareRequirements{MinRAMGB: 32}},
+				ExpectedHostName: "",
+			}),
+		Entry("No host that does not fulfil hardware requirements",
+			testCaseChooseHost{
+				Hosts:            []client.Object{&hostWithHardwareDetails, &host},
+				HostSelector:     infrav1.HostSelector{HardwareRequirements: &infrav1.HardwareRequirements{MinRAMGB: 128}},
+				ExpectedHostName: "",
+			}),
 	)
 })
 
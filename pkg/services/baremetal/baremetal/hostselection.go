@@ -0,0 +1,162 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
+)
+
+// selectHost chooses one of the available hosts according to the host selection policy. The claimed hosts are
+// the hosts that are already consumed by machines of the same cluster, they are needed to spread across topology
+// domains. Besides the host, a human readable reason for the choice is returned.
+func selectHost(
+	available, claimed []*infrav1.HetznerBareMetalHost,
+	policy infrav1.HostSelectionPolicy,
+) (*infrav1.HetznerBareMetalHost, string, error) {
+	if len(available) == 0 {
+		return nil, "", nil
+	}
+
+	candidates := available
+	var usage map[string]int
+
+	switch policy.Strategy {
+	case infrav1.HostSelectionStrategySpread:
+		usage = topologyUsage(claimed, policy.TopologyKey)
+		candidates = filterHosts(candidates, func(host *infrav1.HetznerBareMetalHost) int {
+			return -usage[host.Labels[policy.TopologyKey]]
+		})
+	case infrav1.HostSelectionStrategyLeastRecentlyUsed:
+		candidates = filterHosts(candidates, func(host *infrav1.HetznerBareMetalHost) int {
+			if host.Spec.Status.LastReleased == nil {
+				return 0
+			}
+			// the longer ago the host has been released, the better
+			return -int(host.Spec.Status.LastReleased.Unix())
+		})
+	case infrav1.HostSelectionStrategySmallestFit:
+		// hosts without hardware details are only considered if no host has hardware details
+		knownHosts := make([]*infrav1.HetznerBareMetalHost, 0, len(candidates))
+		for _, host := range candidates {
+			if threads, _ := hardwareSize(host); threads != 0 {
+				knownHosts = append(knownHosts, host)
+			}
+		}
+		if len(knownHosts) > 0 {
+			candidates = filterHosts(knownHosts, func(host *infrav1.HetznerBareMetalHost) int {
+				threads, _ := hardwareSize(host)
+				return -threads
+			})
+			candidates = filterHosts(candidates, func(host *infrav1.HetznerBareMetalHost) int {
+				_, ramGB := hardwareSize(host)
+				return -ramGB
+			})
+		}
+	}
+
+	// hosts that are equally suitable are ordered by preference score
+	candidates = filterHosts(candidates, func(host *infrav1.HetznerBareMetalHost) int {
+		return host.Spec.PreferenceScore
+	})
+
+	chosenHost := candidates[0]
+	if len(candidates) > 1 {
+		randomNumber, err := rand.Int(rand.Reader, big.NewInt(int64(len(candidates))))
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to create random number: %w", err)
+		}
+		chosenHost = candidates[randomNumber.Int64()]
+	}
+
+	var reasons []string
+	switch policy.Strategy {
+	case infrav1.HostSelectionStrategySpread:
+		domain := chosenHost.Labels[policy.TopologyKey]
+		reasons = append(reasons, fmt.Sprintf("topology domain %s=%q has the fewest hosts of the cluster (%d)",
+			policy.TopologyKey, domain, usage[domain]))
+	case infrav1.HostSelectionStrategyLeastRecentlyUsed:
+		if chosenHost.Spec.Status.LastReleased == nil {
+			reasons = append(reasons, "least recently used host, it has never been used")
+		} else {
+			reasons = append(reasons, fmt.Sprintf("least recently used host, it has been released at %s",
+				chosenHost.Spec.Status.LastReleased.Format(time.RFC3339)))
+		}
+	case infrav1.HostSelectionStrategySmallestFit:
+		if threads, ramGB := hardwareSize(chosenHost); threads != 0 {
+			reasons = append(reasons, fmt.Sprintf("smallest fitting host with %d CPU threads and %d GB RAM", threads, ramGB))
+		} else {
+			reasons = append(reasons, "no available host has hardware details")
+		}
+	}
+	if chosenHost.Spec.PreferenceScore != 0 {
+		reasons = append(reasons, fmt.Sprintf("preference score %d", chosenHost.Spec.PreferenceScore))
+	}
+	if len(candidates) > 1 {
+		reasons = append(reasons, fmt.Sprintf("chosen randomly among %d equally suitable hosts", len(candidates)))
+	}
+	if len(reasons) == 0 {
+		reasons = append(reasons, "only available host")
+	}
+
+	return chosenHost, strings.Join(reasons, ", "), nil
+}
+
+// filterHosts returns the hosts with the highest score.
+func filterHosts(hosts []*infrav1.HetznerBareMetalHost, score func(*infrav1.HetznerBareMetalHost) int) []*infrav1.HetznerBareMetalHost {
+	var bestHosts []*infrav1.HetznerBareMetalHost
+	var bestScore int
+	for _, host := range hosts {
+		hostScore := score(host)
+		switch {
+		case len(bestHosts) == 0 || hostScore > bestScore:
+			bestHosts = []*infrav1.HetznerBareMetalHost{host}
+			bestScore = hostScore
+		case hostScore == bestScore:
+			bestHosts = append(bestHosts, host)
+		}
+	}
+	return bestHosts
+}
+
+// topologyUsage counts the hosts per value of the topology label.
+func topologyUsage(hosts []*infrav1.HetznerBareMetalHost, topologyKey string) map[string]int {
+	usage := make(map[string]int)
+	for _, host := range hosts {
+		usage[host.Labels[topologyKey]]++
+	}
+	return usage
+}
+
+// hardwareSize returns the number of CPU threads and the memory of a host. If the host
+// has no hardware details yet, zero values are returned.
+func hardwareSize(host *infrav1.HetznerBareMetalHost) (threads, ramGB int) {
+	details := host.Spec.Status.HardwareDetails
+	if details == nil {
+		return 0, 0
+	}
+	threads = details.CPU.Threads
+	if threads == 0 {
+		threads = details.CPU.Cores
+	}
+	return threads, details.RAMGB
+}
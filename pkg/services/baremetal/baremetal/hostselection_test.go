@@ -0,0 +1,147 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
+)
+
+var _ = Describe("selectHost", func() {
+	const topologyKey = "topology.kubernetes.io/zone"
+
+	newHost := func(name string, opts ...func(*infrav1.HetznerBareMetalHost)) *infrav1.HetznerBareMetalHost {
+		host := &infrav1.HetznerBareMetalHost{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+		}
+		for _, opt := range opts {
+			opt(host)
+		}
+		return host
+	}
+	inZone := func(zone string) func(*infrav1.HetznerBareMetalHost) {
+		return func(host *infrav1.HetznerBareMetalHost) {
+			host.Labels = map[string]string{topologyKey: zone}
+		}
+	}
+	releasedAgo := func(d time.Duration) func(*infrav1.HetznerBareMetalHost) {
+		return func(host *infrav1.HetznerBareMetalHost) {
+			host.Spec.Status.LastReleased = &metav1.Time{Time: time.Now().Add(-d)}
+		}
+	}
+	withHardware := func(threads, ramGB int) func(*infrav1.HetznerBareMetalHost) {
+		return func(host *infrav1.HetznerBareMetalHost) {
+			host.Spec.Status.HardwareDetails = &infrav1.HardwareDetails{
+				RAMGB: ramGB,
+				CPU:   infrav1.CPU{Threads: threads},
+			}
+		}
+	}
+	withScore := func(score int) func(*infrav1.HetznerBareMetalHost) {
+		return func(host *infrav1.HetznerBareMetalHost) {
+			host.Spec.PreferenceScore = score
+		}
+	}
+
+	type testCaseSelectHost struct {
+		available        []*infrav1.HetznerBareMetalHost
+		claimed          []*infrav1.HetznerBareMetalHost
+		policy           infrav1.HostSelectionPolicy
+		expectedHostName string
+		expectedReason   string
+	}
+
+	DescribeTable("selectHost",
+		func(tc testCaseSelectHost) {
+			host, reason, err := selectHost(tc.available, tc.claimed, tc.policy)
+			Expect(err).To(Succeed())
+			if tc.expectedHostName == "" {
+				Expect(host).To(BeNil())
+				return
+			}
+			Expect(host).ToNot(BeNil())
+			Expect(host.Name).To(Equal(tc.expectedHostName))
+			Expect(reason).To(ContainSubstring(tc.expectedReason))
+		},
+		Entry("no available host", testCaseSelectHost{
+			policy: infrav1.HostSelectionPolicy{Strategy: infrav1.HostSelectionStrategySpread, TopologyKey: topologyKey},
+		}),
+		Entry("only one host", testCaseSelectHost{
+			available:        []*infrav1.HetznerBareMetalHost{newHost("host")},
+			expectedHostName: "host",
+			expectedReason:   "only available host",
+		}),
+		Entry("random strategy prefers higher preference score", testCaseSelectHost{
+			available:        []*infrav1.HetznerBareMetalHost{newHost("low", withScore(1)), newHost("high", withScore(10)), newHost("none")},
+			expectedHostName: "high",
+			expectedReason:   "preference score 10",
+		}),
+		Entry("spread chooses the topology domain with the fewest hosts of the cluster", testCaseSelectHost{
+			available:        []*infrav1.HetznerBareMetalHost{newHost("fsn1-host", inZone("fsn1")), newHost("nbg1-host", inZone("nbg1"))},
+			claimed:          []*infrav1.HetznerBareMetalHost{newHost("fsn1-claimed", inZone("fsn1"))},
+			policy:           infrav1.HostSelectionPolicy{Strategy: infrav1.HostSelectionStrategySpread, TopologyKey: topologyKey},
+			expectedHostName: "nbg1-host",
+			expectedReason:   `topology domain topology.kubernetes.io/zone="nbg1" has the fewest hosts of the cluster (0)`,
+		}),
+		Entry("spread ignores the preference score of hosts in a used domain", testCaseSelectHost{
+			available:        []*infrav1.HetznerBareMetalHost{newHost("fsn1-host", inZone("fsn1"), withScore(10)), newHost("nbg1-host", inZone("nbg1"))},
+			claimed:          []*infrav1.HetznerBareMetalHost{newHost("fsn1-claimed", inZone("fsn1"))},
+			policy:           infrav1.HostSelectionPolicy{Strategy: infrav1.HostSelectionStrategySpread, TopologyKey: topologyKey},
+			expectedHostName: "nbg1-host",
+		}),
+		Entry("least recently used chooses a host that has never been used", testCaseSelectHost{
+			available:        []*infrav1.HetznerBareMetalHost{newHost("used", releasedAgo(time.Hour)), newHost("new")},
+			policy:           infrav1.HostSelectionPolicy{Strategy: infrav1.HostSelectionStrategyLeastRecentlyUsed},
+			expectedHostName: "new",
+			expectedReason:   "it has never been used",
+		}),
+		Entry("least recently used chooses the host released the longest time ago", testCaseSelectHost{
+			available:        []*infrav1.HetznerBareMetalHost{newHost("recent", releasedAgo(time.Hour)), newHost("old", releasedAgo(48*time.Hour))},
+			policy:           infrav1.HostSelectionPolicy{Strategy: infrav1.HostSelectionStrategyLeastRecentlyUsed},
+			expectedHostName: "old",
+			expectedReason:   "it has been released at",
+		}),
+		Entry("smallest fit chooses the host with the least CPU threads and memory", testCaseSelectHost{
+			available: []*infrav1.HetznerBareMetalHost{
+				newHost("big", withHardware(32, 128)),
+				newHost("small-more-ram", withHardware(8, 64)),
+				newHost("small", withHardware(8, 32)),
+				newHost("unknown"),
+			},
+			policy:           infrav1.HostSelectionPolicy{Strategy: infrav1.HostSelectionStrategySmallestFit},
+			expectedHostName: "small",
+			expectedReason:   "smallest fitting host with 8 CPU threads and 32 GB RAM",
+		}),
+		Entry("smallest fit falls back to hosts without hardware details", testCaseSelectHost{
+			available:        []*infrav1.HetznerBareMetalHost{newHost("unknown")},
+			policy:           infrav1.HostSelectionPolicy{Strategy: infrav1.HostSelectionStrategySmallestFit},
+			expectedHostName: "unknown",
+			expectedReason:   "no available host has hardware details",
+		}),
+	)
+
+	It("explains a random choice", func() {
+		_, reason, err := selectHost([]*infrav1.HetznerBareMetalHost{newHost("host1"), newHost("host2")}, nil, infrav1.HostSelectionPolicy{})
+		Expect(err).To(Succeed())
+		Expect(reason).To(Equal("chosen randomly among 2 equally suitable hosts"))
+	})
+})
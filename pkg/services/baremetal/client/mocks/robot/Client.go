@@ -7,6 +7,8 @@ import (
 	models "github.com/syself/hrobot-go/models"
 
 	v1beta1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
+
+	robotclient "github.com/syself/cluster-api-provider-hetzner/pkg/services/baremetal/client/robot"
 )
 
 // Client is an autogenerated mock type for the Client type
@@ -14,6 +16,57 @@ type Client struct {
 	mock.Mock
 }
 
+// AddServerToVSwitch provides a mock function with given fields: vSwitchID, serverID
+func (_m *Client) AddServerToVSwitch(vSwitchID int, serverID int) error {
+	ret := _m.Called(vSwitchID, serverID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int) error); ok {
+		r0 = rf(vSwitchID, serverID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CancelVSwitch provides a mock function with given fields: id
+func (_m *Client) CancelVSwitch(id int) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateVSwitch provides a mock function with given fields: name, vlan
+func (_m *Client) CreateVSwitch(name string, vlan int) (*robotclient.VSwitch, error) {
+	ret := _m.Called(name, vlan)
+
+	var r0 *robotclient.VSwitch
+	if rf, ok := ret.Get(0).(func(string, int) *robotclient.VSwitch); ok {
+		r0 = rf(name, vlan)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*robotclient.VSwitch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(name, vlan)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteBootRescue provides a mock function with given fields: id
 func (_m *Client) DeleteBootRescue(id int) (*models.Rescue, error) {
 	ret := _m.Called(id)
@@ -83,6 +136,29 @@ func (_m *Client) GetBootRescue(id int) (*models.Rescue, error) {
 	return r0, r1
 }
 
+// GetFailoverIP provides a mock function with given fields: ip
+func (_m *Client) GetFailoverIP(ip string) (*models.Failover, error) {
+	ret := _m.Called(ip)
+
+	var r0 *models.Failover
+	if rf, ok := ret.Get(0).(func(string) *models.Failover); ok {
+		r0 = rf(ip)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Failover)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(ip)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetReboot provides a mock function with given fields: _a0
 func (_m *Client) GetReboot(_a0 int) (*models.Reset, error) {
 	ret := _m.Called(_a0)
@@ -106,6 +182,29 @@ func (_m *Client) GetReboot(_a0 int) (*models.Reset, error) {
 	return r0, r1
 }
 
+// GetVSwitch provides a mock function with given fields: id
+func (_m *Client) GetVSwitch(id int) (*robotclient.VSwitch, error) {
+	ret := _m.Called(id)
+
+	var r0 *robotclient.VSwitch
+	if rf, ok := ret.Get(0).(func(int) *robotclient.VSwitch); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*robotclient.VSwitch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListBMServers provides a mock function with given fields:
 func (_m *Client) ListBMServers() ([]models.Server, error) {
 	ret := _m.Called()
@@ -152,6 +251,29 @@ func (_m *Client) ListSSHKeys() ([]models.Key, error) {
 	return r0, r1
 }
 
+// ListVSwitches provides a mock function with given fields:
+func (_m *Client) ListVSwitches() ([]robotclient.VSwitch, error) {
+	ret := _m.Called()
+
+	var r0 []robotclient.VSwitch
+	if rf, ok := ret.Get(0).(func() []robotclient.VSwitch); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]robotclient.VSwitch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RebootBMServer provides a mock function with given fields: _a0, _a1
 func (_m *Client) RebootBMServer(_a0 int, _a1 v1beta1.RebootType) (*models.ResetPost, error) {
 	ret := _m.Called(_a0, _a1)
@@ -175,6 +297,20 @@ func (_m *Client) RebootBMServer(_a0 int, _a1 v1beta1.RebootType) (*models.Reset
 	return r0, r1
 }
 
+// RemoveServerFromVSwitch provides a mock function with given fields: vSwitchID, serverID
+func (_m *Client) RemoveServerFromVSwitch(vSwitchID int, serverID int) error {
+	ret := _m.Called(vSwitchID, serverID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int) error); ok {
+		r0 = rf(vSwitchID, serverID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetBMServerName provides a mock function with given fields: _a0, _a1
 func (_m *Client) SetBMServerName(_a0 int, _a1 string) (*models.Server, error) {
 	ret := _m.Called(_a0, _a1)
@@ -221,6 +357,29 @@ func (_m *Client) SetBootRescue(id int, fingerprint string) (*models.Rescue, err
 	return r0, r1
 }
 
+// SetFailoverIP provides a mock function with given fields: ip, activeServerIP
+func (_m *Client) SetFailoverIP(ip string, activeServerIP string) (*models.Failover, error) {
+	ret := _m.Called(ip, activeServerIP)
+
+	var r0 *models.Failover
+	if rf, ok := ret.Get(0).(func(string, string) *models.Failover); ok {
+		r0 = rf(ip, activeServerIP)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Failover)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(ip, activeServerIP)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetSSHKey provides a mock function with given fields: name, publickey
 func (_m *Client) SetSSHKey(name string, publickey string) (*models.Key, error) {
 	ret := _m.Called(name, publickey)
@@ -82,13 +82,13 @@ func (_m *Client) CreateAutoSetup(data string) sshclient.Output {
 	return r0
 }
 
-// CreateMetaData provides a mock function with given fields: hostName
-func (_m *Client) CreateMetaData(hostName string) sshclient.Output {
-	ret := _m.Called(hostName)
+// CreateMetaData provides a mock function with given fields: hostName, vSwitchVLAN
+func (_m *Client) CreateMetaData(hostName string, vSwitchVLAN int) sshclient.Output {
+	ret := _m.Called(hostName, vSwitchVLAN)
 
 	var r0 sshclient.Output
-	if rf, ok := ret.Get(0).(func(string) sshclient.Output); ok {
-		r0 = rf(hostName)
+	if rf, ok := ret.Get(0).(func(string, int) sshclient.Output); ok {
+		r0 = rf(hostName, vSwitchVLAN)
 	} else {
 		r0 = ret.Get(0).(sshclient.Output)
 	}
@@ -180,6 +180,20 @@ func (_m *Client) ExecuteInstallImage(hasPostInstallScript bool) sshclient.Outpu
 	return r0
 }
 
+// GetDiskWipeStatus provides a mock function with given fields:
+func (_m *Client) GetDiskWipeStatus() sshclient.Output {
+	ret := _m.Called()
+
+	var r0 sshclient.Output
+	if rf, ok := ret.Get(0).(func() sshclient.Output); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(sshclient.Output)
+	}
+
+	return r0
+}
+
 // GetHardwareDetailsCPUArch provides a mock function with given fields:
 func (_m *Client) GetHardwareDetailsCPUArch() sshclient.Output {
 	ret := _m.Called()
@@ -348,6 +362,34 @@ func (_m *Client) ResetKubeadm() sshclient.Output {
 	return r0
 }
 
+// StartDiskWipe provides a mock function with given fields: devices
+func (_m *Client) StartDiskWipe(devices []string) sshclient.Output {
+	ret := _m.Called(devices)
+
+	var r0 sshclient.Output
+	if rf, ok := ret.Get(0).(func([]string) sshclient.Output); ok {
+		r0 = rf(devices)
+	} else {
+		r0 = ret.Get(0).(sshclient.Output)
+	}
+
+	return r0
+}
+
+// UnlockDisks provides a mock function with given fields: passphrase
+func (_m *Client) UnlockDisks(passphrase string) sshclient.Output {
+	ret := _m.Called(passphrase)
+
+	var r0 sshclient.Output
+	if rf, ok := ret.Get(0).(func(string) sshclient.Output); ok {
+		r0 = rf(passphrase)
+	} else {
+		r0 = ret.Get(0).(sshclient.Output)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewClient interface {
 	mock.TestingT
 	Cleanup(func())
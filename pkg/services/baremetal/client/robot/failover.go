@@ -0,0 +1,41 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package robotclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/syself/hrobot-go/models"
+)
+
+func (c *realHetznerRobotClient) GetFailoverIP(ip string) (*models.Failover, error) {
+	return c.client.FailoverGet(ip)
+}
+
+// SetFailoverIP routes the failover IP to the bare metal server with the given main IP.
+func (c *realHetznerRobotClient) SetFailoverIP(ip, activeServerIP string) (*models.Failover, error) {
+	formData := url.Values{}
+	formData.Set("active_server_ip", activeServerIP)
+
+	var failoverResp models.FailoverResponse
+	if err := c.doRequest(http.MethodPost, fmt.Sprintf("/failover/%s", ip), formData, &failoverResp); err != nil {
+		return nil, err
+	}
+	return &failoverResp.Failover, nil
+}
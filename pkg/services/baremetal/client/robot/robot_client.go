@@ -44,6 +44,16 @@ type Client interface {
 	GetBootRescue(id int) (*models.Rescue, error)
 	DeleteBootRescue(id int) (*models.Rescue, error)
 	GetReboot(int) (*models.Reset, error)
+
+	ListVSwitches() ([]VSwitch, error)
+	GetVSwitch(id int) (*VSwitch, error)
+	CreateVSwitch(name string, vlan int) (*VSwitch, error)
+	CancelVSwitch(id int) error
+	AddServerToVSwitch(vSwitchID, serverID int) error
+	RemoveServerFromVSwitch(vSwitchID, serverID int) error
+
+	GetFailoverIP(ip string) (*models.Failover, error)
+	SetFailoverIP(ip, activeServerIP string) (*models.Failover, error)
 }
 
 // Factory is the interface for creating new Client objects.
@@ -81,7 +91,11 @@ func (f *factory) NewClient(creds Credentials) Client {
 		},
 	}
 	return &realHetznerRobotClient{
-		client: hrobot.NewBasicAuthClientWithCustomHttpClient(creds.Username, creds.Password, client),
+		client:     hrobot.NewBasicAuthClientWithCustomHttpClient(creds.Username, creds.Password, client),
+		httpClient: client,
+		baseURL:    robotBaseURL,
+		userName:   creds.Username,
+		password:   creds.Password,
 	}
 }
 
@@ -97,9 +111,13 @@ func NewFactory() Factory {
 var _ = Client(&realHetznerRobotClient{})
 
 type realHetznerRobotClient struct {
-	client   hrobot.RobotClient
-	userName string
-	password string
+	client hrobot.RobotClient
+
+	// httpClient and baseURL are used for endpoints which are not supported by hrobot-go.
+	httpClient *http.Client
+	baseURL    string
+	userName   string
+	password   string
 }
 
 func (c *realHetznerRobotClient) UserName() string {
@@ -0,0 +1,168 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package robotclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/syself/hrobot-go/models"
+)
+
+const robotBaseURL = "https://robot-ws.your-server.de"
+
+const (
+	// ErrorCodeVSwitchInProcess is returned by the robot API if the vSwitch is still processing a previous change.
+	ErrorCodeVSwitchInProcess models.ErrorCode = "VSWITCH_IN_PROCESS"
+	// ErrorCodeVSwitchServerLimitReached is returned by the robot API if no more servers can be added to the vSwitch.
+	ErrorCodeVSwitchServerLimitReached models.ErrorCode = "VSWITCH_SERVER_LIMIT_REACHED"
+)
+
+// VSwitch is a vSwitch of the Hetzner robot API.
+type VSwitch struct {
+	ID           int                   `json:"id"`
+	Name         string                `json:"name"`
+	VLAN         int                   `json:"vlan"`
+	Cancelled    bool                  `json:"cancelled"`
+	Server       []VSwitchServer       `json:"server"`
+	CloudNetwork []VSwitchCloudNetwork `json:"cloud_network"`
+}
+
+// VSwitchServer is a bare metal server connected to a vSwitch.
+type VSwitchServer struct {
+	ServerIP     string `json:"server_ip"`
+	ServerNumber int    `json:"server_number"`
+	Status       string `json:"status"`
+}
+
+// VSwitchCloudNetwork is an HCloud network coupled with a vSwitch.
+type VSwitchCloudNetwork struct {
+	ID      int64  `json:"id"`
+	IP      string `json:"ip"`
+	Mask    int    `json:"mask"`
+	Gateway string `json:"gateway"`
+}
+
+// HasServer returns whether the server with the given server number is connected to the vSwitch.
+func (v *VSwitch) HasServer(serverID int) bool {
+	for _, server := range v.Server {
+		if server.ServerNumber == serverID {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *realHetznerRobotClient) ListVSwitches() ([]VSwitch, error) {
+	var vSwitches []VSwitch
+	if err := c.doRequest(http.MethodGet, "/vswitch", nil, &vSwitches); err != nil {
+		return nil, err
+	}
+	return vSwitches, nil
+}
+
+func (c *realHetznerRobotClient) GetVSwitch(id int) (*VSwitch, error) {
+	var vSwitch VSwitch
+	if err := c.doRequest(http.MethodGet, fmt.Sprintf("/vswitch/%d", id), nil, &vSwitch); err != nil {
+		return nil, err
+	}
+	return &vSwitch, nil
+}
+
+func (c *realHetznerRobotClient) CreateVSwitch(name string, vlan int) (*VSwitch, error) {
+	formData := url.Values{}
+	formData.Set("name", name)
+	formData.Set("vlan", strconv.Itoa(vlan))
+
+	var vSwitch VSwitch
+	if err := c.doRequest(http.MethodPost, "/vswitch", formData, &vSwitch); err != nil {
+		return nil, err
+	}
+	return &vSwitch, nil
+}
+
+func (c *realHetznerRobotClient) CancelVSwitch(id int) error {
+	formData := url.Values{}
+	formData.Set("cancellation_date", "now")
+
+	return c.doRequest(http.MethodDelete, fmt.Sprintf("/vswitch/%d", id), formData, nil)
+}
+
+func (c *realHetznerRobotClient) AddServerToVSwitch(vSwitchID, serverID int) error {
+	formData := url.Values{}
+	formData.Add("server[]", strconv.Itoa(serverID))
+
+	return c.doRequest(http.MethodPost, fmt.Sprintf("/vswitch/%d/server", vSwitchID), formData, nil)
+}
+
+func (c *realHetznerRobotClient) RemoveServerFromVSwitch(vSwitchID, serverID int) error {
+	formData := url.Values{}
+	formData.Add("server[]", strconv.Itoa(serverID))
+
+	return c.doRequest(http.MethodDelete, fmt.Sprintf("/vswitch/%d/server", vSwitchID), formData, nil)
+}
+
+// doRequest calls the robot API directly for endpoints that are not supported by hrobot-go, e.g. vSwitches.
+// Errors of the API are returned as models.Error, so that models.IsError can be used.
+func (c *realHetznerRobotClient) doRequest(method, path string, formData url.Values, result interface{}) error {
+	var body io.Reader
+	if formData != nil {
+		body = strings.NewReader(formData.Encode())
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if formData != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	req.SetBasicAuth(c.userName, c.password)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		var errorResponse models.ErrorResponse
+		if err := json.Unmarshal(respBody, &errorResponse); err == nil && errorResponse.Error.Code != "" {
+			return errorResponse.Error
+		}
+		return fmt.Errorf("server responded with status code %v", resp.StatusCode)
+	}
+
+	if result == nil || len(respBody) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sshclient
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const defaultBastionUser = "root"
+
+// Bastion defines a bastion host that SSH connections are tunneled through.
+type Bastion struct {
+	// Address of the bastion host. The port defaults to 22.
+	Address string
+	// User that logs in to the bastion host. It defaults to root.
+	User       string
+	PrivateKey string
+	// HostKey is the host key of the bastion host in authorized_keys format. If it is empty, the host key of the
+	// bastion host is not verified.
+	HostKey string
+}
+
+// BastionError means that the bastion host could not be used to reach the server. The server itself might be fine,
+// so the error is neither a connection refused nor a timeout nor an authentication error of the server.
+type BastionError struct {
+	// Address is the address of the bastion host.
+	Address string
+	Err     error
+}
+
+// Error implements the error interface.
+func (e *BastionError) Error() string {
+	return fmt.Sprintf("ssh bastion %s: %s", e.Address, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *BastionError) Unwrap() error {
+	return e.Err
+}
+
+// IsBastionError checks whether the ssh error is caused by the bastion host.
+func IsBastionError(err error) bool {
+	var bastionErr *BastionError
+	return errors.As(err, &bastionErr)
+}
+
+func bastionAddress(address string) string {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return net.JoinHostPort(address, "22")
+	}
+	return address
+}
+
+func bastionUser(bastion *Bastion) string {
+	if bastion.User == "" {
+		return defaultBastionUser
+	}
+	return bastion.User
+}
+
+// connectBastion returns a pooled connection to the bastion host or opens a new one. The connection has to be
+// released after use.
+func (c *sshClient) connectBastion() (*pooledConn, error) {
+	conn, err := c.pool.connect(c.bastionKey, c.verifyBastionHostKey, c.dialBastion)
+	if err != nil {
+		return nil, &BastionError{Address: c.bastionKey.address, Err: err}
+	}
+	return conn, nil
+}
+
+func (c *sshClient) dialBastion() (*pooledConn, error) {
+	signer, err := ssh.ParsePrivateKey([]byte(c.bastion.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse private key: %w", err)
+	}
+
+	var hostKey ssh.PublicKey
+	config := &ssh.ClientConfig{
+		User: bastionUser(c.bastion),
+		Auth: []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: func(_ string, _ net.Addr, key ssh.PublicKey) error {
+			hostKey = key
+			return c.verifyBastionHostKey(key)
+		},
+		HostKeyAlgorithms: hostKeyAlgorithms([]string{c.bastion.HostKey}),
+		Timeout:           sshTimeOut,
+	}
+
+	client, err := ssh.Dial("tcp", c.bastionKey.address, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial ssh: %w", err)
+	}
+	return &pooledConn{client: client, hostKey: hostKey}, nil
+}
+
+// verifyBastionHostKey verifies the host key of the bastion host. A mismatch is no HostKeyMismatchError, as it
+// concerns the configuration of the bastion host and not the server.
+func (c *sshClient) verifyBastionHostKey(key ssh.PublicKey) error {
+	if c.bastion.HostKey == "" {
+		return nil
+	}
+	known, _, _, _, err := ssh.ParseAuthorizedKey([]byte(c.bastion.HostKey))
+	if err != nil {
+		return fmt.Errorf("failed to parse host key: %w", err)
+	}
+	if !bytes.Equal(known.Marshal(), key.Marshal()) {
+		return fmt.Errorf("host key does not match: got %q, want %q", FormatHostKey(key), c.bastion.HostKey)
+	}
+	return nil
+}
+
+// dialViaBastion opens a TCP connection to the server that is tunneled through the bastion connection. Errors of
+// the bastion host that refer to the server are translated, so that IsConnectionRefusedError and IsTimeoutError
+// detect them like errors of direct connections.
+func (c *sshClient) dialViaBastion(bastion *ssh.Client) (net.Conn, error) {
+	type dialResult struct {
+		conn net.Conn
+		err  error
+	}
+	resultCh := make(chan dialResult, 1)
+	go func() {
+		conn, err := bastion.Dial("tcp", c.address)
+		resultCh <- dialResult{conn: conn, err: err}
+	}()
+
+	timer := time.NewTimer(sshTimeOut)
+	defer timer.Stop()
+
+	select {
+	case result := <-resultCh:
+		if result.err != nil {
+			return nil, c.tunnelError(result.err)
+		}
+		return result.conn, nil
+	case <-timer.C:
+		go func() {
+			if result := <-resultCh; result.conn != nil {
+				_ = result.conn.Close()
+			}
+		}()
+		return nil, fmt.Errorf("dial tcp %s via bastion: %w", c.address, ErrTimeout)
+	}
+}
+
+func (c *sshClient) tunnelError(err error) error {
+	var openChannelErr *ssh.OpenChannelError
+	if !errors.As(err, &openChannelErr) || openChannelErr.Reason != ssh.ConnectionFailed {
+		// the bastion host does not allow forwarding or the connection to it broke
+		return &BastionError{Address: c.bastionKey.address, Err: err}
+	}
+
+	msg := strings.ToLower(openChannelErr.Message)
+	switch {
+	case strings.Contains(msg, "refused"):
+		return fmt.Errorf("dial tcp %s via bastion: %w", c.address, ErrConnectionRefused)
+	case strings.Contains(msg, "timed out") || strings.Contains(msg, "timeout"):
+		return fmt.Errorf("dial tcp %s via bastion: %w", c.address, ErrTimeout)
+	}
+	return fmt.Errorf("dial tcp %s via bastion: %w", c.address, err)
+}
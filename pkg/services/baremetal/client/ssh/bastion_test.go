@@ -0,0 +1,142 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sshclient
+
+import (
+	"net"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ssh"
+)
+
+// closedPort returns a local port that nothing listens on.
+func closedPort() int {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).To(Succeed())
+	port := listener.Addr().(*net.TCPAddr).Port
+	Expect(listener.Close()).To(Succeed())
+	return port
+}
+
+var _ = Describe("bastion host", func() {
+	var (
+		bastionServer    *testSSHServer
+		bastionPubKey    string
+		servers          []*testSSHServer
+		clientKey        string
+		bastionClientKey string
+		factory          Factory
+		bastion          *Bastion
+	)
+
+	BeforeEach(func() {
+		var (
+			bastionKey string
+			err        error
+		)
+		bastionKey, bastionPubKey, err = GenerateHostKey()
+		Expect(err).To(Succeed())
+		bastionServer = startSSHServer(bastionKey)
+
+		servers = make([]*testSSHServer, 2)
+		for i := range servers {
+			serverKey, _, err := GenerateHostKey()
+			Expect(err).To(Succeed())
+			servers[i] = startSSHServer(serverKey)
+		}
+
+		clientKey, _, err = GenerateHostKey()
+		Expect(err).To(Succeed())
+		bastionClientKey, _, err = GenerateHostKey()
+		Expect(err).To(Succeed())
+
+		factory = NewFactory()
+		bastion = &Bastion{
+			Address:    bastionServer.address(),
+			User:       "jump",
+			PrivateKey: bastionClientKey,
+			HostKey:    bastionPubKey,
+		}
+	})
+
+	newInput := func(ip string, port int) Input {
+		return Input{IP: ip, Port: port, PrivateKey: clientKey, Bastion: bastion}
+	}
+
+	It("tunnels the connections to all servers through one bastion connection", func() {
+		for _, server := range servers {
+			for i := 0; i < 2; i++ {
+				out := factory.NewClient(newInput(server.ip, server.port)).GetHostName()
+				Expect(out.Err).To(Succeed())
+				Expect(out.StdOut).To(Equal("rescue\n"))
+			}
+			Expect(server.connections()).To(Equal(1))
+		}
+		Expect(bastionServer.connections()).To(Equal(1))
+	})
+
+	It("detects refused connections of the server through the tunnel", func() {
+		err := factory.NewClient(newInput("127.0.0.1", closedPort())).GetHostName().Err
+		Expect(IsConnectionRefusedError(err)).To(BeTrue())
+		Expect(IsBastionError(err)).To(BeFalse())
+	})
+
+	It("reports an unreachable bastion host as bastion error", func() {
+		bastion.Address = net.JoinHostPort("127.0.0.1", strconv.Itoa(closedPort()))
+
+		err := factory.NewClient(newInput(servers[0].ip, servers[0].port)).GetHostName().Err
+		Expect(IsBastionError(err)).To(BeTrue())
+		Expect(IsConnectionRefusedError(err)).To(BeFalse())
+		Expect(IsTimeoutError(err)).To(BeFalse())
+	})
+
+	It("verifies the host key of the bastion host", func() {
+		_, otherPubKey, err := GenerateHostKey()
+		Expect(err).To(Succeed())
+		bastion.HostKey = otherPubKey
+
+		err = factory.NewClient(newInput(servers[0].ip, servers[0].port)).GetHostName().Err
+		Expect(IsBastionError(err)).To(BeTrue())
+		Expect(IsHostKeyMismatchError(err)).To(BeFalse())
+		Expect(servers[0].connections()).To(BeZero())
+	})
+
+	It("reconnects through a new bastion connection if the old one broke", func() {
+		client := factory.NewClient(newInput(servers[0].ip, servers[0].port))
+		Expect(client.GetHostName().Err).To(Succeed())
+
+		bastionServer.closeConnections()
+		Expect(client.GetHostName().Err).To(Succeed())
+		Expect(bastionServer.connections()).To(Equal(2))
+	})
+})
+
+var _ = DescribeTable("tunnelError",
+	func(err error, isConnectionRefused, isTimeout, isBastionError bool) {
+		c := &sshClient{address: "1.2.3.4:22", bastionKey: newConnKey("5.6.7.8:22", "root", "")}
+		tunnelErr := c.tunnelError(err)
+		Expect(IsConnectionRefusedError(tunnelErr)).To(Equal(isConnectionRefused))
+		Expect(IsTimeoutError(tunnelErr)).To(Equal(isTimeout))
+		Expect(IsBastionError(tunnelErr)).To(Equal(isBastionError))
+	},
+	Entry("connection refused", &ssh.OpenChannelError{Reason: ssh.ConnectionFailed, Message: "Connection refused"}, true, false, false),
+	Entry("connection timed out", &ssh.OpenChannelError{Reason: ssh.ConnectionFailed, Message: "Connection timed out"}, false, true, false),
+	Entry("no route to host", &ssh.OpenChannelError{Reason: ssh.ConnectionFailed, Message: "No route to host"}, false, false, false),
+	Entry("forwarding prohibited", &ssh.OpenChannelError{Reason: ssh.Prohibited, Message: "administratively prohibited"}, false, false, true),
+)
@@ -0,0 +1,98 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sshclient
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	diskWipeScriptPath = "/root/wipe-disks.sh"
+	diskWipeStatusDir  = "/root/wipe-disks"
+	diskWipeLogPath    = "/root/wipe-disks.log"
+)
+
+// diskWipeScript wipes the disks that are passed as arguments in parallel. The state of every disk is written to a
+// file in the status directory as "<name> <state> [<method>]". NVMe disks are formatted with a user data erase, other
+// disks are discarded. If neither is supported, the disk is overwritten with zeros.
+const diskWipeScript = `#!/bin/bash
+status_dir=` + diskWipeStatusDir + `
+
+write_state() {
+	echo "$1 $2 $3" > "$status_dir/$1.tmp" && mv "$status_dir/$1.tmp" "$status_dir/$1"
+}
+
+wipe_disk() {
+	local name=$1
+	local dev=/dev/$1
+	write_state "$name" running
+	if [[ $name == nvme* ]] && nvme format "$dev" --ses=1 --force; then
+		write_state "$name" wiped nvme-format
+	elif blkdiscard --secure "$dev" || blkdiscard "$dev"; then
+		write_state "$name" wiped blkdiscard
+	elif shred --iterations=0 --zero "$dev"; then
+		write_state "$name" wiped overwrite
+	else
+		write_state "$name" failed
+	fi
+}
+
+# release the disks that the rescue system has assembled
+vgchange --activate n
+mdadm --stop --scan
+
+for name in "$@"; do
+	write_state "$name" pending
+done
+for name in "$@"; do
+	wipe_disk "$name" &
+done
+wait
+`
+
+var diskNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// StartDiskWipe implements the StartDiskWipe method of the SSHClient interface.
+func (c *sshClient) StartDiskWipe(devices []string) Output {
+	if len(devices) == 0 {
+		return Output{Err: fmt.Errorf("no disks to wipe")}
+	}
+	for _, device := range devices {
+		if !diskNameRegex.MatchString(device) {
+			return Output{Err: fmt.Errorf("invalid disk name %q", device)}
+		}
+	}
+
+	out := c.runSSH(fmt.Sprintf(`cat << 'EOF' > %s
+%sEOF`, diskWipeScriptPath, diskWipeScript))
+	if out.Err != nil || out.StdErr != "" {
+		return out
+	}
+
+	// the wipe takes hours for large hard disks, so the script keeps running after the session has been closed
+	return c.runSSH(fmt.Sprintf(`rm -rf %[1]s && mkdir %[1]s && nohup bash %[2]s %[3]s > %[4]s 2>&1 < /dev/null &`,
+		diskWipeStatusDir, diskWipeScriptPath, strings.Join(devices, " "), diskWipeLogPath))
+}
+
+// GetDiskWipeStatus implements the GetDiskWipeStatus method of the SSHClient interface. It prints the state of every
+// disk and a last line "active" while the wipe is running.
+func (c *sshClient) GetDiskWipeStatus() Output {
+	return c.runSSH(fmt.Sprintf(`cat %s/* 2>/dev/null
+if pgrep -f "^bash %s" > /dev/null; then echo active; fi`, diskWipeStatusDir, diskWipeScriptPath))
+}
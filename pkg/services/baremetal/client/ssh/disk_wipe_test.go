@@ -0,0 +1,35 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sshclient
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StartDiskWipe", func() {
+	DescribeTable("rejects disks that cannot be passed to the script",
+		func(devices []string) {
+			// the client is never connected, as the disks are validated first
+			out := NewFactory().NewClient(Input{IP: "127.0.0.1", Port: 1}).StartDiskWipe(devices)
+			Expect(out.Err).To(HaveOccurred())
+		},
+		Entry("no disks", nil),
+		Entry("path", []string{"sda", "/dev/sdb"}),
+		Entry("shell command", []string{"sda;reboot"}),
+	)
+})
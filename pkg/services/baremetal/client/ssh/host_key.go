@@ -0,0 +1,192 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sshclient
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// defaultHostKeyAlgorithms are the host key algorithms that are offered if no host key is known yet. ed25519 is
+// preferred, as every current OpenSSH server has an ed25519 host key and the controller generates one for the OS.
+var defaultHostKeyAlgorithms = []string{
+	ssh.KeyAlgoED25519,
+	ssh.KeyAlgoECDSA256, ssh.KeyAlgoECDSA384, ssh.KeyAlgoECDSA521,
+	ssh.KeyAlgoRSASHA512, ssh.KeyAlgoRSASHA256,
+}
+
+// HostKeyMismatchError means that a server presented a host key that differs from the host keys known for it.
+type HostKeyMismatchError struct {
+	// Address is the address of the server.
+	Address string
+	// Want are the known host keys in authorized_keys format.
+	Want []string
+	// Got is the presented host key in authorized_keys format.
+	Got string
+}
+
+// Error implements the error interface.
+func (e *HostKeyMismatchError) Error() string {
+	return fmt.Sprintf("ssh host key of %s does not match the recorded host key: got %q, want %q",
+		e.Address, e.Got, strings.Join(e.Want, ", "))
+}
+
+// IsHostKeyMismatchError checks whether the ssh error is a host key mismatch error.
+func IsHostKeyMismatchError(err error) bool {
+	var mismatchErr *HostKeyMismatchError
+	return errors.As(err, &mismatchErr)
+}
+
+// FormatHostKey returns a host key in authorized_keys format without a trailing line break.
+func FormatHostKey(key ssh.PublicKey) string {
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key)))
+}
+
+// GenerateHostKey generates an ed25519 host key. The private key is returned in the OpenSSH format that sshd
+// expects, the public key in authorized_keys format.
+func GenerateHostKey() (privateKey, publicKey string, err error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate ed25519 key: %w", err)
+	}
+	sshPub, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to convert ed25519 public key: %w", err)
+	}
+	block, err := marshalED25519PrivateKey(sshPub, priv)
+	if err != nil {
+		return "", "", err
+	}
+	return string(pem.EncodeToMemory(block)), FormatHostKey(sshPub), nil
+}
+
+// marshalED25519PrivateKey encodes an unencrypted ed25519 private key in the "openssh-key-v1" format.
+func marshalED25519PrivateKey(pub ssh.PublicKey, priv ed25519.PrivateKey) (*pem.Block, error) {
+	var check [4]byte
+	if _, err := rand.Read(check[:]); err != nil {
+		return nil, fmt.Errorf("failed to generate check value: %w", err)
+	}
+	checkInt := binary.BigEndian.Uint32(check[:])
+
+	privBlock := ssh.Marshal(struct {
+		Check1  uint32
+		Check2  uint32
+		Keytype string
+		Pub     []byte
+		Priv    []byte
+		Comment string
+	}{
+		Check1:  checkInt,
+		Check2:  checkInt,
+		Keytype: ssh.KeyAlgoED25519,
+		Pub:     priv.Public().(ed25519.PublicKey),
+		Priv:    priv,
+	})
+	// pad to the block size of the cipher "none"
+	for i := byte(1); len(privBlock)%8 != 0; i++ {
+		privBlock = append(privBlock, i)
+	}
+
+	data := ssh.Marshal(struct {
+		CipherName   string
+		KdfName      string
+		KdfOpts      string
+		NumKeys      uint32
+		PubKey       []byte
+		PrivKeyBlock []byte
+	}{
+		CipherName:   "none",
+		KdfName:      "none",
+		NumKeys:      1,
+		PubKey:       pub.Marshal(),
+		PrivKeyBlock: privBlock,
+	})
+
+	return &pem.Block{
+		Type:  "OPENSSH PRIVATE KEY",
+		Bytes: append([]byte("openssh-key-v1\x00"), data...),
+	}, nil
+}
+
+// hostKeyAlgorithms returns the host key algorithms that make a server present one of the known host keys.
+func hostKeyAlgorithms(hostKeys []string) []string {
+	var algorithms []string
+	for _, hostKey := range hostKeys {
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(hostKey))
+		if err != nil {
+			continue
+		}
+		keyAlgorithms := []string{key.Type()}
+		if key.Type() == ssh.KeyAlgoRSA {
+			keyAlgorithms = []string{ssh.KeyAlgoRSASHA512, ssh.KeyAlgoRSASHA256, ssh.KeyAlgoRSA}
+		}
+		for _, algorithm := range keyAlgorithms {
+			if !slices.Contains(algorithms, algorithm) {
+				algorithms = append(algorithms, algorithm)
+			}
+		}
+	}
+	if len(algorithms) == 0 {
+		return defaultHostKeyAlgorithms
+	}
+	return algorithms
+}
+
+// hostKeyCallback returns a callback that verifies the host key with verifyHostKey. A mismatch is stored in
+// mismatchErr, as the ssh package does not wrap the error of the callback.
+func (c *sshClient) hostKeyCallback(mismatchErr *error) ssh.HostKeyCallback {
+	return func(hostname string, _ net.Addr, key ssh.PublicKey) error {
+		if err := c.verifyHostKey(hostname, key); err != nil {
+			*mismatchErr = err
+			return err
+		}
+		return nil
+	}
+}
+
+// verifyHostKey verifies the presented host key against the known host keys of the client. If no host key is
+// known, the presented host key is trusted on first use: it is passed to trustHostKey and verified on all further
+// connections of the client.
+func (c *sshClient) verifyHostKey(hostname string, key ssh.PublicKey) error {
+	got := FormatHostKey(key)
+	if len(c.hostKeys) == 0 {
+		c.hostKeys = []string{got}
+		if c.trustHostKey != nil {
+			c.trustHostKey(got)
+		}
+		return nil
+	}
+
+	for _, hostKey := range c.hostKeys {
+		known, _, _, _, err := ssh.ParseAuthorizedKey([]byte(hostKey))
+		if err == nil && bytes.Equal(known.Marshal(), key.Marshal()) {
+			return nil
+		}
+	}
+
+	return &HostKeyMismatchError{Address: hostname, Want: c.hostKeys, Got: got}
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sshclient
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ssh"
+)
+
+// testPassphrase is the passphrase that cryptroot-unlock of the test server accepts.
+const testPassphrase = "passphrase"
+
+// testSSHServer is an SSH server that answers every command with "rescue". It also accepts cryptroot-unlock
+// with testPassphrase.
+type testSSHServer struct {
+	ip   string
+	port int
+
+	mu    sync.Mutex
+	conns []net.Conn
+}
+
+// startSSHServer starts an SSH server with the given host key.
+func startSSHServer(hostKey string) *testSSHServer {
+	signer, err := ssh.ParsePrivateKey([]byte(hostKey))
+	Expect(err).To(Succeed())
+
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(ssh.ConnMetadata, ssh.PublicKey) (*ssh.Permissions, error) {
+			return nil, nil
+		},
+	}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).To(Succeed())
+
+	server := &testSSHServer{}
+	DeferCleanup(func() {
+		_ = listener.Close()
+		server.closeConnections()
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			server.mu.Lock()
+			server.conns = append(server.conns, conn)
+			server.mu.Unlock()
+			go serveSSH(conn, config)
+		}
+	}()
+
+	host, portStr, err := net.SplitHostPort(listener.Addr().String())
+	Expect(err).To(Succeed())
+	server.ip = host
+	server.port, err = strconv.Atoi(portStr)
+	Expect(err).To(Succeed())
+	return server
+}
+
+func (s *testSSHServer) address() string {
+	return net.JoinHostPort(s.ip, strconv.Itoa(s.port))
+}
+
+// connections returns the number of connections that have been opened.
+func (s *testSSHServer) connections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.conns)
+}
+
+// closeConnections closes all connections, like a reboot of the server does.
+func (s *testSSHServer) closeConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, conn := range s.conns {
+		_ = conn.Close()
+	}
+}
+
+func serveSSH(conn net.Conn, config *ssh.ServerConfig) {
+	defer conn.Close()
+	_, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		switch newChannel.ChannelType() {
+		case "session":
+			go serveSession(newChannel)
+		case "direct-tcpip":
+			go serveForwarding(newChannel)
+		default:
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+		}
+	}
+}
+
+func serveSession(newChannel ssh.NewChannel) {
+	channel, channelRequests, err := newChannel.Accept()
+	if err != nil {
+		return
+	}
+	for req := range channelRequests {
+		if req.Type != "exec" {
+			_ = req.Reply(false, nil)
+			continue
+		}
+		_ = req.Reply(true, nil)
+
+		var exec struct{ Command string }
+		_ = ssh.Unmarshal(req.Payload, &exec)
+		status := uint32(0)
+		if exec.Command == "cryptroot-unlock" {
+			passphrase, _ := bufio.NewReader(channel).ReadString('\n')
+			if passphrase != testPassphrase+"\n" {
+				status = 1
+			}
+		} else {
+			_, _ = channel.Write([]byte("rescue\n"))
+		}
+		_, _ = channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{status}))
+		_ = channel.Close()
+	}
+}
+
+// serveForwarding forwards a TCP connection like a bastion host does.
+func serveForwarding(newChannel ssh.NewChannel) {
+	var target struct {
+		Host     string
+		Port     uint32
+		OrigHost string
+		OrigPort uint32
+	}
+	if err := ssh.Unmarshal(newChannel.ExtraData(), &target); err != nil {
+		_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+
+	conn, err := net.Dial("tcp", net.JoinHostPort(target.Host, strconv.Itoa(int(target.Port))))
+	if err != nil {
+		_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+	channel, channelRequests, err := newChannel.Accept()
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(channelRequests)
+
+	go func() {
+		_, _ = io.Copy(channel, conn)
+		_ = channel.CloseWrite()
+	}()
+	_, _ = io.Copy(conn, channel)
+	_ = conn.Close()
+}
+
+var _ = Describe("GenerateHostKey", func() {
+	It("generates a private key that sshd can parse and its public key", func() {
+		privateKey, publicKey, err := GenerateHostKey()
+		Expect(err).To(Succeed())
+
+		signer, err := ssh.ParsePrivateKey([]byte(privateKey))
+		Expect(err).To(Succeed())
+		Expect(FormatHostKey(signer.PublicKey())).To(Equal(publicKey))
+		Expect(publicKey).To(HavePrefix(ssh.KeyAlgoED25519 + " "))
+	})
+})
+
+var _ = Describe("host key verification", func() {
+	var (
+		clientKey     string
+		serverKey     string
+		serverPubKey  string
+		otherPubKey   string
+		server        *testSSHServer
+		trustedKeys   []string
+		newTestClient func(hostKeys ...string) Client
+	)
+
+	BeforeEach(func() {
+		var err error
+		clientKey, _, err = GenerateHostKey()
+		Expect(err).To(Succeed())
+		serverKey, serverPubKey, err = GenerateHostKey()
+		Expect(err).To(Succeed())
+		_, otherPubKey, err = GenerateHostKey()
+		Expect(err).To(Succeed())
+
+		server = startSSHServer(serverKey)
+
+		trustedKeys = nil
+		newTestClient = func(hostKeys ...string) Client {
+			return NewFactory().NewClient(Input{
+				IP:           server.ip,
+				Port:         server.port,
+				PrivateKey:   clientKey,
+				HostKeys:     hostKeys,
+				TrustHostKey: func(hostKey string) { trustedKeys = append(trustedKeys, hostKey) },
+			})
+		}
+	})
+
+	It("trusts the host key on first use and verifies it afterwards", func() {
+		client := newTestClient()
+
+		out := client.GetHostName()
+		Expect(out.Err).To(Succeed())
+		Expect(out.StdOut).To(Equal("rescue\n"))
+		Expect(trustedKeys).To(Equal([]string{serverPubKey}))
+
+		Expect(client.GetHostName().Err).To(Succeed())
+		Expect(trustedKeys).To(HaveLen(1))
+	})
+
+	It("accepts any of the known host keys", func() {
+		out := newTestClient(otherPubKey, serverPubKey).GetHostName()
+		Expect(out.Err).To(Succeed())
+		Expect(trustedKeys).To(BeEmpty())
+	})
+
+	It("fails with a host key mismatch error if the host key is unknown", func() {
+		out := newTestClient(otherPubKey).GetHostName()
+		Expect(IsHostKeyMismatchError(out.Err)).To(BeTrue())
+		Expect(IsConnectionRefusedError(out.Err)).To(BeFalse())
+		Expect(IsTimeoutError(out.Err)).To(BeFalse())
+		Expect(out.StdOut).To(BeEmpty())
+		Expect(trustedKeys).To(BeEmpty())
+	})
+})
+
+var _ = Describe("hostKeyAlgorithms", func() {
+	It("prefers ed25519 if no host key is known", func() {
+		Expect(hostKeyAlgorithms(nil)[0]).To(Equal(ssh.KeyAlgoED25519))
+	})
+
+	It("offers only the algorithms of the known host keys", func() {
+		_, publicKey, err := GenerateHostKey()
+		Expect(err).To(Succeed())
+		Expect(hostKeyAlgorithms([]string{publicKey, publicKey})).To(Equal([]string{ssh.KeyAlgoED25519}))
+	})
+})
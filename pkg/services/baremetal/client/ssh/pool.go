@@ -0,0 +1,259 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sshclient
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// maxPooledConnections is the number of connections that are kept open. If another connection is opened,
+	// the least recently used idle connection is closed.
+	maxPooledConnections = 100
+	// connectionIdleTimeout is the time after which unused connections are closed.
+	connectionIdleTimeout = 2 * time.Minute
+)
+
+// connKey identifies the connections that can be shared. Sessions of all clients with the same address, user,
+// private key and bastion host are multiplexed over one connection.
+type connKey struct {
+	address        string
+	user           string
+	privateKeyHash [sha256.Size]byte
+	// bastionHash identifies the bastion connection that the connection is tunneled through. It is zero for
+	// direct connections.
+	bastionHash [sha256.Size]byte
+}
+
+func newConnKey(address, user, privateKey string) connKey {
+	return connKey{address: address, user: user, privateKeyHash: sha256.Sum256([]byte(privateKey))}
+}
+
+// tunneledVia returns the key of the connection that is tunneled through the connection of the bastion key.
+func (k connKey) tunneledVia(bastion connKey) connKey {
+	k.bastionHash = sha256.Sum256(fmt.Appendf(nil, "%s\x00%s\x00%x\x00%x",
+		bastion.address, bastion.user, bastion.privateKeyHash, bastion.bastionHash))
+	return k
+}
+
+type pooledConn struct {
+	client *ssh.Client
+	// hostKey is the host key that has been presented when the connection was opened.
+	hostKey ssh.PublicKey
+	// bastion is the bastion connection that the connection is tunneled through. It stays acquired until the
+	// connection is closed.
+	bastion  *pooledConn
+	inUse    int
+	lastUsed time.Time
+}
+
+// connPool keeps SSH connections open across reconciles, so that a host is not connected to for every command.
+// Idle connections are closed by a janitor, which runs while the pool has connections.
+type connPool struct {
+	mu          sync.Mutex
+	conns       map[connKey]*pooledConn
+	maxConns    int
+	idleTimeout time.Duration
+	now         func() time.Time
+	// janitorRunning reports whether the janitor has been started and not stopped yet.
+	janitorRunning bool
+}
+
+func newConnPool(maxConns int, idleTimeout time.Duration) *connPool {
+	return &connPool{
+		conns:       make(map[connKey]*pooledConn),
+		maxConns:    maxConns,
+		idleTimeout: idleTimeout,
+		now:         time.Now,
+	}
+}
+
+// connect returns a pooled connection or opens a new one with dial. The host key of a pooled connection is checked
+// with verify, as the connection might have been opened by a client that knows other host keys. The connection has
+// to be released after use.
+func (p *connPool) connect(key connKey, verify func(ssh.PublicKey) error, dial func() (*pooledConn, error)) (*pooledConn, error) {
+	if conn := p.acquire(key); conn != nil {
+		if err := verify(conn.hostKey); err != nil {
+			p.release(conn)
+			return nil, err
+		}
+		if alive(conn.client) {
+			return conn, nil
+		}
+		p.release(conn)
+		p.remove(key, conn)
+	}
+
+	dialed, err := dial()
+	if err != nil {
+		return nil, err
+	}
+	conn := p.add(key, dialed)
+	if conn != dialed {
+		// another client has pooled a connection in the meantime
+		if err := verify(conn.hostKey); err != nil {
+			p.release(conn)
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// acquire returns the pooled connection of a key or nil. The connection has to be released after use.
+func (p *connPool) acquire(key connKey) *pooledConn {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closeIdle()
+	conn, ok := p.conns[key]
+	if !ok {
+		return nil
+	}
+	conn.inUse++
+	return conn
+}
+
+// add pools a new connection and acquires it. If another connection has been pooled in the meantime, the new
+// connection is closed and the pooled one is acquired instead.
+func (p *connPool) add(key connKey, conn *pooledConn) *pooledConn {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if pooled, ok := p.conns[key]; ok {
+		p.close(conn)
+		pooled.inUse++
+		return pooled
+	}
+
+	p.closeIdle()
+	if len(p.conns) >= p.maxConns {
+		p.closeLeastRecentlyUsed()
+	}
+
+	conn.inUse = 1
+	conn.lastUsed = p.now()
+	p.conns[key] = conn
+
+	if !p.janitorRunning {
+		p.janitorRunning = true
+		go p.runJanitor()
+	}
+	return conn
+}
+
+// runJanitor closes idle connections periodically, so that connections to hosts are closed even if no more
+// reconciles use the pool. It stops once the pool is empty and is started again with the next connection.
+func (p *connPool) runJanitor() {
+	ticker := time.NewTicker(p.idleTimeout / 2)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		p.mu.Lock()
+		p.closeIdle()
+		if len(p.conns) == 0 {
+			p.janitorRunning = false
+			p.mu.Unlock()
+			return
+		}
+		p.mu.Unlock()
+	}
+}
+
+func (p *connPool) release(conn *pooledConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	conn.inUse--
+	conn.lastUsed = p.now()
+}
+
+// remove closes a connection and removes it from the pool, e.g. because it is broken.
+func (p *connPool) remove(key connKey, conn *pooledConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conns[key] != conn {
+		// the connection has already been closed
+		return
+	}
+	delete(p.conns, key)
+	p.close(conn)
+}
+
+// closeIdle closes the connections that have not been used within the idle timeout. It has to be called with the
+// lock held.
+func (p *connPool) closeIdle() {
+	for key, conn := range p.conns {
+		if conn.inUse == 0 && p.now().Sub(conn.lastUsed) > p.idleTimeout {
+			delete(p.conns, key)
+			p.close(conn)
+		}
+	}
+}
+
+// closeLeastRecentlyUsed closes the idle connection that has not been used for the longest time. Connections that
+// are in use are never closed, so the pool can grow beyond its size while all of them are busy. It has to be
+// called with the lock held.
+func (p *connPool) closeLeastRecentlyUsed() {
+	var (
+		lruKey  connKey
+		lruConn *pooledConn
+	)
+	for key, conn := range p.conns {
+		if conn.inUse > 0 {
+			continue
+		}
+		if lruConn == nil || conn.lastUsed.Before(lruConn.lastUsed) {
+			lruKey, lruConn = key, conn
+		}
+	}
+	if lruConn != nil {
+		delete(p.conns, lruKey)
+		p.close(lruConn)
+	}
+}
+
+// close closes a connection and releases its bastion connection. It has to be called with the lock held.
+func (p *connPool) close(conn *pooledConn) {
+	_ = conn.client.Close()
+	if conn.bastion != nil {
+		conn.bastion.inUse--
+		conn.bastion.lastUsed = p.now()
+	}
+}
+
+// alive checks whether the server still answers on a connection. Connections to hosts that have been rebooted
+// might not have been closed yet.
+func alive(client *ssh.Client) bool {
+	errCh := make(chan error, 1)
+	go func() {
+		_, _, err := client.SendRequest("keepalive@openssh.com", true, nil)
+		errCh <- err
+	}()
+
+	select {
+	case err := <-errCh:
+		return err == nil
+	case <-time.After(sshTimeOut):
+		return false
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sshclient
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("connection pool", func() {
+	var (
+		server  *testSSHServer
+		pool    *connPool
+		factory Factory
+		keys    []string
+		now     time.Time
+	)
+
+	newInput := func(privateKey string) Input {
+		return Input{IP: server.ip, Port: server.port, PrivateKey: privateKey}
+	}
+
+	BeforeEach(func() {
+		serverKey, _, err := GenerateHostKey()
+		Expect(err).To(Succeed())
+		server = startSSHServer(serverKey)
+
+		keys = make([]string, 2)
+		for i := range keys {
+			keys[i], _, err = GenerateHostKey()
+			Expect(err).To(Succeed())
+		}
+
+		now = time.Now()
+		pool = newConnPool(2, time.Minute)
+		pool.now = func() time.Time { return now }
+		factory = &sshFactory{pool: pool}
+	})
+
+	It("multiplexes the sessions of all clients over one connection", func() {
+		for i := 0; i < 2; i++ {
+			client := factory.NewClient(newInput(keys[0]))
+			for j := 0; j < 3; j++ {
+				Expect(client.GetHostName().Err).To(Succeed())
+			}
+		}
+		Expect(server.connections()).To(Equal(1))
+	})
+
+	It("opens a connection per private key", func() {
+		Expect(factory.NewClient(newInput(keys[0])).GetHostName().Err).To(Succeed())
+		Expect(factory.NewClient(newInput(keys[1])).GetHostName().Err).To(Succeed())
+		Expect(server.connections()).To(Equal(2))
+	})
+
+	It("verifies the host key of pooled connections", func() {
+		Expect(factory.NewClient(newInput(keys[0])).GetHostName().Err).To(Succeed())
+
+		_, otherPubKey, err := GenerateHostKey()
+		Expect(err).To(Succeed())
+		in := newInput(keys[0])
+		in.HostKeys = []string{otherPubKey}
+		Expect(IsHostKeyMismatchError(factory.NewClient(in).GetHostName().Err)).To(BeTrue())
+	})
+
+	It("opens a new connection if the pooled one is broken", func() {
+		client := factory.NewClient(newInput(keys[0]))
+		Expect(client.GetHostName().Err).To(Succeed())
+
+		server.closeConnections()
+		Expect(client.GetHostName().Err).To(Succeed())
+		Expect(server.connections()).To(Equal(2))
+	})
+
+	It("closes idle connections", func() {
+		Expect(factory.NewClient(newInput(keys[0])).GetHostName().Err).To(Succeed())
+
+		now = now.Add(2 * time.Minute)
+		Expect(factory.NewClient(newInput(keys[1])).GetHostName().Err).To(Succeed())
+		Expect(pool.conns).To(HaveLen(1))
+		Expect(pool.conns).To(HaveKey(newConnKey(server.address(), "root", keys[1])))
+	})
+
+	It("closes idle connections without further use of the pool", func() {
+		pool = newConnPool(2, 100*time.Millisecond)
+		factory = &sshFactory{pool: pool}
+		Expect(factory.NewClient(newInput(keys[0])).GetHostName().Err).To(Succeed())
+
+		pooledConnections := func() int {
+			pool.mu.Lock()
+			defer pool.mu.Unlock()
+			return len(pool.conns)
+		}
+		Expect(pooledConnections()).To(Equal(1))
+		Eventually(pooledConnections).Should(BeZero())
+		Eventually(func() bool {
+			pool.mu.Lock()
+			defer pool.mu.Unlock()
+			return pool.janitorRunning
+		}).Should(BeFalse())
+
+		// the janitor is started again with the next connection
+		Expect(factory.NewClient(newInput(keys[0])).GetHostName().Err).To(Succeed())
+		Eventually(pooledConnections).Should(BeZero())
+		Expect(server.connections()).To(Equal(2))
+	})
+
+	It("closes the least recently used connection if the pool is full", func() {
+		thirdKey, _, err := GenerateHostKey()
+		Expect(err).To(Succeed())
+
+		for _, key := range []string{keys[0], keys[1], keys[0], thirdKey} {
+			now = now.Add(time.Second)
+			Expect(factory.NewClient(newInput(key)).GetHostName().Err).To(Succeed())
+		}
+		Expect(pool.conns).To(HaveLen(2))
+		Expect(pool.conns).To(HaveKey(newConnKey(server.address(), "root", keys[0])))
+		Expect(pool.conns).To(HaveKey(newConnKey(server.address(), "root", thirdKey)))
+	})
+})
@@ -21,6 +21,9 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
+	"net"
+	"strconv"
 	"strings"
 	"time"
 
@@ -54,6 +57,15 @@ type Input struct {
 	IP         string
 	PrivateKey string
 	Port       int
+	// HostKeys are the host keys in authorized_keys format that the server may present. If it presents another
+	// host key, the connection fails with a HostKeyMismatchError. If HostKeys is empty, the host key that is
+	// presented first is trusted and verified on all further connections of the client.
+	HostKeys []string
+	// TrustHostKey is called with the host key that is trusted on first use. It can be nil.
+	TrustHostKey func(hostKey string)
+	// Bastion is the bastion host that the connection is tunneled through. If it is nil, the server is
+	// connected to directly.
+	Bastion *Bastion
 }
 
 // Output defines the SSH output.
@@ -82,13 +94,16 @@ type Client interface {
 	Reboot() Output
 	EnsureCloudInit() Output
 	CreateNoCloudDirectory() Output
-	CreateMetaData(hostName string) Output
+	CreateMetaData(hostName string, vSwitchVLAN int) Output
 	CreateUserData(userData string) Output
 	CloudInitStatus() Output
 	CheckCloudInitLogsForSigTerm() Output
 	CleanCloudInitLogs() Output
 	CleanCloudInitInstances() Output
 	ResetKubeadm() Output
+	StartDiskWipe(devices []string) Output
+	GetDiskWipeStatus() Output
+	UnlockDisks(passphrase string) Output
 }
 
 // Factory is the interface for creating new Client objects.
@@ -96,28 +111,47 @@ type Factory interface {
 	NewClient(Input) Client
 }
 
-type sshFactory struct{}
+type sshFactory struct {
+	pool *connPool
+}
 
-// NewFactory creates a new factory for SSH clients.
+// NewFactory creates a new factory for SSH clients. The clients of a factory share their connections: sessions
+// to the same host with the same key are multiplexed over one connection, which is kept open across reconciles
+// until it is idle for some time.
 func NewFactory() Factory {
-	return &sshFactory{}
+	return &sshFactory{pool: newConnPool(maxPooledConnections, connectionIdleTimeout)}
 }
 
 var _ = Factory(&sshFactory{})
 
 // NewClient implements the NewClient method of the factory interface.
 func (f *sshFactory) NewClient(in Input) Client {
-	return &sshClient{
+	address := net.JoinHostPort(in.IP, strconv.Itoa(in.Port))
+	c := &sshClient{
 		privateSSHKey: in.PrivateKey,
-		ip:            in.IP,
-		port:          in.Port,
+		address:       address,
+		hostKeys:      in.HostKeys,
+		trustHostKey:  in.TrustHostKey,
+		pool:          f.pool,
+		connKey:       newConnKey(address, "root", in.PrivateKey),
+	}
+	if in.Bastion != nil {
+		c.bastion = in.Bastion
+		c.bastionKey = newConnKey(bastionAddress(in.Bastion.Address), bastionUser(in.Bastion), in.Bastion.PrivateKey)
+		c.connKey = c.connKey.tunneledVia(c.bastionKey)
 	}
+	return c
 }
 
 type sshClient struct {
-	ip            string
+	address       string
 	privateSSHKey string
-	port          int
+	hostKeys      []string
+	trustHostKey  func(hostKey string)
+	pool          *connPool
+	connKey       connKey
+	bastion       *Bastion
+	bastionKey    connKey
 }
 
 var _ = Client(&sshClient{})
@@ -254,12 +288,26 @@ EOF`, cmd))
 // Reboot implements the Reboot method of the SSHClient interface.
 func (c *sshClient) Reboot() Output {
 	out := c.runSSH(`reboot`)
+	// the connection does not survive the reboot
+	c.closeConnection()
 	if out.Err != nil && strings.Contains(out.Err.Error(), ErrCommandExitedWithoutExitSignal.Error()) {
 		return Output{}
 	}
 	return out
 }
 
+// UnlockDisks implements the UnlockDisks method of the SSHClient interface. It unlocks the encrypted partitions
+// through the SSH server in the initramfs.
+func (c *sshClient) UnlockDisks(passphrase string) Output {
+	out := c.runSSHWithStdin(`cryptroot-unlock`, strings.NewReader(passphrase+"\n"))
+	// the SSH server in the initramfs stops as soon as the boot continues
+	c.closeConnection()
+	if out.Err != nil && strings.Contains(out.Err.Error(), ErrCommandExitedWithoutExitSignal.Error()) {
+		return Output{StdOut: out.StdOut}
+	}
+	return out
+}
+
 // EnsureCloudInit implements the EnsureCloudInit method of the SSHClient interface.
 func (c *sshClient) EnsureCloudInit() Output {
 	return c.runSSH(`command -v cloud-init`)
@@ -271,10 +319,14 @@ func (c *sshClient) CreateNoCloudDirectory() Output {
 }
 
 // CreateMetaData implements the CreateMetaData method of the SSHClient interface.
-func (c *sshClient) CreateMetaData(hostName string) Output {
+// The VLAN of the vSwitch is only added if the host is part of a vSwitch.
+func (c *sshClient) CreateMetaData(hostName string, vSwitchVLAN int) Output {
+	metaData := fmt.Sprintf("local-hostname: %s\n", hostName)
+	if vSwitchVLAN != 0 {
+		metaData += fmt.Sprintf("vswitch_vlan: %d\n", vSwitchVLAN)
+	}
 	return c.runSSH(fmt.Sprintf(`cat << 'EOF' > /var/lib/cloud/seed/nocloud-net/meta-data
-local-hostname: %s
-EOF`, hostName))
+%sEOF`, metaData))
 }
 
 // CreateUserData implements the CreateUserData method of the SSHClient interface.
@@ -333,12 +385,12 @@ func (c *sshClient) ResetKubeadm() Output {
 
 // IsConnectionRefusedError checks whether the ssh error is a connection refused error.
 func IsConnectionRefusedError(err error) bool {
-	return strings.Contains(err.Error(), ErrConnectionRefused.Error())
+	return !IsBastionError(err) && strings.Contains(err.Error(), ErrConnectionRefused.Error())
 }
 
 // IsAuthenticationFailedError checks whether the ssh error is an authentication failed error.
 func IsAuthenticationFailedError(err error) bool {
-	return strings.Contains(err.Error(), ErrAuthenticationFailed.Error())
+	return !IsBastionError(err) && strings.Contains(err.Error(), ErrAuthenticationFailed.Error())
 }
 
 // IsCommandExitedWithoutExitSignalError checks whether the ssh error is an unplanned exit error.
@@ -348,50 +400,131 @@ func IsCommandExitedWithoutExitSignalError(err error) bool {
 
 // IsTimeoutError checks whether the ssh error is an unplanned exit error.
 func IsTimeoutError(err error) bool {
-	return strings.Contains(err.Error(), ErrTimeout.Error())
+	return !IsBastionError(err) && strings.Contains(err.Error(), ErrTimeout.Error())
 }
 
 func (c *sshClient) runSSH(command string) Output {
+	return c.runSSHWithStdin(command, nil)
+}
+
+// runSSHWithStdin runs a command with the given stdin. Secrets are passed via stdin, so that they do not show up in
+// the process list of the server.
+func (c *sshClient) runSSHWithStdin(command string, stdin io.Reader) Output {
+	conn, err := c.connect()
+	if err != nil {
+		return Output{Err: err}
+	}
+	defer c.pool.release(conn)
+
+	sess, err := conn.client.NewSession()
+	if err != nil {
+		c.pool.remove(c.connKey, conn)
+		return Output{Err: fmt.Errorf("unable to create new ssh session: %w", err)}
+	}
+	defer sess.Close()
+
+	var stdoutBuffer bytes.Buffer
+	var stderrBuffer bytes.Buffer
+
+	sess.Stdin = stdin
+	sess.Stdout = &stdoutBuffer
+	sess.Stderr = &stderrBuffer
+
+	err = sess.Run(command)
+	return Output{
+		StdOut: stdoutBuffer.String(),
+		StdErr: stderrBuffer.String(),
+		Err:    err,
+	}
+}
+
+// connect returns a pooled connection to the host or opens a new one. The connection has to be released after use.
+func (c *sshClient) connect() (*pooledConn, error) {
+	verify := func(key ssh.PublicKey) error {
+		if err := c.verifyHostKey(c.address, key); err != nil {
+			return fmt.Errorf("failed to dial ssh: %w", err)
+		}
+		return nil
+	}
+	return c.pool.connect(c.connKey, verify, c.dial)
+}
+
+func (c *sshClient) dial() (*pooledConn, error) {
 	// Create the Signer for this private key.
 	signer, err := ssh.ParsePrivateKey([]byte(c.privateSSHKey))
 	if err != nil {
-		return Output{Err: fmt.Errorf("unable to parse private key: %w", err)}
+		return nil, fmt.Errorf("unable to parse private key: %w", err)
 	}
 
+	var (
+		hostKey     ssh.PublicKey
+		mismatchErr error
+	)
+	verifyHostKey := c.hostKeyCallback(&mismatchErr)
 	config := &ssh.ClientConfig{
 		User: "root",
 		Auth: []ssh.AuthMethod{
 			// Use the PublicKeys method for remote authentication.
 			ssh.PublicKeys(signer),
 		},
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(), //#nosec
-		Timeout:         sshTimeOut,
+		HostKeyCallback: func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			hostKey = key
+			return verifyHostKey(hostname, remote, key)
+		},
+		HostKeyAlgorithms: hostKeyAlgorithms(c.hostKeys),
+		Timeout:           sshTimeOut,
+	}
+
+	if c.bastion != nil {
+		return c.dialTunneled(config, &hostKey, &mismatchErr)
 	}
 
 	// Connect to the remote server and perform the SSH handshake.
 
-	client, err := ssh.Dial("tcp", fmt.Sprintf("%s:%v", c.ip, c.port), config)
+	client, err := ssh.Dial("tcp", c.address, config)
 	if err != nil {
-		return Output{Err: fmt.Errorf("failed to dial ssh. Error message: %s. DialErr: %w", err.Error(), errSSHDialFailed)}
+		return nil, c.dialError(err, mismatchErr)
 	}
-	defer client.Close()
+	return &pooledConn{client: client, hostKey: hostKey}, nil
+}
 
-	sess, err := client.NewSession()
+// dialTunneled connects to the server through the bastion host. The bastion connection stays acquired until the
+// returned connection is closed.
+func (c *sshClient) dialTunneled(config *ssh.ClientConfig, hostKey *ssh.PublicKey, mismatchErr *error) (*pooledConn, error) {
+	bastion, err := c.connectBastion()
 	if err != nil {
-		return Output{Err: fmt.Errorf("unable to create new ssh session: %w", err)}
+		return nil, fmt.Errorf("failed to dial ssh: %w", err)
 	}
-	defer sess.Close()
 
-	var stdoutBuffer bytes.Buffer
-	var stderrBuffer bytes.Buffer
+	tcpConn, err := c.dialViaBastion(bastion.client)
+	if err != nil {
+		c.pool.release(bastion)
+		return nil, c.dialError(err, nil)
+	}
 
-	sess.Stdout = &stdoutBuffer
-	sess.Stderr = &stderrBuffer
+	conn, chans, reqs, err := ssh.NewClientConn(tcpConn, c.address, config)
+	if err != nil {
+		_ = tcpConn.Close()
+		c.pool.release(bastion)
+		return nil, c.dialError(err, *mismatchErr)
+	}
+	return &pooledConn{client: ssh.NewClient(conn, chans, reqs), hostKey: *hostKey, bastion: bastion}, nil
+}
 
-	err = sess.Run(command)
-	return Output{
-		StdOut: stdoutBuffer.String(),
-		StdErr: stderrBuffer.String(),
-		Err:    err,
+func (c *sshClient) dialError(err, mismatchErr error) error {
+	if mismatchErr != nil {
+		return fmt.Errorf("failed to dial ssh: %w", mismatchErr)
+	}
+	if IsBastionError(err) {
+		return fmt.Errorf("failed to dial ssh: %w", err)
+	}
+	return fmt.Errorf("failed to dial ssh. Error message: %s. DialErr: %w", err.Error(), errSSHDialFailed)
+}
+
+// closeConnection closes the pooled connection of the client, if there is one.
+func (c *sshClient) closeConnection() {
+	if conn := c.pool.acquire(c.connKey); conn != nil {
+		c.pool.release(conn)
+		c.pool.remove(c.connKey, conn)
 	}
 }
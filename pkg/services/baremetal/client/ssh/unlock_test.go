@@ -0,0 +1,56 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sshclient
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UnlockDisks", func() {
+	var (
+		server  *testSSHServer
+		factory Factory
+		input   Input
+	)
+
+	BeforeEach(func() {
+		clientKey, _, err := GenerateHostKey()
+		Expect(err).To(Succeed())
+		serverKey, _, err := GenerateHostKey()
+		Expect(err).To(Succeed())
+
+		server = startSSHServer(serverKey)
+		factory = &sshFactory{pool: newConnPool(maxPooledConnections, connectionIdleTimeout)}
+		input = Input{IP: server.ip, Port: server.port, PrivateKey: clientKey}
+	})
+
+	It("passes the passphrase to cryptroot-unlock", func() {
+		Expect(factory.NewClient(input).UnlockDisks(testPassphrase).Err).To(Succeed())
+	})
+
+	It("fails with a wrong passphrase", func() {
+		Expect(factory.NewClient(input).UnlockDisks("wrong").Err).To(HaveOccurred())
+	})
+
+	It("closes the connection, as the SSH server of the initramfs stops", func() {
+		client := factory.NewClient(input)
+		Expect(client.UnlockDisks(testPassphrase).Err).To(Succeed())
+		Expect(client.GetHostName().Err).To(Succeed())
+		Expect(server.connections()).To(Equal(2))
+	})
+})
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -39,6 +40,7 @@ import (
 
 	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
 	"github.com/syself/cluster-api-provider-hetzner/pkg/scope"
+	robotclient "github.com/syself/cluster-api-provider-hetzner/pkg/services/baremetal/client/robot"
 	sshclient "github.com/syself/cluster-api-provider-hetzner/pkg/services/baremetal/client/ssh"
 	"github.com/syself/cluster-api-provider-hetzner/pkg/utils"
 )
@@ -53,6 +55,7 @@ const (
 	gbToMebiBytes        int           = 1000
 	gbToBytes            int           = 1000000 * gbToMebiBytes
 	kikiToMebiBytes      int           = 1024
+	maxDiskWipeAttempts  int           = 5
 
 	errMsgFailedReboot                 = "failed to reboot bare metal server: %w"
 	errMsgInvalidSSHStdOut             = "invalid output in stdOut: %w"
@@ -72,6 +75,10 @@ var (
 	errMissingStorageDevice = fmt.Errorf("missing storage device")
 	errUnknownRota          = fmt.Errorf("unknown rota")
 	errSSHStderr            = fmt.Errorf("ssh cmd returned non-empty StdErr")
+	errUnknownDiskWipePhase = fmt.Errorf("unknown disk wipe phase")
+
+	errMissingEncryptionPassphrase = fmt.Errorf("missing encryption passphrase")
+	errInvalidEncryptionPassphrase = fmt.Errorf("invalid encryption passphrase")
 )
 
 // Service defines struct with machine scope to reconcile HetznerBareMetalHosts.
@@ -205,11 +212,11 @@ func (s *Service) actionPreparing() actionResult {
 		return actionError{err: fmt.Errorf("failed to enforce rescue mode: %w", err)}
 	}
 
-	sshClient := s.scope.SSHClientFactory.NewClient(sshclient.Input{
-		PrivateKey: sshclient.CredentialsFromSecret(s.scope.OSSSHSecret, s.scope.HetznerBareMetalHost.Spec.Status.SSHSpec.SecretRef).PrivateKey,
-		Port:       s.scope.HetznerBareMetalHost.Spec.Status.SSHSpec.PortAfterCloudInit,
-		IP:         s.scope.HetznerBareMetalHost.Spec.Status.GetIPAddress(),
-	})
+	// The host might run anything before it is prepared. It is only rebooted via SSH if it presents the recorded
+	// host key, but a host key presented now is not recorded.
+	in := s.osSSHInput(s.scope.HetznerBareMetalHost.Spec.Status.SSHSpec.PortAfterCloudInit)
+	in.TrustHostKey = nil
+	sshClient := s.scope.SSHClientFactory.NewClient(in)
 
 	// Check hostname with sshClient
 	out := sshClient.GetHostName()
@@ -251,6 +258,8 @@ func (s *Service) enforceRescueMode() error {
 		s.handleRobotRateLimitExceeded(err, "SetBootRescue")
 		return fmt.Errorf("failed to set boot rescue: %w", err)
 	}
+	// every rescue system has new host keys
+	s.scope.HetznerBareMetalHost.Spec.Status.SSHStatus.RescueHostKey = ""
 	return nil
 }
 
@@ -364,7 +373,9 @@ func (s *Service) handleIncompleteBoot(isRebootIntoRescue, isTimeout, isConnecti
 		// the (ssh) reboot did not start. We trigger an API reboot instead.
 		return false, s.handleErrorTypeSSHRebootFailed(isTimeout, isRebootIntoRescue)
 
-	case infrav1.ErrorTypeSSHRebootTriggered:
+	case infrav1.ErrorTypeSSHRebootTriggered, infrav1.ErrorTypeDisksUnlocked:
+		// after the partitions have been unlocked, the operating system gets as much time to boot as after a reboot
+		// via ssh
 		return false, s.handleErrorTypeSSHRebootFailed(isTimeout, isRebootIntoRescue)
 
 	case infrav1.ErrorTypeSoftwareRebootTriggered:
@@ -492,40 +503,20 @@ func (s *Service) ensureRescueMode() error {
 			s.handleRobotRateLimitExceeded(err, "SetBootRescue")
 			return fmt.Errorf("failed to set boot rescue: %w", err)
 		}
+		// every rescue system has new host keys
+		s.scope.HetznerBareMetalHost.Spec.Status.SSHStatus.RescueHostKey = ""
 	}
 	return nil
 }
 
 func (s *Service) actionRegistering() actionResult {
-	creds := sshclient.CredentialsFromSecret(s.scope.RescueSSHSecret, s.scope.HetznerCluster.Spec.SSHKeys.RobotRescueSecretRef)
-	in := sshclient.Input{
-		PrivateKey: creds.PrivateKey,
-		Port:       rescuePort,
-		IP:         s.scope.HetznerBareMetalHost.Spec.Status.GetIPAddress(),
-	}
-	sshClient := s.scope.SSHClientFactory.NewClient(in)
+	hasRescueHostKey := s.scope.HetznerBareMetalHost.Spec.Status.SSHStatus.RescueHostKey != ""
+	sshClient := s.scope.SSHClientFactory.NewClient(s.rescueSSHInput())
 
 	// Check hostname with sshClient
 	out := sshClient.GetHostName()
 	if trimLineBreak(out.StdOut) != rescue {
-		// give the reboot some time until it takes effect
-		if s.hasJustRebooted() {
-			return actionContinue{delay: 2 * time.Second}
-		}
-
-		isSSHTimeoutError, isSSHConnectionRefusedError, err := s.analyzeSSHOutputRegistering(out)
-		if err != nil {
-			return actionError{err: fmt.Errorf("failed to handle incomplete boot - registering: %w", err)}
-		}
-
-		failed, err := s.handleIncompleteBoot(true, isSSHTimeoutError, isSSHConnectionRefusedError)
-		if failed {
-			return s.recordActionFailure(infrav1.ProvisioningError, err.Error())
-		}
-		if err != nil {
-			return actionError{err: fmt.Errorf(errMsgFailedHandlingIncompleteBoot, err)}
-		}
-		return actionContinue{delay: 10 * time.Second}
+		return s.handleRescueNotBooted(out, hasRescueHostKey, "registering")
 	}
 
 	if s.scope.HetznerBareMetalHost.Spec.Status.HardwareDetails == nil {
@@ -549,6 +540,34 @@ func (s *Service) actionRegistering() actionResult {
 	return actionComplete{}
 }
 
+// handleRescueNotBooted waits for the host to boot into the rescue system and escalates the reboot if it takes too
+// long. out is the output of getting the hostname.
+func (s *Service) handleRescueNotBooted(out sshclient.Output, hasRescueHostKey bool, action string) actionResult {
+	// the host key is trusted on first use only if it belongs to the rescue system
+	if !hasRescueHostKey {
+		s.scope.HetznerBareMetalHost.Spec.Status.SSHStatus.RescueHostKey = ""
+	}
+
+	// give the reboot some time until it takes effect
+	if s.hasJustRebooted() {
+		return actionContinue{delay: 2 * time.Second}
+	}
+
+	isSSHTimeoutError, isSSHConnectionRefusedError, err := s.analyzeSSHOutputRegistering(out)
+	if err != nil {
+		return actionError{err: fmt.Errorf("failed to handle incomplete boot - %s: %w", action, err)}
+	}
+
+	failed, err := s.handleIncompleteBoot(true, isSSHTimeoutError, isSSHConnectionRefusedError)
+	if failed {
+		return s.recordActionFailure(infrav1.ProvisioningError, err.Error())
+	}
+	if err != nil {
+		return actionError{err: fmt.Errorf(errMsgFailedHandlingIncompleteBoot, err)}
+	}
+	return actionContinue{delay: 10 * time.Second}
+}
+
 func validateRootDevices(rootDeviceHints *infrav1.RootDeviceHints, storageDevices []infrav1.Storage) error {
 	for _, wwn := range rootDeviceHints.ListOfWWN() {
 		foundWWN := false
@@ -897,6 +916,86 @@ func handleSSHError(out sshclient.Output) error {
 	return nil
 }
 
+// rescueSSHInput returns the input of SSH clients for the rescue system. The recorded host key of the rescue system
+// is verified. If there is none, the host key is recorded on first use.
+func (s *Service) rescueSSHInput() sshclient.Input {
+	host := s.scope.HetznerBareMetalHost
+	return sshclient.Input{
+		PrivateKey: sshclient.CredentialsFromSecret(s.scope.RescueSSHSecret, s.scope.HetznerCluster.Spec.SSHKeys.RobotRescueSecretRef).PrivateKey,
+		Port:       rescuePort,
+		IP:         host.Spec.Status.GetIPAddress(),
+		HostKeys:   hostKeys(host.Spec.Status.SSHStatus.RescueHostKey),
+		TrustHostKey: func(hostKey string) {
+			s.trustHostKey(&host.Spec.Status.SSHStatus.RescueHostKey, hostKey)
+		},
+		Bastion: s.sshBastion(),
+	}
+}
+
+// osSSHInput returns the input of SSH clients for the installed operating system. The host key of the operating
+// system is verified. If there is none, e.g. because the host has been provisioned without a generated host key,
+// the host key is recorded on first use.
+func (s *Service) osSSHInput(port int) sshclient.Input {
+	host := s.scope.HetznerBareMetalHost
+	return sshclient.Input{
+		PrivateKey: sshclient.CredentialsFromSecret(s.scope.OSSSHSecret, host.Spec.Status.SSHSpec.SecretRef).PrivateKey,
+		Port:       port,
+		IP:         host.Spec.Status.GetIPAddress(),
+		HostKeys:   hostKeys(host.Spec.Status.SSHStatus.OSHostKey),
+		TrustHostKey: func(hostKey string) {
+			s.trustHostKey(&host.Spec.Status.SSHStatus.OSHostKey, hostKey)
+		},
+		Bastion: s.sshBastion(),
+	}
+}
+
+// sshBastion returns the bastion host that SSH connections to the host are tunneled through or nil.
+func (s *Service) sshBastion() *sshclient.Bastion {
+	bastion := s.scope.SSHBastion()
+	if bastion == nil {
+		return nil
+	}
+	return &sshclient.Bastion{
+		Address:    bastion.Address,
+		User:       bastion.User,
+		PrivateKey: sshclient.CredentialsFromSecret(s.scope.SSHBastionSecret, bastion.SecretRef).PrivateKey,
+		HostKey:    bastion.HostKey,
+	}
+}
+
+func (s *Service) trustHostKey(recordedHostKey *string, hostKey string) {
+	*recordedHostKey = hostKey
+	s.scope.Info("Recorded SSH host key on first use", "hostKey", hostKey)
+	conditions.MarkTrue(s.scope.HetznerBareMetalHost, infrav1.SSHHostKeyVerifiedCondition)
+}
+
+func hostKeys(hostKey string) []string {
+	if hostKey == "" {
+		return nil
+	}
+	return []string{hostKey}
+}
+
+// handleHostKeyMismatch marks the host as failed if the SSH host key of the host did not match the recorded one.
+// Nothing is sent to the host until the recorded host key is removed or the host is deprovisioned.
+func (s *Service) handleHostKeyMismatch(actResult actionResult) actionResult {
+	actErr, ok := actResult.(actionError)
+	if !ok || !sshclient.IsHostKeyMismatchError(actErr.err) {
+		return actResult
+	}
+
+	msg := actErr.err.Error()
+	conditions.MarkFalse(
+		s.scope.HetznerBareMetalHost,
+		infrav1.SSHHostKeyVerifiedCondition,
+		infrav1.SSHHostKeyMismatchReason,
+		clusterv1.ConditionSeverityError,
+		msg,
+	)
+	record.Warnf(s.scope.HetznerBareMetalHost, "SSHHostKeyMismatch", msg)
+	return s.recordActionFailure(infrav1.FatalError, msg)
+}
+
 func validateStdOut(stdOut string) (string, error) {
 	stdOut = trimLineBreak(stdOut)
 	if stdOut == "" {
@@ -906,13 +1005,7 @@ func validateStdOut(stdOut string) (string, error) {
 }
 
 func (s *Service) actionImageInstalling() actionResult {
-	creds := sshclient.CredentialsFromSecret(s.scope.RescueSSHSecret, s.scope.HetznerCluster.Spec.SSHKeys.RobotRescueSecretRef)
-	in := sshclient.Input{
-		PrivateKey: creds.PrivateKey,
-		Port:       rescuePort,
-		IP:         s.scope.HetznerBareMetalHost.Spec.Status.GetIPAddress(),
-	}
-	sshClient := s.scope.SSHClientFactory.NewClient(in)
+	sshClient := s.scope.SSHClientFactory.NewClient(s.rescueSSHInput())
 
 	// Ensure os ssh secret
 	sshKey, actResult := s.ensureSSHKey(s.scope.HetznerBareMetalHost.Spec.Status.SSHSpec.SecretRef, s.scope.OSSSHSecret)
@@ -927,26 +1020,55 @@ func (s *Service) actionImageInstalling() actionResult {
 		return actionRes
 	}
 
+	var unlock *diskUnlockInput
+	if encryption := s.scope.HetznerBareMetalHost.Spec.Status.InstallImage.Encryption; encryption != nil {
+		passphrase, err := s.encryptionPassphrase()
+		if err != nil {
+			return s.recordActionFailure(infrav1.ProvisioningError, err.Error())
+		}
+		autoSetupInput.cryptPassword = passphrase
+
+		unlock = &diskUnlockInput{
+			authorizedKey: sshclient.CredentialsFromSecret(s.scope.OSSSHSecret, s.scope.HetznerBareMetalHost.Spec.Status.SSHSpec.SecretRef).PublicKey,
+			dropbearPort:  encryption.GetDropbearPort(),
+		}
+		if encryption.Unlock == infrav1.DiskUnlockMethodTPM {
+			unlock.tpmPassphrase = passphrase
+		}
+	}
+
 	autoSetup := buildAutoSetup(s.scope.HetznerBareMetalHost.Spec.Status.InstallImage, autoSetupInput)
 
 	if err := handleSSHError(sshClient.CreateAutoSetup(autoSetup)); err != nil {
+		if unlock != nil {
+			// the autosetup contains the passphrase
+			return actionError{err: fmt.Errorf("failed to create autosetup: %w", err)}
+		}
 		return actionError{err: fmt.Errorf("failed to create autosetup %s: %w", autoSetup, err)}
 	}
 
+	// generate the host key of the OS, so that it can be verified from the first boot on
+	hostPrivateKey, hostPublicKey, err := sshclient.GenerateHostKey()
+	if err != nil {
+		return actionError{err: fmt.Errorf("failed to generate ssh host key: %w", err)}
+	}
+
 	// create post install script
-	postInstallScript := s.scope.HetznerBareMetalHost.Spec.Status.InstallImage.PostInstallScript
+	userPostInstallScript := s.scope.HetznerBareMetalHost.Spec.Status.InstallImage.PostInstallScript
+	postInstallScript := buildPostInstallScript(hostPrivateKey, hostPublicKey, unlock, userPostInstallScript)
 
-	if postInstallScript != "" {
-		if err := handleSSHError(sshClient.CreatePostInstallScript(postInstallScript)); err != nil {
-			return actionError{err: fmt.Errorf("failed to create post install script %s: %w", postInstallScript, err)}
-		}
+	if err := handleSSHError(sshClient.CreatePostInstallScript(postInstallScript)); err != nil {
+		return actionError{err: fmt.Errorf("failed to create post install script %s: %w", userPostInstallScript, err)}
 	}
 
 	// Execute install image
-	if err := handleSSHError(sshClient.ExecuteInstallImage(postInstallScript != "")); err != nil {
+	if err := handleSSHError(sshClient.ExecuteInstallImage(true)); err != nil {
 		return actionError{err: fmt.Errorf("failed to execute installimage: %w", err)}
 	}
 
+	s.scope.HetznerBareMetalHost.Spec.Status.SSHStatus.OSHostKey = hostPublicKey
+	conditions.MarkTrue(s.scope.HetznerBareMetalHost, infrav1.SSHHostKeyVerifiedCondition)
+
 	// Update name in robot API
 	if _, err := s.scope.RobotClient.SetBMServerName(s.scope.HetznerBareMetalHost.Spec.ServerID, autoSetupInput.hostName); err != nil {
 		s.handleRobotRateLimitExceeded(err, "SetBMServerName")
@@ -1027,29 +1149,35 @@ func (s *Service) actionProvisioning() actionResult {
 	host := s.scope.HetznerBareMetalHost
 
 	portAfterInstallImage := host.Spec.Status.SSHSpec.PortAfterInstallImage
-	privateKey := sshclient.CredentialsFromSecret(s.scope.OSSSHSecret, host.Spec.Status.SSHSpec.SecretRef).PrivateKey
-	sshClient := s.scope.SSHClientFactory.NewClient(sshclient.Input{
-		PrivateKey: privateKey,
-		Port:       portAfterInstallImage,
-		IP:         host.Spec.Status.GetIPAddress(),
-	})
+	hasOSHostKey := host.Spec.Status.SSHStatus.OSHostKey != ""
+	in := s.osSSHInput(portAfterInstallImage)
+	if hasOSHostKey {
+		// the host might still run the rescue system, which is detected by its hostname
+		in.HostKeys = append(in.HostKeys, hostKeys(host.Spec.Status.SSHStatus.RescueHostKey)...)
+	}
+	sshClient := s.scope.SSHClientFactory.NewClient(in)
 
 	// check hostname with sshClient
 	wantHostName := infrav1.BareMetalHostNamePrefix + host.Spec.ConsumerRef.Name
 
 	out := sshClient.GetHostName()
 	if trimLineBreak(out.StdOut) != wantHostName {
+		// the host key is trusted on first use only if it belongs to the installed operating system
+		if !hasOSHostKey {
+			host.Spec.Status.SSHStatus.OSHostKey = ""
+		}
+
 		// give the reboot some time until it takes effect
 		if s.hasJustRebooted() {
 			return actionContinue{delay: 2 * time.Second}
 		}
 
-		privateKeyRescue := sshclient.CredentialsFromSecret(s.scope.RescueSSHSecret, s.scope.HetznerCluster.Spec.SSHKeys.RobotRescueSecretRef).PrivateKey
-		rescueSSHClient := s.scope.SSHClientFactory.NewClient(sshclient.Input{
-			PrivateKey: privateKeyRescue,
-			Port:       rescuePort,
-			IP:         host.Spec.Status.GetIPAddress(),
-		})
+		// the host might wait in the initramfs for its partitions to be unlocked, which is no incomplete boot
+		if actResult := s.unlockDisks(); actResult != nil {
+			return actResult
+		}
+
+		rescueSSHClient := s.scope.SSHClientFactory.NewClient(s.rescueSSHInput())
 
 		isSSHTimeoutError, isSSHConnectionRefusedError, err := analyzeSSHOutputInstallImage(out, rescueSSHClient, portAfterInstallImage)
 		if err != nil {
@@ -1065,6 +1193,11 @@ func (s *Service) actionProvisioning() actionResult {
 		return actionContinue{delay: 10 * time.Second}
 	}
 
+	// the server has to be part of the vSwitch before cloud-init configures the VLAN interface
+	if actResult := s.ensureRobotVSwitch(); actResult != nil {
+		return actResult
+	}
+
 	// we are in correct boot and can start provisioning
 	if failedAction := s.provision(sshClient, host.Spec.ConsumerRef.Name); failedAction != nil {
 		return failedAction
@@ -1100,7 +1233,12 @@ func (s *Service) provision(sshClient sshclient.Client, machineName string) acti
 		return actionError{err: fmt.Errorf("failed to create no cloud directory: %w", err)}
 	}
 
-	if err := handleSSHError(sshClient.CreateMetaData(infrav1.BareMetalHostNamePrefix + machineName)); err != nil {
+	var vSwitchVLAN int
+	if vSwitch := s.scope.HetznerBareMetalHost.Spec.Status.RobotVSwitch; vSwitch != nil {
+		vSwitchVLAN = vSwitch.VLAN
+	}
+
+	if err := handleSSHError(sshClient.CreateMetaData(infrav1.BareMetalHostNamePrefix+machineName, vSwitchVLAN)); err != nil {
 		return actionError{err: fmt.Errorf("failed to create meta data: %w", err)}
 	}
 
@@ -1119,6 +1257,84 @@ func (s *Service) provision(sshClient sshclient.Client, machineName string) acti
 	return nil
 }
 
+// ensureRobotVSwitch adds the server to the vSwitch of the cluster, if the cluster has one.
+func (s *Service) ensureRobotVSwitch() actionResult {
+	host := s.scope.HetznerBareMetalHost
+
+	if s.scope.HetznerCluster.Spec.RobotVSwitch == nil {
+		return nil
+	}
+
+	vSwitchStatus := s.scope.HetznerCluster.Status.RobotVSwitch
+	if vSwitchStatus == nil {
+		conditions.MarkFalse(
+			host,
+			infrav1.ProvisionSucceededCondition,
+			infrav1.RobotVSwitchNotReadyReason,
+			clusterv1.ConditionSeverityInfo,
+			"waiting for the vSwitch of the cluster",
+		)
+		return actionContinue{delay: 10 * time.Second}
+	}
+
+	vSwitch, err := s.scope.RobotClient.GetVSwitch(vSwitchStatus.ID)
+	if err != nil {
+		s.handleRobotRateLimitExceeded(err, "GetVSwitch")
+		return actionError{err: fmt.Errorf("failed to get vSwitch %v: %w", vSwitchStatus.ID, err)}
+	}
+
+	if !vSwitch.HasServer(host.Spec.ServerID) {
+		if err := s.scope.RobotClient.AddServerToVSwitch(vSwitchStatus.ID, host.Spec.ServerID); err != nil {
+			s.handleRobotRateLimitExceeded(err, "AddServerToVSwitch")
+			// the vSwitch processes one change at a time
+			if models.IsError(err, robotclient.ErrorCodeVSwitchInProcess) {
+				return actionContinue{delay: 10 * time.Second}
+			}
+			msg := fmt.Sprintf("failed to add server to vSwitch %v: %s", vSwitchStatus.ID, err.Error())
+			conditions.MarkFalse(
+				host,
+				infrav1.ProvisionSucceededCondition,
+				infrav1.RobotVSwitchAttachFailedReason,
+				clusterv1.ConditionSeverityWarning,
+				msg,
+			)
+			record.Warnf(host, "VSwitchAttachFailed", msg)
+			return actionError{err: fmt.Errorf("failed to add server to vSwitch %v: %w", vSwitchStatus.ID, err)}
+		}
+		record.Eventf(host, "AddedToVSwitch", "Added server to vSwitch %q with VLAN %d", vSwitchStatus.Name, vSwitchStatus.VLAN)
+	}
+
+	host.Spec.Status.RobotVSwitch = vSwitchStatus.DeepCopy()
+	return nil
+}
+
+// removeFromRobotVSwitch removes the server from the vSwitch it has been added to during provisioning.
+func (s *Service) removeFromRobotVSwitch() actionResult {
+	host := s.scope.HetznerBareMetalHost
+
+	vSwitchStatus := host.Spec.Status.RobotVSwitch
+	if vSwitchStatus == nil {
+		return nil
+	}
+
+	if err := s.scope.RobotClient.RemoveServerFromVSwitch(vSwitchStatus.ID, host.Spec.ServerID); err != nil {
+		s.handleRobotRateLimitExceeded(err, "RemoveServerFromVSwitch")
+		// the vSwitch processes one change at a time
+		if models.IsError(err, robotclient.ErrorCodeVSwitchInProcess) {
+			return actionContinue{delay: 10 * time.Second}
+		}
+		// the vSwitch or the server in it does not exist anymore
+		if !models.IsError(err, models.ErrorCodeNotFound) {
+			return actionError{err: fmt.Errorf("failed to remove server from vSwitch %v: %w", vSwitchStatus.ID, err)}
+		}
+	} else {
+		record.Eventf(host, "RemovedFromVSwitch", "Removed server from vSwitch %q", vSwitchStatus.Name)
+	}
+
+	host.Spec.Status.RobotVSwitch = nil
+	return nil
+}
+
 func analyzeSSHOutputInstallImage(out sshclient.Output, sshClient sshclient.Client, port int) (isTimeout, isConnectionRefused bool, reterr error) {
 	// check err
 	if out.Err != nil {
@@ -1182,11 +1398,7 @@ func verifyConnectionRefused(sshClient sshclient.Client, port int) bool {
 }
 
 func (s *Service) actionEnsureProvisioned() actionResult {
-	sshClient := s.scope.SSHClientFactory.NewClient(sshclient.Input{
-		PrivateKey: sshclient.CredentialsFromSecret(s.scope.OSSSHSecret, s.scope.HetznerBareMetalHost.Spec.Status.SSHSpec.SecretRef).PrivateKey,
-		Port:       s.scope.HetznerBareMetalHost.Spec.Status.SSHSpec.PortAfterCloudInit,
-		IP:         s.scope.HetznerBareMetalHost.Spec.Status.GetIPAddress(),
-	})
+	sshClient := s.scope.SSHClientFactory.NewClient(s.osSSHInput(s.scope.HetznerBareMetalHost.Spec.Status.SSHSpec.PortAfterCloudInit))
 
 	// Check hostname with sshClient
 	wantHostName := infrav1.BareMetalHostNamePrefix + s.scope.HetznerBareMetalHost.Spec.ConsumerRef.Name
@@ -1198,6 +1410,10 @@ func (s *Service) actionEnsureProvisioned() actionResult {
 			return actionContinue{delay: 2 * time.Second}
 		}
 
+		if actResult := s.unlockDisks(); actResult != nil {
+			return actResult
+		}
+
 		isTimeout, isSSHConnectionRefusedError, err := analyzeSSHOutputProvisioned(out)
 		if err != nil {
 			return actionError{err: fmt.Errorf("failed to handle incomplete boot - provisioning: %w", err)}
@@ -1246,11 +1462,7 @@ func (s *Service) handleConnectionRefused() actionResult {
 	if s.scope.HetznerBareMetalHost.Spec.Status.SSHSpec.PortAfterInstallImage == s.scope.HetznerBareMetalHost.Spec.Status.SSHSpec.PortAfterCloudInit {
 		return nil
 	}
-	oldSSHClient := s.scope.SSHClientFactory.NewClient(sshclient.Input{
-		PrivateKey: sshclient.CredentialsFromSecret(s.scope.OSSSHSecret, s.scope.HetznerBareMetalHost.Spec.Status.SSHSpec.SecretRef).PrivateKey,
-		Port:       s.scope.HetznerBareMetalHost.Spec.Status.SSHSpec.PortAfterInstallImage,
-		IP:         s.scope.HetznerBareMetalHost.Spec.Status.GetIPAddress(),
-	})
+	oldSSHClient := s.scope.SSHClientFactory.NewClient(s.osSSHInput(s.scope.HetznerBareMetalHost.Spec.Status.SSHSpec.PortAfterInstallImage))
 	actResult, err := s.checkCloudInitStatus(oldSSHClient)
 	// If this ssh client also gives an error, then we go back to analyzing the error of the first ssh call
 	// This happens in the statement below this one.
@@ -1309,11 +1521,7 @@ func (s *Service) checkCloudInitStatus(sshClient sshclient.Client) (actionResult
 
 func (s *Service) handleCloudInitNotStarted() actionResult {
 	// Check whether cloud init really was successfully. Sigterm causes problems there.
-	oldSSHClient := s.scope.SSHClientFactory.NewClient(sshclient.Input{
-		PrivateKey: sshclient.CredentialsFromSecret(s.scope.OSSSHSecret, s.scope.HetznerBareMetalHost.Spec.Status.SSHSpec.SecretRef).PrivateKey,
-		Port:       s.scope.HetznerBareMetalHost.Spec.Status.SSHSpec.PortAfterInstallImage,
-		IP:         s.scope.HetznerBareMetalHost.Spec.Status.GetIPAddress(),
-	})
+	oldSSHClient := s.scope.SSHClientFactory.NewClient(s.osSSHInput(s.scope.HetznerBareMetalHost.Spec.Status.SSHSpec.PortAfterInstallImage))
 	out := oldSSHClient.CheckCloudInitLogsForSigTerm()
 	if err := handleSSHError(out); err != nil {
 		return actionError{err: fmt.Errorf("failed to CheckCloudInitLogsForSigTerm: %w", err)}
@@ -1381,13 +1589,7 @@ func (s *Service) actionProvisioned() actionResult {
 
 	rebootDesired := s.scope.HetznerBareMetalHost.HasRebootAnnotation()
 	isRebooted := s.scope.HetznerBareMetalHost.Spec.Status.Rebooted
-	creds := sshclient.CredentialsFromSecret(s.scope.OSSSHSecret, s.scope.HetznerBareMetalHost.Spec.Status.SSHSpec.SecretRef)
-	in := sshclient.Input{
-		PrivateKey: creds.PrivateKey,
-		Port:       s.scope.HetznerBareMetalHost.Spec.Status.SSHSpec.PortAfterCloudInit,
-		IP:         s.scope.HetznerBareMetalHost.Spec.Status.GetIPAddress(),
-	}
-	sshClient := s.scope.SSHClientFactory.NewClient(in)
+	sshClient := s.scope.SSHClientFactory.NewClient(s.osSSHInput(s.scope.HetznerBareMetalHost.Spec.Status.SSHSpec.PortAfterCloudInit))
 
 	if rebootDesired {
 		if isRebooted {
@@ -1403,6 +1605,9 @@ func (s *Service) actionProvisioned() actionResult {
 				return actionComplete{}
 			}
 			// Reboot has been ongoing
+			if actResult := s.unlockDisks(); actResult != nil {
+				return actResult
+			}
 			isTimeout, isSSHConnectionRefusedError, err := analyzeSSHOutputProvisioned(out)
 			if err != nil {
 				return actionError{err: fmt.Errorf("failed to handle incomplete boot - provisioning: %w", err)}
@@ -1428,6 +1633,53 @@ func (s *Service) actionProvisioned() actionResult {
 	return actionComplete{}
 }
 
+func (s *Service) actionReprovisioning() actionResult {
+	host := s.scope.HetznerBareMetalHost
+
+	// the next boot has to end up in the rescue system, so that installimage can be run again
+	if err := s.enforceRescueMode(); err != nil {
+		return actionError{err: fmt.Errorf("failed to enforce rescue mode: %w", err)}
+	}
+
+	sshClient := s.scope.SSHClientFactory.NewClient(s.osSSHInput(host.Spec.Status.SSHSpec.PortAfterCloudInit))
+
+	// the OS of an unhealthy host is often not reachable anymore, so we fall back to an API reboot
+	if err := s.rebootViaSSHOrAPI(sshClient); err != nil {
+		return actionError{err: err}
+	}
+
+	// a pending reboot is superseded by reprovisioning
+	host.Spec.Status.Rebooted = false
+	host.ClearRebootAnnotations()
+	host.ClearReprovisionAnnotation()
+
+	record.Event(host, "ReprovisioningHost", "Rebooted into rescue system to install the image again")
+	return actionComplete{}
+}
+
+// rebootViaSSHOrAPI reboots the server via ssh if it is reachable and via API otherwise. The reboot is tracked in
+// the error type of the host, so that it can be escalated if the server does not come up. sshClient might be nil if
+// the server cannot be reached via ssh.
+func (s *Service) rebootViaSSHOrAPI(sshClient sshclient.Client) error {
+	host := s.scope.HetznerBareMetalHost
+
+	if sshClient != nil && trimLineBreak(sshClient.GetHostName().StdOut) != "" {
+		if err := handleSSHError(sshClient.Reboot()); err != nil {
+			return fmt.Errorf("failed to reboot server via ssh: %w", err)
+		}
+		host.SetError(infrav1.ErrorTypeSSHRebootTriggered, "ssh reboot triggered")
+		return nil
+	}
+
+	rebootType, errorType := rebootAndErrorTypeAfterTimeout(host)
+	if _, err := s.scope.RobotClient.RebootBMServer(host.Spec.ServerID, rebootType); err != nil {
+		s.handleRobotRateLimitExceeded(err, rebootServerStr)
+		return fmt.Errorf(errMsgFailedReboot, err)
+	}
+	host.SetError(errorType, "software/hardware reboot triggered")
+	return nil
+}
+
 func (s *Service) actionDeprovisioning() actionResult {
 	// Update name in robot API
 	if _, err := s.scope.RobotClient.SetBMServerName(
@@ -1438,13 +1690,13 @@ func (s *Service) actionDeprovisioning() actionResult {
 		return actionError{err: fmt.Errorf("failed to update name of host in robot API: %w", err)}
 	}
 
+	if actResult := s.removeFromRobotVSwitch(); actResult != nil {
+		return actResult
+	}
+
 	// If has been provisioned completely, stop all running pods
 	if s.scope.OSSSHSecret != nil {
-		sshClient := s.scope.SSHClientFactory.NewClient(sshclient.Input{
-			PrivateKey: sshclient.CredentialsFromSecret(s.scope.OSSSHSecret, s.scope.HetznerBareMetalHost.Spec.Status.SSHSpec.SecretRef).PrivateKey,
-			Port:       s.scope.HetznerBareMetalHost.Spec.Status.SSHSpec.PortAfterCloudInit,
-			IP:         s.scope.HetznerBareMetalHost.Spec.Status.GetIPAddress(),
-		})
+		sshClient := s.scope.SSHClientFactory.NewClient(s.osSSHInput(s.scope.HetznerBareMetalHost.Spec.Status.SSHSpec.PortAfterCloudInit))
 		out := sshClient.ResetKubeadm()
 		s.scope.V(1).Info("Output of ResetKubeadm", "stdout", out.StdOut, "stderr", out.StdErr, "err", out.Err)
 		if out.Err != nil {
@@ -1467,6 +1719,253 @@ func (s *Service) actionDeprovisioning() actionResult {
 	return actionComplete{}
 }
 
+// actionWipingDisks boots the rescue system and wipes all disks of the host in it. A failed wipe is retried with a
+// fresh rescue system after a backoff.
+func (s *Service) actionWipingDisks() actionResult {
+	host := s.scope.HetznerBareMetalHost
+
+	if !host.Spec.WipeDisks {
+		CancelDiskWipe(host)
+		return actionComplete{}
+	}
+
+	if host.Spec.Status.DiskWipe == nil {
+		host.Spec.Status.DiskWipe = &infrav1.DiskWipeStatus{}
+	}
+
+	// the host stays out of the pool until the disk wipe is cancelled or the host is deleted
+	if DiskWipeFailedPermanently(host) {
+		return actionStop{}
+	}
+
+	switch host.Spec.Status.DiskWipe.Phase {
+	case infrav1.DiskWipePhasePending:
+		return s.rebootIntoRescueForDiskWipe()
+	case infrav1.DiskWipePhaseRebooting:
+		return s.startDiskWipe()
+	case infrav1.DiskWipePhaseWiping:
+		return s.checkDiskWipe()
+	case infrav1.DiskWipePhaseSucceeded:
+		return actionComplete{}
+	}
+	return actionError{err: fmt.Errorf("%w: %q", errUnknownDiskWipePhase, host.Spec.Status.DiskWipe.Phase)}
+}
+
+func (s *Service) rebootIntoRescueForDiskWipe() actionResult {
+	host := s.scope.HetznerBareMetalHost
+
+	// After a failed attempt the host is still in the rescue system. Otherwise, the OS might still be reachable.
+	// The input has to be built before the rescue system is enforced, as that resets the recorded host key.
+	var sshClient sshclient.Client
+	switch {
+	case host.Spec.Status.DiskWipe.FailedAttempts > 0:
+		sshClient = s.scope.SSHClientFactory.NewClient(s.rescueSSHInput())
+	case s.scope.OSSSHSecret != nil && host.Spec.Status.SSHSpec != nil:
+		sshClient = s.scope.SSHClientFactory.NewClient(s.osSSHInput(host.Spec.Status.SSHSpec.PortAfterCloudInit))
+	}
+
+	// every attempt boots a fresh rescue system
+	if err := s.enforceRescueMode(); err != nil {
+		return actionError{err: fmt.Errorf("failed to enforce rescue mode: %w", err)}
+	}
+
+	if err := s.rebootViaSSHOrAPI(sshClient); err != nil {
+		return actionError{err: err}
+	}
+
+	host.Spec.Status.DiskWipe.Phase = infrav1.DiskWipePhaseRebooting
+	return actionContinue{delay: 10 * time.Second}
+}
+
+func (s *Service) startDiskWipe() actionResult {
+	host := s.scope.HetznerBareMetalHost
+
+	hasRescueHostKey := host.Spec.Status.SSHStatus.RescueHostKey != ""
+	sshClient := s.scope.SSHClientFactory.NewClient(s.rescueSSHInput())
+
+	out := sshClient.GetHostName()
+	if trimLineBreak(out.StdOut) != rescue {
+		return s.handleRescueNotBooted(out, hasRescueHostKey, "wiping disks")
+	}
+	host.ClearError()
+
+	// the device names might differ from the ones during registration, so the disks are read again
+	storage, err := obtainHardwareDetailsStorage(sshClient)
+	if err != nil {
+		return actionError{err: fmt.Errorf("failed to obtain hardware details storage: %w", err)}
+	}
+
+	if host.Spec.Status.HardwareDetails != nil {
+		for _, known := range host.Spec.Status.HardwareDetails.Storage {
+			if known.WWN != "" && !slices.ContainsFunc(storage, func(st infrav1.Storage) bool { return st.WWN == known.WWN }) {
+				return s.handleDiskWipeFailed(fmt.Sprintf("disk %s with wwn %s has not been found", known.Name, known.WWN))
+			}
+		}
+	}
+
+	disks := make([]infrav1.WipedDisk, 0, len(storage))
+	names := make([]string, 0, len(storage))
+	for _, st := range storage {
+		disks = append(disks, infrav1.WipedDisk{Name: st.Name, WWN: st.WWN, State: infrav1.DiskWipeStatePending})
+		names = append(names, st.Name)
+	}
+
+	if err := handleSSHError(sshClient.StartDiskWipe(names)); err != nil {
+		return actionError{err: fmt.Errorf("failed to start disk wipe: %w", err)}
+	}
+
+	now := metav1.Now()
+	host.Spec.Status.DiskWipe.Phase = infrav1.DiskWipePhaseWiping
+	host.Spec.Status.DiskWipe.StartedAt = &now
+	host.Spec.Status.DiskWipe.Disks = disks
+
+	record.Eventf(host, "WipingDisks", "Started to wipe disks %s", strings.Join(names, ", "))
+	return actionContinue{delay: 30 * time.Second}
+}
+
+func (s *Service) checkDiskWipe() actionResult {
+	host := s.scope.HetznerBareMetalHost
+	diskWipe := host.Spec.Status.DiskWipe
+
+	sshClient := s.scope.SSHClientFactory.NewClient(s.rescueSSHInput())
+	out := sshClient.GetDiskWipeStatus()
+	if out.Err != nil {
+		return actionError{err: fmt.Errorf("failed to get disk wipe status: %w", out.Err)}
+	}
+
+	states, active := parseDiskWipeStatus(out.StdOut)
+
+	var notWiped []string
+	for i := range diskWipe.Disks {
+		if state, found := states[diskWipe.Disks[i].Name]; found {
+			diskWipe.Disks[i].State = state.State
+			diskWipe.Disks[i].Method = state.Method
+		}
+		if diskWipe.Disks[i].State != infrav1.DiskWipeStateWiped {
+			notWiped = append(notWiped, diskWipe.Disks[i].Name)
+		}
+	}
+
+	if len(notWiped) == 0 {
+		now := metav1.Now()
+		diskWipe.Phase = infrav1.DiskWipePhaseSucceeded
+		diskWipe.FinishedAt = &now
+		conditions.MarkTrue(host, infrav1.DiskWipeSucceededCondition)
+		record.Event(host, "WipedDisks", "Wiped all disks of the host")
+		return actionComplete{}
+	}
+
+	// the script might not show up right after it has been started
+	if active || (diskWipe.StartedAt != nil && !hasTimedOut(diskWipe.StartedAt, time.Minute)) {
+		return actionContinue{delay: 30 * time.Second}
+	}
+
+	return s.handleDiskWipeFailed(fmt.Sprintf("failed to wipe disks %s", strings.Join(notWiped, ", ")))
+}
+
+// handleDiskWipeFailed records a failed attempt. The next attempt reboots into a fresh rescue system after a backoff.
+// After too many attempts, the host gives up with a permanent error.
+func (s *Service) handleDiskWipeFailed(msg string) actionResult {
+	host := s.scope.HetznerBareMetalHost
+
+	host.Spec.Status.DiskWipe.FailedAttempts++
+	host.Spec.Status.DiskWipe.Phase = infrav1.DiskWipePhasePending
+
+	if DiskWipeFailedPermanently(host) {
+		errMsg := fmt.Sprintf("failed to wipe disks in %d attempts: %s", host.Spec.Status.DiskWipe.FailedAttempts, msg)
+		conditions.MarkFalse(
+			host,
+			infrav1.DiskWipeSucceededCondition,
+			infrav1.DiskWipeFailedReason,
+			clusterv1.ConditionSeverityError,
+			errMsg,
+		)
+		record.Warnf(host, "DiskWipeFailedPermanently", errMsg)
+		host.SetError(infrav1.PermanentError, errMsg)
+		return actionStop{}
+	}
+
+	conditions.MarkFalse(
+		host,
+		infrav1.DiskWipeSucceededCondition,
+		infrav1.DiskWipeFailedReason,
+		clusterv1.ConditionSeverityWarning,
+		msg,
+	)
+	record.Warnf(host, "DiskWipeFailed", "Attempt %d: %s", host.Spec.Status.DiskWipe.FailedAttempts, msg)
+	return actionFailed{errorCount: host.Spec.Status.DiskWipe.FailedAttempts}
+}
+
+// DiskWipeFailedPermanently returns whether the host has given up to wipe its disks.
+func DiskWipeFailedPermanently(host *infrav1.HetznerBareMetalHost) bool {
+	return host.Spec.Status.DiskWipe != nil && host.Spec.Status.DiskWipe.FailedAttempts >= maxDiskWipeAttempts
+}
+
+// CancelDiskWipe resets the disk wipe of a host whose wipe has been disabled, including a permanent error of a wipe
+// that has been given up.
+func CancelDiskWipe(host *infrav1.HetznerBareMetalHost) {
+	if DiskWipeFailedPermanently(host) && host.Spec.Status.ErrorType == infrav1.PermanentError {
+		host.ClearError()
+	}
+	conditions.Delete(host, infrav1.DiskWipeSucceededCondition)
+	record.Warnf(host, "DiskWipeCancelled", "Disk wipe has been disabled - the disks of the host might not have been wiped")
+}
+
+// encryptionPassphrase returns the passphrase of the encrypted partitions of the host. installimage reads it from a
+// single line of the autosetup, so it must not contain whitespace.
+func (s *Service) encryptionPassphrase() (string, error) {
+	secretRef := s.scope.HetznerBareMetalHost.Spec.Status.InstallImage.Encryption.SecretRef
+	if s.scope.EncryptionSecret == nil {
+		return "", fmt.Errorf("%w: secret %s", errMissingEncryptionPassphrase, secretRef.Name)
+	}
+	passphrase := string(s.scope.EncryptionSecret.Data[secretRef.GetKey()])
+	if passphrase == "" {
+		return "", fmt.Errorf("%w: key %q of secret %s", errMissingEncryptionPassphrase, secretRef.GetKey(), secretRef.Name)
+	}
+	if strings.ContainsAny(passphrase, " \t\r\n") {
+		return "", fmt.Errorf("%w: key %q of secret %s contains whitespace", errInvalidEncryptionPassphrase, secretRef.GetKey(), secretRef.Name)
+	}
+	return passphrase, nil
+}
+
+// unlockDisks unlocks the encrypted partitions of the host through the SSH server in the initramfs. It returns nil
+// if the partitions are not encrypted or the host does not wait in the initramfs, so that the boot is handled as
+// usual. Once the partitions have been unlocked, the boot is tracked as a reboot that has just been triggered.
+func (s *Service) unlockDisks() actionResult {
+	host := s.scope.HetznerBareMetalHost
+	if host.Spec.Status.InstallImage == nil || host.Spec.Status.InstallImage.Encryption == nil {
+		return nil
+	}
+
+	passphrase, err := s.encryptionPassphrase()
+	if err != nil {
+		return s.recordActionFailure(infrav1.ProvisioningError, err.Error())
+	}
+
+	// the SSH server in the initramfs presents the host key of the OS
+	sshClient := s.scope.SSHClientFactory.NewClient(s.osSSHInput(host.Spec.Status.InstallImage.Encryption.GetDropbearPort()))
+	out := sshClient.UnlockDisks(passphrase)
+	if out.Err != nil {
+		if os.IsTimeout(out.Err) || sshclient.IsTimeoutError(out.Err) || sshclient.IsConnectionRefusedError(out.Err) {
+			// the host does not wait in the initramfs
+			return nil
+		}
+		if sshclient.IsBastionError(out.Err) || sshclient.IsHostKeyMismatchError(out.Err) {
+			return actionError{err: fmt.Errorf("failed to unlock disks: %w", out.Err)}
+		}
+		msg := fmt.Sprintf("failed to unlock disks: %s", out.Err.Error())
+		if out.StdErr != "" {
+			msg = fmt.Sprintf("%s: %s", msg, trimLineBreak(out.StdErr))
+		}
+		record.Warnf(host, "DiskUnlockFailed", msg)
+		return s.recordActionFailure(infrav1.ProvisioningError, msg)
+	}
+
+	host.SetError(infrav1.ErrorTypeDisksUnlocked, "disks unlocked - waiting for the operating system to boot")
+	record.Event(host, "UnlockedDisks", "Unlocked the encrypted partitions in the initramfs")
+	return actionContinue{delay: 10 * time.Second}
+}
+
 func (s *Service) actionDeleting() actionResult {
 	s.scope.HetznerBareMetalHost.Finalizers = utils.FilterStringFromList(s.scope.HetznerBareMetalHost.Finalizers, infrav1.BareMetalHostFinalizer)
 	return deleteComplete{}
@@ -1489,6 +1988,7 @@ func (s *Service) handleRobotRateLimitExceeded(err error, functionName string) {
 func (s *Service) hasJustRebooted() bool {
 	return (s.scope.HetznerBareMetalHost.Spec.Status.ErrorType == infrav1.ErrorTypeSSHRebootTriggered ||
 		s.scope.HetznerBareMetalHost.Spec.Status.ErrorType == infrav1.ErrorTypeSoftwareRebootTriggered ||
-		s.scope.HetznerBareMetalHost.Spec.Status.ErrorType == infrav1.ErrorTypeHardwareRebootTriggered) &&
+		s.scope.HetznerBareMetalHost.Spec.Status.ErrorType == infrav1.ErrorTypeHardwareRebootTriggered ||
+		s.scope.HetznerBareMetalHost.Spec.Status.ErrorType == infrav1.ErrorTypeDisksUnlocked) &&
 		!hasTimedOut(s.scope.HetznerBareMetalHost.Spec.Status.LastUpdated, rebootWaitTime)
 }
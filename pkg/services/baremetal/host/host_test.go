@@ -24,13 +24,17 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
 	"github.com/syself/hrobot-go/models"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 
 	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
 	bmmock "github.com/syself/cluster-api-provider-hetzner/pkg/services/baremetal/client/mocks"
 	robotmock "github.com/syself/cluster-api-provider-hetzner/pkg/services/baremetal/client/mocks/robot"
 	sshmock "github.com/syself/cluster-api-provider-hetzner/pkg/services/baremetal/client/mocks/ssh"
+	robotclient "github.com/syself/cluster-api-provider-hetzner/pkg/services/baremetal/client/robot"
 	sshclient "github.com/syself/cluster-api-provider-hetzner/pkg/services/baremetal/client/ssh"
 	"github.com/syself/cluster-api-provider-hetzner/test/helpers"
 )
@@ -1708,3 +1712,519 @@ var _ = Describe("actionProvisioned", func() {
 		}),
 	)
 })
+
+var _ = Describe("actionReprovisioning", func() {
+	type testCaseActionReprovisioning struct {
+		hostNameOutput      sshclient.Output
+		expectSSHReboot     bool
+		expectedHostErrType infrav1.ErrorType
+	}
+
+	DescribeTable("actionReprovisioning",
+		func(tc testCaseActionReprovisioning) {
+			host := helpers.BareMetalHost(
+				"test-host",
+				"default",
+				helpers.WithRebootTypes([]infrav1.RebootType{
+					infrav1.RebootTypeSoftware,
+					infrav1.RebootTypeHardware,
+				}),
+				helpers.WithSSHSpecInclPorts(23, 24),
+				helpers.WithSSHStatus(),
+				helpers.WithIPv4(),
+				helpers.WithConsumerRef(),
+			)
+			host.SetAnnotations(map[string]string{
+				infrav1.ReprovisionAnnotation: "",
+				infrav1.RebootAnnotation:      "reboot",
+			})
+			host.Spec.Status.Rebooted = true
+			host.Spec.Status.SSHStatus.RescueHostKey = "ssh-ed25519 AAAA"
+
+			robotMock := robotmock.Client{}
+			robotMock.On("DeleteBootRescue", mock.Anything).Return(nil, nil)
+			robotMock.On("SetBootRescue", mock.Anything, sshFingerprint).Return(nil, nil)
+			robotMock.On("RebootBMServer", mock.Anything, mock.Anything).Return(nil, nil)
+
+			sshMock := &sshmock.Client{}
+			sshMock.On("GetHostName").Return(tc.hostNameOutput)
+			sshMock.On("Reboot").Return(sshclient.Output{})
+
+			service := newTestService(host, &robotMock, bmmock.NewSSHFactory(sshMock, sshMock, sshMock), helpers.GetDefaultSSHSecret(osSSHKeyName, "default"), helpers.GetDefaultSSHSecret(rescueSSHKeyName, "default"))
+
+			actResult := service.actionReprovisioning()
+			Expect(actResult).Should(BeAssignableToTypeOf(actionComplete{}))
+
+			Expect(robotMock.AssertCalled(GinkgoT(), "SetBootRescue", mock.Anything, sshFingerprint)).To(BeTrue())
+			if tc.expectSSHReboot {
+				Expect(sshMock.AssertCalled(GinkgoT(), "Reboot")).To(BeTrue())
+				Expect(robotMock.AssertNotCalled(GinkgoT(), "RebootBMServer", mock.Anything, mock.Anything)).To(BeTrue())
+			} else {
+				Expect(sshMock.AssertNotCalled(GinkgoT(), "Reboot")).To(BeTrue())
+				Expect(robotMock.AssertCalled(GinkgoT(), "RebootBMServer", mock.Anything, infrav1.RebootTypeSoftware)).To(BeTrue())
+			}
+
+			Expect(host.Spec.Status.ErrorType).To(Equal(tc.expectedHostErrType))
+			Expect(host.Spec.Status.SSHStatus.RescueHostKey).To(BeEmpty())
+			Expect(host.HasReprovisionAnnotation()).To(BeFalse())
+			Expect(host.HasRebootAnnotation()).To(BeFalse())
+			Expect(host.Spec.Status.Rebooted).To(BeFalse())
+		},
+		Entry("os reachable via ssh", testCaseActionReprovisioning{
+			hostNameOutput:      sshclient.Output{StdOut: infrav1.BareMetalHostNamePrefix + "bm-machine"},
+			expectSSHReboot:     true,
+			expectedHostErrType: infrav1.ErrorTypeSSHRebootTriggered,
+		}),
+		Entry("os not reachable via ssh", testCaseActionReprovisioning{
+			hostNameOutput:      sshclient.Output{Err: timeout},
+			expectSSHReboot:     false,
+			expectedHostErrType: infrav1.ErrorTypeSoftwareRebootTriggered,
+		}),
+	)
+})
+
+var _ = Describe("actionWipingDisks", func() {
+	const storageStdOut = `NAME="loop0" LABEL="" FSTYPE="ext2" TYPE="loop" HCTL="" MODEL="" VENDOR="" SERIAL="" SIZE="3068773888" WWN="" ROTA="0"
+NAME="nvme2n1" LABEL="" FSTYPE="" TYPE="disk" HCTL="" MODEL="SAMSUNG MZVL22T0HBLB-00B00" VENDOR="" SERIAL="S677NF0R402742" SIZE="2048408248320" WWN="eui.002538b411b2cee8" ROTA="0"
+NAME="sda" LABEL="" FSTYPE="" TYPE="disk" HCTL="0:0:0:0" MODEL="ST4000NM0245" VENDOR="ATA" SERIAL="ZC1B2CDE" SIZE="4000787030016" WWN="0x5000c500b1d2e3f4" ROTA="1"`
+
+	var (
+		host      *infrav1.HetznerBareMetalHost
+		robotMock *robotmock.Client
+		sshMock   *sshmock.Client
+		service   *Service
+	)
+
+	BeforeEach(func() {
+		host = helpers.BareMetalHost(
+			"test-host",
+			"default",
+			helpers.WithRebootTypes([]infrav1.RebootType{
+				infrav1.RebootTypeSoftware,
+				infrav1.RebootTypeHardware,
+			}),
+			helpers.WithSSHSpecInclPorts(23, 24),
+			helpers.WithSSHStatus(),
+			helpers.WithIPv4(),
+			helpers.WithConsumerRef(),
+		)
+		host.Spec.WipeDisks = true
+		host.Spec.Status.ProvisioningState = infrav1.StateWipingDisks
+		host.Spec.Status.DiskWipe = &infrav1.DiskWipeStatus{}
+		host.Spec.Status.HardwareDetails = &infrav1.HardwareDetails{
+			Storage: []infrav1.Storage{
+				{Name: "nvme0n1", WWN: "eui.002538b411b2cee8"},
+				{Name: "sdb", WWN: "0x5000c500b1d2e3f4"},
+			},
+		}
+
+		robotMock = &robotmock.Client{}
+		robotMock.On("DeleteBootRescue", mock.Anything).Return(nil, nil)
+		robotMock.On("SetBootRescue", mock.Anything, sshFingerprint).Return(nil, nil)
+		robotMock.On("RebootBMServer", mock.Anything, mock.Anything).Return(nil, nil)
+
+		sshMock = &sshmock.Client{}
+		sshMock.On("Reboot").Return(sshclient.Output{})
+		sshMock.On("GetHardwareDetailsStorage").Return(sshclient.Output{StdOut: storageStdOut})
+		sshMock.On("StartDiskWipe", mock.Anything).Return(sshclient.Output{})
+
+		service = newTestService(host, robotMock, bmmock.NewSSHFactory(sshMock, sshMock, sshMock),
+			helpers.GetDefaultSSHSecret(osSSHKeyName, "default"), helpers.GetDefaultSSHSecret(rescueSSHKeyName, "default"))
+	})
+
+	It("reboots into the rescue system", func() {
+		sshMock.On("GetHostName").Return(sshclient.Output{StdOut: infrav1.BareMetalHostNamePrefix + "bm-machine"})
+
+		Expect(service.actionWipingDisks()).To(BeAssignableToTypeOf(actionContinue{}))
+		Expect(robotMock.AssertCalled(GinkgoT(), "SetBootRescue", mock.Anything, sshFingerprint)).To(BeTrue())
+		Expect(sshMock.AssertCalled(GinkgoT(), "Reboot")).To(BeTrue())
+		Expect(host.Spec.Status.ErrorType).To(Equal(infrav1.ErrorTypeSSHRebootTriggered))
+		Expect(host.Spec.Status.DiskWipe.Phase).To(Equal(infrav1.DiskWipePhaseRebooting))
+	})
+
+	It("wipes the disks with their names in the rescue system", func() {
+		host.Spec.Status.DiskWipe.Phase = infrav1.DiskWipePhaseRebooting
+		host.SetError(infrav1.ErrorTypeSSHRebootTriggered, "ssh reboot triggered")
+		sshMock.On("GetHostName").Return(sshclient.Output{StdOut: "rescue"})
+
+		Expect(service.actionWipingDisks()).To(BeAssignableToTypeOf(actionContinue{}))
+		Expect(sshMock.AssertCalled(GinkgoT(), "StartDiskWipe", []string{"nvme2n1", "sda"})).To(BeTrue())
+		Expect(host.Spec.Status.ErrorType).To(BeEmpty())
+		Expect(host.Spec.Status.DiskWipe.Phase).To(Equal(infrav1.DiskWipePhaseWiping))
+		Expect(host.Spec.Status.DiskWipe.StartedAt).ToNot(BeNil())
+		Expect(host.Spec.Status.DiskWipe.Disks).To(Equal([]infrav1.WipedDisk{
+			{Name: "nvme2n1", WWN: "eui.002538b411b2cee8", State: infrav1.DiskWipeStatePending},
+			{Name: "sda", WWN: "0x5000c500b1d2e3f4", State: infrav1.DiskWipeStatePending},
+		}))
+	})
+
+	It("fails if a disk of the host is missing", func() {
+		host.Spec.Status.DiskWipe.Phase = infrav1.DiskWipePhaseRebooting
+		host.Spec.Status.HardwareDetails.Storage = append(host.Spec.Status.HardwareDetails.Storage,
+			infrav1.Storage{Name: "sdc", WWN: "0x5000c500b1d2e3f5"})
+		sshMock.On("GetHostName").Return(sshclient.Output{StdOut: "rescue"})
+
+		Expect(service.actionWipingDisks()).To(BeAssignableToTypeOf(actionFailed{}))
+		Expect(sshMock.AssertNotCalled(GinkgoT(), "StartDiskWipe", mock.Anything)).To(BeTrue())
+		Expect(host.Spec.Status.DiskWipe.Phase).To(Equal(infrav1.DiskWipePhasePending))
+		Expect(host.Spec.Status.DiskWipe.FailedAttempts).To(Equal(1))
+		Expect(conditions.IsFalse(host, infrav1.DiskWipeSucceededCondition)).To(BeTrue())
+		Expect(conditions.GetReason(host, infrav1.DiskWipeSucceededCondition)).To(Equal(infrav1.DiskWipeFailedReason))
+	})
+
+	Context("while the disks are wiped", func() {
+		BeforeEach(func() {
+			startedAt := metav1.NewTime(time.Now().Add(-2 * time.Minute))
+			host.Spec.Status.DiskWipe = &infrav1.DiskWipeStatus{
+				Phase:     infrav1.DiskWipePhaseWiping,
+				StartedAt: &startedAt,
+				Disks: []infrav1.WipedDisk{
+					{Name: "nvme2n1", State: infrav1.DiskWipeStatePending},
+					{Name: "sda", State: infrav1.DiskWipeStatePending},
+				},
+			}
+		})
+
+		It("waits while the wipe is running", func() {
+			sshMock.On("GetDiskWipeStatus").Return(sshclient.Output{StdOut: "nvme2n1 wiped nvme-format\nsda running\nactive\n"})
+
+			Expect(service.actionWipingDisks()).To(BeAssignableToTypeOf(actionContinue{}))
+			Expect(host.Spec.Status.DiskWipe.Disks[0]).To(Equal(infrav1.WipedDisk{Name: "nvme2n1", State: infrav1.DiskWipeStateWiped, Method: "nvme-format"}))
+			Expect(host.Spec.Status.DiskWipe.Disks[1].State).To(Equal(infrav1.DiskWipeStateRunning))
+		})
+
+		It("succeeds if all disks have been wiped", func() {
+			sshMock.On("GetDiskWipeStatus").Return(sshclient.Output{StdOut: "nvme2n1 wiped nvme-format\nsda wiped overwrite\n"})
+
+			Expect(service.actionWipingDisks()).To(BeAssignableToTypeOf(actionComplete{}))
+			Expect(host.Spec.Status.DiskWipe.Phase).To(Equal(infrav1.DiskWipePhaseSucceeded))
+			Expect(host.Spec.Status.DiskWipe.FinishedAt).ToNot(BeNil())
+			Expect(host.Spec.Status.DiskWipe.Disks[1].Method).To(Equal("overwrite"))
+			Expect(conditions.IsTrue(host, infrav1.DiskWipeSucceededCondition)).To(BeTrue())
+		})
+
+		It("retries with a fresh rescue system if a disk could not be wiped", func() {
+			sshMock.On("GetDiskWipeStatus").Return(sshclient.Output{StdOut: "nvme2n1 wiped nvme-format\nsda failed\n"})
+
+			Expect(service.actionWipingDisks()).To(BeAssignableToTypeOf(actionFailed{}))
+			Expect(host.Spec.Status.DiskWipe.Phase).To(Equal(infrav1.DiskWipePhasePending))
+			Expect(host.Spec.Status.DiskWipe.FailedAttempts).To(Equal(1))
+			Expect(conditions.GetMessage(host, infrav1.DiskWipeSucceededCondition)).To(ContainSubstring("sda"))
+		})
+
+		It("gives up with a permanent error after the last attempt", func() {
+			host.Spec.Status.DiskWipe.FailedAttempts = maxDiskWipeAttempts - 1
+			sshMock.On("GetDiskWipeStatus").Return(sshclient.Output{StdOut: "nvme2n1 wiped nvme-format\nsda failed\n"})
+
+			Expect(service.actionWipingDisks()).To(BeAssignableToTypeOf(actionStop{}))
+			Expect(host.Spec.Status.DiskWipe.FailedAttempts).To(Equal(maxDiskWipeAttempts))
+			Expect(host.Spec.Status.ErrorType).To(Equal(infrav1.PermanentError))
+			Expect(conditions.GetSeverity(host, infrav1.DiskWipeSucceededCondition)).To(Equal(ptr.To(clusterv1.ConditionSeverityError)))
+		})
+	})
+
+	It("does not retry after it has given up", func() {
+		host.Spec.Status.DiskWipe.FailedAttempts = maxDiskWipeAttempts
+
+		Expect(service.actionWipingDisks()).To(BeAssignableToTypeOf(actionStop{}))
+		Expect(robotMock.AssertNotCalled(GinkgoT(), "SetBootRescue", mock.Anything, mock.Anything)).To(BeTrue())
+	})
+
+	It("stops if wiping disks has been disabled", func() {
+		host.Spec.WipeDisks = false
+
+		Expect(service.actionWipingDisks()).To(BeAssignableToTypeOf(actionComplete{}))
+		Expect(robotMock.AssertNotCalled(GinkgoT(), "SetBootRescue", mock.Anything, mock.Anything)).To(BeTrue())
+		Expect(conditions.Has(host, infrav1.DiskWipeSucceededCondition)).To(BeFalse())
+	})
+
+	It("clears the permanent error of a given up disk wipe if it has been disabled", func() {
+		host.Spec.WipeDisks = false
+		host.Spec.Status.DiskWipe.FailedAttempts = maxDiskWipeAttempts
+		host.SetError(infrav1.PermanentError, "failed to wipe disks")
+
+		Expect(service.actionWipingDisks()).To(BeAssignableToTypeOf(actionComplete{}))
+		Expect(host.Spec.Status.ErrorType).To(BeEmpty())
+	})
+})
+
+var _ = Describe("SSH host keys", func() {
+	var (
+		host    *infrav1.HetznerBareMetalHost
+		service *Service
+	)
+
+	BeforeEach(func() {
+		host = helpers.BareMetalHost(
+			"test-host",
+			"default",
+			helpers.WithSSHSpecInclPorts(23, 24),
+			helpers.WithSSHStatus(),
+			helpers.WithIPv4(),
+		)
+		service = newTestService(host, nil, nil, helpers.GetDefaultSSHSecret(osSSHKeyName, "default"), helpers.GetDefaultSSHSecret(rescueSSHKeyName, "default"))
+	})
+
+	It("records the host key of the operating system on first use", func() {
+		in := service.osSSHInput(24)
+		Expect(in.HostKeys).To(BeEmpty())
+
+		in.TrustHostKey("ssh-ed25519 AAAA")
+		Expect(host.Spec.Status.SSHStatus.OSHostKey).To(Equal("ssh-ed25519 AAAA"))
+		Expect(conditions.IsTrue(host, infrav1.SSHHostKeyVerifiedCondition)).To(BeTrue())
+		Expect(service.osSSHInput(24).HostKeys).To(Equal([]string{"ssh-ed25519 AAAA"}))
+		Expect(service.rescueSSHInput().HostKeys).To(BeEmpty())
+	})
+
+	It("marks the host as failed if the host key does not match", func() {
+		mismatchErr := &sshclient.HostKeyMismatchError{Address: "1.2.3.4:22", Want: []string{"ssh-ed25519 AAAA"}, Got: "ssh-ed25519 BBBB"}
+		actResult := service.handleHostKeyMismatch(actionError{err: fmt.Errorf("failed to get hostname: %w", mismatchErr)})
+
+		Expect(actResult).To(BeAssignableToTypeOf(actionFailed{}))
+		Expect(host.Spec.Status.ErrorType).To(Equal(infrav1.FatalError))
+		Expect(conditions.IsFalse(host, infrav1.SSHHostKeyVerifiedCondition)).To(BeTrue())
+		Expect(conditions.GetReason(host, infrav1.SSHHostKeyVerifiedCondition)).To(Equal(infrav1.SSHHostKeyMismatchReason))
+	})
+
+	It("does not change other results", func() {
+		actResult := service.handleHostKeyMismatch(actionError{err: errTest})
+		Expect(actResult).To(Equal(actionError{err: errTest}))
+		Expect(host.Spec.Status.ErrorType).To(BeEmpty())
+		Expect(conditions.Has(host, infrav1.SSHHostKeyVerifiedCondition)).To(BeFalse())
+	})
+})
+
+var _ = Describe("SSH bastion", func() {
+	var (
+		host    *infrav1.HetznerBareMetalHost
+		service *Service
+	)
+
+	newBastion := func(address string) *infrav1.SSHBastionSpec {
+		return &infrav1.SSHBastionSpec{
+			Address: address,
+			User:    "jump",
+			SecretRef: infrav1.SSHSecretRef{
+				Name: "bastion-ssh",
+				Key:  infrav1.SSHSecretKeyRef{Name: "sshkey-name", PublicKey: "public-key", PrivateKey: "private-key"},
+			},
+			HostKey: "ssh-ed25519 AAAA",
+		}
+	}
+
+	BeforeEach(func() {
+		host = helpers.BareMetalHost(
+			"test-host",
+			"default",
+			helpers.WithSSHSpecInclPorts(23, 24),
+			helpers.WithSSHStatus(),
+			helpers.WithIPv4(),
+		)
+		service = newTestService(host, nil, nil, helpers.GetDefaultSSHSecret(osSSHKeyName, "default"), helpers.GetDefaultSSHSecret(rescueSSHKeyName, "default"))
+		service.scope.SSHBastionSecret = helpers.GetDefaultSSHSecret("bastion-ssh", "default")
+	})
+
+	It("connects directly without bastion host", func() {
+		Expect(service.rescueSSHInput().Bastion).To(BeNil())
+		Expect(service.osSSHInput(24).Bastion).To(BeNil())
+	})
+
+	It("tunnels all connections through the bastion host of the cluster", func() {
+		service.scope.HetznerCluster.Spec.SSHBastion = newBastion("bastion.example.com")
+
+		expectedBastion := &sshclient.Bastion{
+			Address:    "bastion.example.com",
+			User:       "jump",
+			PrivateKey: "bastion-ssh-private-key",
+			HostKey:    "ssh-ed25519 AAAA",
+		}
+		Expect(service.rescueSSHInput().Bastion).To(Equal(expectedBastion))
+		Expect(service.osSSHInput(24).Bastion).To(Equal(expectedBastion))
+	})
+
+	It("prefers the bastion host of the host", func() {
+		service.scope.HetznerCluster.Spec.SSHBastion = newBastion("bastion.example.com")
+		host.Spec.SSHBastion = newBastion("10.0.0.1:2222")
+
+		Expect(service.rescueSSHInput().Bastion.Address).To(Equal("10.0.0.1:2222"))
+		Expect(service.osSSHInput(24).Bastion.Address).To(Equal("10.0.0.1:2222"))
+	})
+})
+
+var _ = Describe("unlockDisks", func() {
+	var (
+		host         *infrav1.HetznerBareMetalHost
+		dropbearMock *sshmock.Client
+		service      *Service
+	)
+
+	BeforeEach(func() {
+		host = helpers.BareMetalHost(
+			"test-host",
+			"default",
+			helpers.WithSSHSpecInclPorts(23, 24),
+			helpers.WithSSHStatus(),
+			helpers.WithIPv4(),
+			helpers.WithConsumerRef(),
+		)
+		host.Spec.Status.InstallImage = &infrav1.InstallImage{
+			Encryption: &infrav1.DiskEncryption{
+				SecretRef: infrav1.EncryptionSecretRef{Name: "encryption"},
+			},
+		}
+
+		// the mock factory returns the client after installimage for the port of dropbear
+		dropbearMock = &sshmock.Client{}
+		service = newTestService(host, nil, bmmock.NewSSHFactory(nil, dropbearMock, nil), helpers.GetDefaultSSHSecret(osSSHKeyName, "default"), nil)
+		service.scope.EncryptionSecret = &corev1.Secret{
+			Data: map[string][]byte{"passphrase": []byte("my-passphrase")},
+		}
+	})
+
+	It("does nothing if the partitions are not encrypted", func() {
+		host.Spec.Status.InstallImage.Encryption = nil
+		Expect(service.unlockDisks()).To(BeNil())
+		Expect(dropbearMock.AssertNotCalled(GinkgoT(), "UnlockDisks", mock.Anything)).To(BeTrue())
+	})
+
+	It("unlocks the partitions and waits for the operating system to boot", func() {
+		dropbearMock.On("UnlockDisks", "my-passphrase").Return(sshclient.Output{})
+
+		Expect(service.unlockDisks()).To(BeAssignableToTypeOf(actionContinue{}))
+		Expect(host.Spec.Status.ErrorType).To(Equal(infrav1.ErrorTypeDisksUnlocked))
+	})
+
+	It("does nothing if the host does not wait in the initramfs", func() {
+		dropbearMock.On("UnlockDisks", "my-passphrase").Return(sshclient.Output{Err: sshclient.ErrConnectionRefused})
+
+		Expect(service.unlockDisks()).To(BeNil())
+		Expect(host.Spec.Status.ErrorType).To(BeEmpty())
+	})
+
+	It("records a failure if the passphrase is wrong", func() {
+		dropbearMock.On("UnlockDisks", "my-passphrase").Return(sshclient.Output{Err: errTest, StdErr: "Error: Bad passphrase"})
+
+		Expect(service.unlockDisks()).To(BeAssignableToTypeOf(actionFailed{}))
+		Expect(host.Spec.Status.ErrorType).To(Equal(infrav1.ProvisioningError))
+		Expect(host.Spec.Status.ErrorMessage).To(ContainSubstring("Bad passphrase"))
+	})
+
+	It("records a failure if the passphrase contains whitespace", func() {
+		service.scope.EncryptionSecret.Data["passphrase"] = []byte("my passphrase")
+
+		Expect(service.unlockDisks()).To(BeAssignableToTypeOf(actionFailed{}))
+		Expect(host.Spec.Status.ErrorType).To(Equal(infrav1.ProvisioningError))
+		Expect(dropbearMock.AssertNotCalled(GinkgoT(), "UnlockDisks", mock.Anything)).To(BeTrue())
+	})
+
+	It("gives the operating system time to boot after the partitions have been unlocked", func() {
+		host.Spec.Status.ErrorType = infrav1.ErrorTypeDisksUnlocked
+		host.Spec.Status.LastUpdated = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+
+		failed, err := service.handleIncompleteBoot(false, true, false)
+		Expect(err).To(Succeed())
+		Expect(failed).To(BeFalse())
+		Expect(host.Spec.Status.ErrorType).To(Equal(infrav1.ErrorTypeDisksUnlocked))
+	})
+})
+
+var _ = Describe("ensureRobotVSwitch", func() {
+	type testCaseEnsureRobotVSwitch struct {
+		vSwitchStatus    *infrav1.RobotVSwitchStatus
+		vSwitchServers   []robotclient.VSwitchServer
+		addServerErr     error
+		expectActResult  actionResult
+		expectAddServer  bool
+		expectHostStatus *infrav1.RobotVSwitchStatus
+	}
+
+	DescribeTable("ensureRobotVSwitch",
+		func(tc testCaseEnsureRobotVSwitch) {
+			host := helpers.BareMetalHost("test-host", "default")
+			host.Spec.ServerID = 42
+
+			robotMock := robotmock.Client{}
+			robotMock.On("GetVSwitch", 1).Return(&robotclient.VSwitch{ID: 1, VLAN: 4000, Server: tc.vSwitchServers}, nil)
+			robotMock.On("AddServerToVSwitch", 1, 42).Return(tc.addServerErr)
+
+			service := newTestService(host, &robotMock, nil, nil, nil)
+			service.scope.HetznerCluster.Spec.RobotVSwitch = &infrav1.RobotVSwitchSpec{VLAN: 4000, IPRange: "10.0.1.0/24"}
+			service.scope.HetznerCluster.Status.RobotVSwitch = tc.vSwitchStatus
+
+			actResult := service.ensureRobotVSwitch()
+			if tc.expectActResult == nil {
+				Expect(actResult).To(BeNil())
+			} else {
+				Expect(actResult).Should(BeAssignableToTypeOf(tc.expectActResult))
+			}
+
+			if tc.expectAddServer {
+				Expect(robotMock.AssertCalled(GinkgoT(), "AddServerToVSwitch", 1, 42)).To(BeTrue())
+			} else {
+				Expect(robotMock.AssertNotCalled(GinkgoT(), "AddServerToVSwitch", 1, 42)).To(BeTrue())
+			}
+
+			Expect(host.Spec.Status.RobotVSwitch).To(Equal(tc.expectHostStatus))
+		},
+		Entry("waits for the vSwitch of the cluster", testCaseEnsureRobotVSwitch{
+			vSwitchStatus:    nil,
+			expectActResult:  actionContinue{},
+			expectAddServer:  false,
+			expectHostStatus: nil,
+		}),
+		Entry("adds the server to the vSwitch", testCaseEnsureRobotVSwitch{
+			vSwitchStatus:    &infrav1.RobotVSwitchStatus{ID: 1, VLAN: 4000},
+			expectActResult:  nil,
+			expectAddServer:  true,
+			expectHostStatus: &infrav1.RobotVSwitchStatus{ID: 1, VLAN: 4000},
+		}),
+		Entry("does not add the server twice", testCaseEnsureRobotVSwitch{
+			vSwitchStatus:    &infrav1.RobotVSwitchStatus{ID: 1, VLAN: 4000},
+			vSwitchServers:   []robotclient.VSwitchServer{{ServerNumber: 42}},
+			expectActResult:  nil,
+			expectAddServer:  false,
+			expectHostStatus: &infrav1.RobotVSwitchStatus{ID: 1, VLAN: 4000},
+		}),
+		Entry("retries if the vSwitch is in process", testCaseEnsureRobotVSwitch{
+			vSwitchStatus:    &infrav1.RobotVSwitchStatus{ID: 1, VLAN: 4000},
+			addServerErr:     models.Error{Code: robotclient.ErrorCodeVSwitchInProcess},
+			expectActResult:  actionContinue{},
+			expectAddServer:  true,
+			expectHostStatus: nil,
+		}),
+		Entry("fails if the server cannot be added", testCaseEnsureRobotVSwitch{
+			vSwitchStatus:    &infrav1.RobotVSwitchStatus{ID: 1, VLAN: 4000},
+			addServerErr:     models.Error{Code: robotclient.ErrorCodeVSwitchServerLimitReached},
+			expectActResult:  actionError{},
+			expectAddServer:  true,
+			expectHostStatus: nil,
+		}),
+	)
+})
+
+var _ = Describe("removeFromRobotVSwitch", func() {
+	DescribeTable("removeFromRobotVSwitch",
+		func(removeServerErr error, expectActResult actionResult, expectHostStatusCleared bool) {
+			host := helpers.BareMetalHost("test-host", "default")
+			host.Spec.ServerID = 42
+			host.Spec.Status.RobotVSwitch = &infrav1.RobotVSwitchStatus{ID: 1, VLAN: 4000}
+
+			robotMock := robotmock.Client{}
+			robotMock.On("RemoveServerFromVSwitch", 1, 42).Return(removeServerErr)
+
+			service := newTestService(host, &robotMock, nil, nil, nil)
+
+			actResult := service.removeFromRobotVSwitch()
+			if expectActResult == nil {
+				Expect(actResult).To(BeNil())
+			} else {
+				Expect(actResult).Should(BeAssignableToTypeOf(expectActResult))
+			}
+			Expect(host.Spec.Status.RobotVSwitch == nil).To(Equal(expectHostStatusCleared))
+		},
+		Entry("removes the server", nil, nil, true),
+		Entry("ignores a missing vSwitch", models.Error{Code: models.ErrorCodeNotFound}, nil, true),
+		Entry("retries if the vSwitch is in process", models.Error{Code: robotclient.ErrorCodeVSwitchInProcess}, actionContinue{}, false),
+		Entry("fails on other errors", errTest, actionError{}, false),
+	)
+})
@@ -62,6 +62,7 @@ func (hsm *hostStateMachine) handlers() map[infrav1.ProvisioningState]stateHandl
 		infrav1.StateEnsureProvisioned: hsm.handleEnsureProvisioned,
 		infrav1.StateProvisioned:       hsm.handleProvisioned,
 		infrav1.StateDeprovisioning:    hsm.handleDeprovisioning,
+		infrav1.StateWipingDisks:       hsm.handleWipingDisks,
 		infrav1.StateDeleting:          hsm.handleDeleting,
 	}
 }
@@ -97,7 +98,7 @@ func (hsm *hostStateMachine) ReconcileState() (actionRes actionResult) {
 	)
 
 	if stateHandler, found := hsm.handlers()[initialState]; found {
-		return stateHandler()
+		return hsm.reconciler.handleHostKeyMismatch(stateHandler())
 	}
 
 	return actionError{fmt.Errorf("%w: state %q", errNoHandlerFound, initialState)}
@@ -115,8 +116,8 @@ func (hsm *hostStateMachine) checkInitiateDelete() bool {
 	case infrav1.StateRegistering, infrav1.StateImageInstalling, infrav1.StateProvisioning,
 		infrav1.StateEnsureProvisioned, infrav1.StateProvisioned:
 		hsm.nextState = infrav1.StateDeprovisioning
-	case infrav1.StateDeprovisioning:
-		// Continue deprovisioning.
+	case infrav1.StateDeprovisioning, infrav1.StateWipingDisks:
+		// Continue deprovisioning. The disks are wiped before the host is deleted, as it leaves the pool.
 		return false
 	}
 	return true
@@ -124,7 +125,8 @@ func (hsm *hostStateMachine) checkInitiateDelete() bool {
 
 func (hsm *hostStateMachine) updateSSHKey() actionResult {
 	// Skip if deprovisioning
-	if hsm.host.Spec.Status.ProvisioningState == infrav1.StateDeprovisioning {
+	if hsm.host.Spec.Status.ProvisioningState == infrav1.StateDeprovisioning ||
+		hsm.host.Spec.Status.ProvisioningState == infrav1.StateWipingDisks {
 		return actionComplete{}
 	}
 
@@ -292,12 +294,49 @@ func (hsm *hostStateMachine) handleProvisioned() actionResult {
 		hsm.nextState = infrav1.StateDeprovisioning
 		return actionComplete{}
 	}
+
+	// Reprovisioning boots the rescue system and goes through registering and installing the image again.
+	if hsm.host.HasReprovisionAnnotation() {
+		actResult := hsm.reconciler.actionReprovisioning()
+		if _, ok := actResult.(actionComplete); ok {
+			hsm.nextState = infrav1.StateRegistering
+		}
+		return actResult
+	}
+
 	return hsm.reconciler.actionProvisioned()
 }
 
 func (hsm *hostStateMachine) handleDeprovisioning() actionResult {
 	actResult := hsm.reconciler.actionDeprovisioning()
 	if _, ok := actResult.(actionComplete); ok {
+		if hsm.host.Spec.WipeDisks {
+			// the machine is released while the disks are wiped, but the host only returns to the pool afterwards
+			hsm.host.Spec.Status.DiskWipe = &infrav1.DiskWipeStatus{}
+			conditions.MarkFalse(
+				hsm.host,
+				infrav1.DiskWipeSucceededCondition,
+				infrav1.DiskWipeInProgressReason,
+				clusterv1.ConditionSeverityInfo,
+				"disks of the host are being wiped",
+			)
+			hsm.nextState = infrav1.StateWipingDisks
+			return actionComplete{}
+		}
+		hsm.nextState = infrav1.StateNone
+		return actionComplete{}
+	}
+	return actResult
+}
+
+func (hsm *hostStateMachine) handleWipingDisks() actionResult {
+	actResult := hsm.reconciler.actionWipingDisks()
+	if _, ok := actResult.(actionComplete); ok {
+		// the machine has been released while the disks were wiped and left the references needed for it
+		if hsm.host.Spec.ConsumerRef == nil {
+			hsm.host.Spec.Status.SSHSpec = nil
+			hsm.host.Spec.Status.HetznerClusterRef = ""
+		}
 		hsm.nextState = infrav1.StateNone
 		return actionComplete{}
 	}
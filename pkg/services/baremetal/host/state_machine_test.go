@@ -255,3 +255,25 @@ var _ = Describe("updateSSHKey", func() {
 		}),
 	)
 })
+
+var _ = Describe("handleWipingDisks", func() {
+	DescribeTable("removes the references of a released host once its disks have been wiped",
+		func(consumerRef *corev1.ObjectReference, expectReferences bool) {
+			host := helpers.BareMetalHost("test-host", "default", helpers.WithSSHSpec())
+			host.Spec.ConsumerRef = consumerRef
+			host.Spec.Status.HetznerClusterRef = "hetzner-cluster"
+			host.Spec.Status.ProvisioningState = infrav1.StateWipingDisks
+			host.Spec.Status.DiskWipe = &infrav1.DiskWipeStatus{Phase: infrav1.DiskWipePhaseSucceeded}
+			host.Spec.WipeDisks = true
+
+			hsm := newTestHostStateMachine(host, newTestService(host, nil, nil, nil, nil))
+
+			Expect(hsm.handleWipingDisks()).To(BeAssignableToTypeOf(actionComplete{}))
+			Expect(hsm.nextState).To(Equal(infrav1.StateNone))
+			Expect(host.Spec.Status.SSHSpec != nil).To(Equal(expectReferences))
+			Expect(host.Spec.Status.HetznerClusterRef != "").To(Equal(expectReferences))
+		},
+		Entry("released host", nil, false),
+		Entry("consumed host", &corev1.ObjectReference{Name: "bm-machine"}, true),
+	)
+})
@@ -27,6 +27,19 @@ type autoSetupInput struct {
 	osDevices []string
 	hostName  string
 	image     string
+	// cryptPassword is the passphrase of the encrypted partitions. It is only used if the InstallImage has an
+	// encryption.
+	cryptPassword string
+}
+
+// diskUnlockInput configures how the encrypted partitions of the installed system are unlocked at boot.
+type diskUnlockInput struct {
+	// authorizedKey is the public key that may log in to the SSH server in the initramfs.
+	authorizedKey string
+	dropbearPort  int
+	// tpmPassphrase is the passphrase that is used to bind the partitions to the TPM. It is empty if the partitions
+	// are not unlocked with the TPM.
+	tpmPassphrase string
 }
 
 func buildAutoSetup(installImageSpec *infrav1.InstallImage, autoSetupInput autoSetupInput) string {
@@ -48,10 +61,19 @@ SWRAID %v`, autoSetupInput.hostName, installImageSpec.Swraid)
 SWRAIDLEVEL %v`, hostName, installImageSpec.SwraidLevel)
 	}
 
+	if installImageSpec.Encryption != nil {
+		hostName = fmt.Sprintf(`%s
+CRYPTPASSWORD %s`, hostName, autoSetupInput.cryptPassword)
+	}
+
 	var partitions string
 	for _, partition := range installImageSpec.Partitions {
 		partitions = fmt.Sprintf(`%s
 PART %s %s %s`, partitions, partition.Mount, partition.FileSystem, partition.Size)
+		// the boot loader cannot read encrypted partitions
+		if installImageSpec.Encryption != nil && partition.Mount != "/boot" && partition.Mount != "/boot/efi" {
+			partitions += " crypt"
+		}
 	}
 
 	// e.g. PART / ext4 all
@@ -81,6 +103,80 @@ IMAGE %s`, autoSetupInput.image)
 	return output
 }
 
+// buildPostInstallScript returns the post install script that installimage runs in a chroot of the installed system.
+// It replaces the host keys of the image with the SSH host key that has been generated for the host and keeps
+// cloud-init from deleting it. If the partitions are encrypted, it sets up their unlock at boot. The post install
+// script of the InstallImage spec runs afterwards.
+func buildPostInstallScript(hostPrivateKey, hostPublicKey string, unlock *diskUnlockInput, userScript string) string {
+	script := fmt.Sprintf(`#!/bin/bash
+set -e
+rm -f /etc/ssh/ssh_host_*
+cat << 'CAPH_EOF' > /etc/ssh/ssh_host_ed25519_key
+%s
+CAPH_EOF
+cat << 'CAPH_EOF' > /etc/ssh/ssh_host_ed25519_key.pub
+%s
+CAPH_EOF
+chmod 600 /etc/ssh/ssh_host_ed25519_key
+chmod 644 /etc/ssh/ssh_host_ed25519_key.pub
+mkdir -p /etc/cloud/cloud.cfg.d
+echo "ssh_deletekeys: false" > /etc/cloud/cloud.cfg.d/99-caph-ssh-host-key.cfg
+`, strings.TrimSpace(hostPrivateKey), strings.TrimSpace(hostPublicKey))
+
+	if unlock != nil {
+		script += buildDiskUnlockScript(unlock)
+	}
+
+	if strings.TrimSpace(userScript) == "" {
+		return script
+	}
+
+	return fmt.Sprintf(`%scat << 'CAPH_EOF' > /tmp/post-install.sh
+%s
+CAPH_EOF
+chmod +x /tmp/post-install.sh
+/tmp/post-install.sh
+rm -f /tmp/post-install.sh
+`, script, strings.TrimSuffix(userScript, "\n"))
+}
+
+// buildDiskUnlockScript returns the part of the post install script that sets up the SSH server in the initramfs,
+// through which the controller unlocks the encrypted partitions. The SSH server presents the host key of the OS, so
+// that it is verified like the OS. With the TPM, clevis unlocks the partitions without the controller.
+func buildDiskUnlockScript(unlock *diskUnlockInput) string {
+	script := fmt.Sprintf(`export DEBIAN_FRONTEND=noninteractive
+apt-get update
+apt-get install -y cryptsetup-initramfs dropbear-initramfs
+dropbear_dir=/etc/dropbear/initramfs
+dropbear_config=$dropbear_dir/dropbear.conf
+if [ -d /etc/dropbear-initramfs ]; then
+  dropbear_dir=/etc/dropbear-initramfs
+  dropbear_config=$dropbear_dir/config
+fi
+cat << 'CAPH_EOF' > $dropbear_dir/authorized_keys
+%s
+CAPH_EOF
+chmod 600 $dropbear_dir/authorized_keys
+rm -f $dropbear_dir/dropbear_*_host_key
+dropbearconvert openssh dropbear /etc/ssh/ssh_host_ed25519_key $dropbear_dir/dropbear_ed25519_host_key
+echo 'DROPBEAR_OPTIONS="-p %d -s -j -k"' >> $dropbear_config
+`, strings.TrimSpace(unlock.authorizedKey), unlock.dropbearPort)
+
+	if unlock.tpmPassphrase != "" {
+		script += fmt.Sprintf(`apt-get install -y clevis clevis-luks clevis-tpm2 clevis-initramfs
+luks_passphrase=$(cat << 'CAPH_EOF'
+%s
+CAPH_EOF
+)
+for device in $(blkid -t TYPE=crypto_LUKS -o device); do
+  printf '%%s' "$luks_passphrase" | clevis luks bind -y -k - -d $device tpm2 '{"pcr_ids":"7"}'
+done
+`, unlock.tpmPassphrase)
+	}
+
+	return script + "update-initramfs -u -k all\n"
+}
+
 func validJSONFromSSHOutput(str string) string {
 	if str == "" {
 		return "{}"
@@ -93,3 +189,29 @@ func validJSONFromSSHOutput(str string) string {
 func trimLineBreak(str string) string {
 	return strings.TrimSuffix(str, "\n")
 }
+
+// diskWipeState is the state of the wipe of a disk as reported by the disk wipe script.
+type diskWipeState struct {
+	State  infrav1.DiskWipeState
+	Method string
+}
+
+// parseDiskWipeStatus parses the output of GetDiskWipeStatus. Every disk has a line "<name> <state> [<method>]",
+// the last line is "active" while the wipe is running.
+func parseDiskWipeStatus(stdOut string) (states map[string]diskWipeState, active bool) {
+	states = make(map[string]diskWipeState)
+	for _, line := range strings.Split(stdOut, "\n") {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 1 && fields[0] == "active":
+			active = true
+		case len(fields) >= 2:
+			state := diskWipeState{State: infrav1.DiskWipeState(fields[1])}
+			if len(fields) > 2 {
+				state.Method = fields[2]
+			}
+			states[fields[0]] = state
+		}
+	}
+	return states, active
+}
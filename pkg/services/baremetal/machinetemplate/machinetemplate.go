@@ -0,0 +1,191 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package machinetemplate implements functions to manage the lifecycle of HetznerBareMetalMachineTemplates.
+package machinetemplate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
+	"github.com/syself/cluster-api-provider-hetzner/pkg/scope"
+	"github.com/syself/cluster-api-provider-hetzner/pkg/services/baremetal/baremetal"
+)
+
+// Service defines struct with BareMetalMachineTemplate scope to reconcile HetznerBareMetalMachineTemplates.
+type Service struct {
+	scope *scope.BareMetalMachineTemplateScope
+}
+
+// NewService outs a new service with BareMetalMachineTemplate scope.
+func NewService(scope *scope.BareMetalMachineTemplateScope) *Service {
+	return &Service{
+		scope: scope,
+	}
+}
+
+// Reconcile computes the capacity of the machine template from the hosts that match its host selector.
+func (s *Service) Reconcile(ctx context.Context) error {
+	hosts, err := s.availableHosts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get available hosts: %w", err)
+	}
+
+	machineTemplate := s.scope.BareMetalMachineTemplate
+	machineTemplate.Status.AvailableHosts = len(hosts)
+
+	if len(hosts) == 0 {
+		conditions.MarkFalse(
+			machineTemplate,
+			infrav1.HostCapacityAvailableCondition,
+			infrav1.NoAvailableHostReason,
+			clusterv1.ConditionSeverityWarning,
+			"no available host matches the host selector",
+		)
+		return nil
+	}
+
+	capacity, nodeInfo, err := getCapacity(hosts)
+	if err != nil {
+		return fmt.Errorf("failed to get capacity: %w", err)
+	}
+
+	if capacity == nil {
+		conditions.MarkFalse(
+			machineTemplate,
+			infrav1.HostCapacityAvailableCondition,
+			infrav1.HardwareDetailsMissingReason,
+			clusterv1.ConditionSeverityWarning,
+			"none of the %d available hosts has hardware details yet", len(hosts),
+		)
+		return nil
+	}
+
+	machineTemplate.Status.Capacity = capacity
+	machineTemplate.Status.NodeInfo = nodeInfo
+	conditions.MarkTrue(machineTemplate, infrav1.HostCapacityAvailableCondition)
+	return nil
+}
+
+// availableHosts returns all unclaimed hosts in the namespace of the template that match its host selector,
+// including its hardware requirements.
+func (s *Service) availableHosts(ctx context.Context) ([]*infrav1.HetznerBareMetalHost, error) {
+	hosts := infrav1.HetznerBareMetalHostList{}
+	opts := &client.ListOptions{
+		Namespace: s.scope.Namespace(),
+	}
+
+	if err := s.scope.Client.List(ctx, &hosts, opts); err != nil {
+		return nil, fmt.Errorf("failed to list hosts: %w", err)
+	}
+
+	hostSelector := s.scope.BareMetalMachineTemplate.Spec.Template.Spec.HostSelector
+	labelSelector := baremetal.LabelSelectorFromHostSelector(hostSelector)
+
+	availableHosts := make([]*infrav1.HetznerBareMetalHost, 0, len(hosts.Items))
+	for i, host := range hosts.Items {
+		if !baremetal.IsHostAvailable(&hosts.Items[i]) {
+			continue
+		}
+		if !labelSelector.Matches(labels.Set(host.ObjectMeta.Labels)) {
+			continue
+		}
+		if !hostSelector.HardwareRequirements.Matches(host.Spec.Status.HardwareDetails) {
+			continue
+		}
+		availableHosts = append(availableHosts, &hosts.Items[i])
+	}
+	return availableHosts, nil
+}
+
+// getCapacity returns the capacity of the smallest host, so that the autoscaler never expects more
+// resources than the node it gets. Hosts without hardware details are ignored. If no host has
+// hardware details, nil is returned.
+func getCapacity(hosts []*infrav1.HetznerBareMetalHost) (corev1.ResourceList, *infrav1.NodeInfo, error) {
+	var cpus, ramGB int
+	var architecture string
+	var mixedArchitectures bool
+
+	for _, host := range hosts {
+		details := host.Spec.Status.HardwareDetails
+		if details == nil {
+			continue
+		}
+
+		hostCPUs := details.CPU.Threads
+		if hostCPUs == 0 {
+			hostCPUs = details.CPU.Cores
+		}
+		if cpus == 0 || hostCPUs < cpus {
+			cpus = hostCPUs
+		}
+		if ramGB == 0 || details.RAMGB < ramGB {
+			ramGB = details.RAMGB
+		}
+
+		hostArchitecture := normalizeArchitecture(details.CPU.Arch)
+		if architecture == "" {
+			architecture = hostArchitecture
+		} else if architecture != hostArchitecture {
+			mixedArchitectures = true
+		}
+	}
+
+	if cpus == 0 || ramGB == 0 {
+		return nil, nil, nil
+	}
+
+	cpu, err := resource.ParseQuantity(fmt.Sprintf("%d", cpus))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse quantity. CPUs %v: %w", cpus, err)
+	}
+	memory, err := resource.ParseQuantity(fmt.Sprintf("%dG", ramGB))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse quantity. Memory %vG: %w", ramGB, err)
+	}
+
+	nodeInfo := &infrav1.NodeInfo{OperatingSystem: "linux"}
+	// the architecture cannot be predicted if the selector matches hosts of different architectures
+	if !mixedArchitectures {
+		nodeInfo.Architecture = architecture
+	}
+
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    cpu,
+		corev1.ResourceMemory: memory,
+	}, nodeInfo, nil
+}
+
+// normalizeArchitecture converts the architecture reported by lscpu to the format of the kubernetes.io/arch label.
+func normalizeArchitecture(arch string) string {
+	switch arch = strings.ToLower(arch); arch {
+	case "x86_64":
+		return "amd64"
+	case "aarch64":
+		return "arm64"
+	default:
+		return arch
+	}
+}
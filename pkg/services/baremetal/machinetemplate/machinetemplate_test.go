@@ -0,0 +1,164 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinetemplate
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
+	"github.com/syself/cluster-api-provider-hetzner/pkg/scope"
+)
+
+const defaultNamespace = "default"
+
+func newHost(name string, hostLabels map[string]string, hardwareDetails *infrav1.HardwareDetails) *infrav1.HetznerBareMetalHost {
+	return &infrav1.HetznerBareMetalHost{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: defaultNamespace,
+			Labels:    hostLabels,
+		},
+		Spec: infrav1.HetznerBareMetalHostSpec{
+			Status: infrav1.ControllerGeneratedStatus{
+				HardwareDetails: hardwareDetails,
+			},
+		},
+	}
+}
+
+func hardwareDetails(arch string, threads, ramGB int) *infrav1.HardwareDetails {
+	return &infrav1.HardwareDetails{
+		RAMGB: ramGB,
+		CPU: infrav1.CPU{
+			Arch:    arch,
+			Threads: threads,
+			Cores:   threads / 2,
+		},
+	}
+}
+
+var _ = Describe("Reconcile", func() {
+	var machineTemplate *infrav1.HetznerBareMetalMachineTemplate
+
+	BeforeEach(func() {
+		machineTemplate = &infrav1.HetznerBareMetalMachineTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "bm-machine-template",
+				Namespace: defaultNamespace,
+			},
+			Spec: infrav1.HetznerBareMetalMachineTemplateSpec{
+				Template: infrav1.HetznerBareMetalMachineTemplateResource{
+					Spec: infrav1.HetznerBareMetalMachineSpec{
+						HostSelector: infrav1.HostSelector{
+							MatchLabels: map[string]string{"role": "worker"},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	newService := func(hosts ...client.Object) *Service {
+		scheme := runtime.NewScheme()
+		Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+		c := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(append(hosts, machineTemplate)...).Build()
+
+		machineTemplateScope, err := scope.NewBareMetalMachineTemplateScope(scope.BareMetalMachineTemplateScopeParams{
+			Client:                   c,
+			BareMetalMachineTemplate: machineTemplate,
+		})
+		Expect(err).To(Succeed())
+		return NewService(machineTemplateScope)
+	}
+
+	It("computes the capacity of the smallest available host", func() {
+		claimedHost := newHost("claimed", map[string]string{"role": "worker"}, hardwareDetails("x86_64", 4, 16))
+		claimedHost.Spec.ConsumerRef = &corev1.ObjectReference{Name: "bm-machine", Namespace: defaultNamespace}
+
+		maintenanceHost := newHost("maintenance", map[string]string{"role": "worker"}, hardwareDetails("x86_64", 4, 16))
+		maintenanceHost.Spec.MaintenanceMode = ptr.To(true)
+
+		service := newService(
+			newHost("big", map[string]string{"role": "worker"}, hardwareDetails("x86_64", 32, 128)),
+			newHost("small", map[string]string{"role": "worker"}, hardwareDetails("x86_64", 16, 64)),
+			newHost("unknown", map[string]string{"role": "worker"}, nil),
+			newHost("other-role", map[string]string{"role": "control-plane"}, hardwareDetails("x86_64", 8, 32)),
+			claimedHost,
+			maintenanceHost,
+		)
+
+		Expect(service.Reconcile(context.Background())).To(Succeed())
+		Expect(machineTemplate.Status.AvailableHosts).To(Equal(3))
+		Expect(machineTemplate.Status.Capacity).To(Equal(corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("16"),
+			corev1.ResourceMemory: resource.MustParse("64G"),
+		}))
+		Expect(machineTemplate.Status.NodeInfo).To(Equal(&infrav1.NodeInfo{Architecture: "amd64", OperatingSystem: "linux"}))
+		Expect(conditions.IsTrue(machineTemplate, infrav1.HostCapacityAvailableCondition)).To(BeTrue())
+	})
+
+	It("leaves the architecture empty if hosts have different architectures", func() {
+		service := newService(
+			newHost("amd", map[string]string{"role": "worker"}, hardwareDetails("x86_64", 16, 64)),
+			newHost("arm", map[string]string{"role": "worker"}, hardwareDetails("aarch64", 16, 64)),
+		)
+
+		Expect(service.Reconcile(context.Background())).To(Succeed())
+		Expect(machineTemplate.Status.NodeInfo).To(Equal(&infrav1.NodeInfo{OperatingSystem: "linux"}))
+	})
+
+	It("sets a condition if no host has hardware details", func() {
+		service := newService(newHost("unknown", map[string]string{"role": "worker"}, nil))
+
+		Expect(service.Reconcile(context.Background())).To(Succeed())
+		Expect(machineTemplate.Status.AvailableHosts).To(Equal(1))
+		Expect(machineTemplate.Status.Capacity).To(BeNil())
+		Expect(conditions.GetReason(machineTemplate, infrav1.HostCapacityAvailableCondition)).To(Equal(infrav1.HardwareDetailsMissingReason))
+	})
+
+	It("keeps the last capacity and sets a condition if no host is available", func() {
+		machineTemplate.Status.Capacity = corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("8"),
+		}
+		service := newService(newHost("other-role", map[string]string{"role": "control-plane"}, hardwareDetails("x86_64", 8, 32)))
+
+		Expect(service.Reconcile(context.Background())).To(Succeed())
+		Expect(machineTemplate.Status.AvailableHosts).To(Equal(0))
+		Expect(machineTemplate.Status.Capacity).To(HaveKey(corev1.ResourceCPU))
+		Expect(conditions.GetReason(machineTemplate, infrav1.HostCapacityAvailableCondition)).To(Equal(infrav1.NoAvailableHostReason))
+	})
+})
+
+var _ = DescribeTable("normalizeArchitecture",
+	func(arch, expectedOutput string) {
+		Expect(normalizeArchitecture(arch)).To(Equal(expectedOutput))
+	},
+	Entry("x86_64", "x86_64", "amd64"),
+	Entry("aarch64", "aarch64", "arm64"),
+	Entry("unknown", "riscv64", "riscv64"),
+)
@@ -38,6 +38,9 @@ import (
 	"github.com/syself/cluster-api-provider-hetzner/pkg/scope"
 )
 
+// reprovisioningRequeueAfter is the interval to check whether a host has finished reprovisioning.
+const reprovisioningRequeueAfter = 30 * time.Second
+
 // Service defines struct with BareMetalRemediationScope to reconcile HetznerBareMetalRemediations.
 type Service struct {
 	scope *scope.BareMetalRemediationScope
@@ -84,8 +87,14 @@ func (s *Service) Reconcile(ctx context.Context) (res reconcile.Result, err erro
 		return res, err
 	}
 
+	strategyType := s.scope.BareMetalRemediation.Spec.Strategy.Type
+
+	// a host that is being reprovisioned through this remediation is legitimately not in state provisioned
+	reprovisioning := strategyType == infrav1.RemediationTypeReprovision &&
+		s.scope.BareMetalRemediation.Status.LastRemediated != nil
+
 	// if host is not provisioned or in maintenance mode, then we do not try to reboot server
-	if host.Spec.Status.ProvisioningState != infrav1.StateProvisioned ||
+	if !reprovisioning && host.Spec.Status.ProvisioningState != infrav1.StateProvisioned ||
 		host.Spec.MaintenanceMode != nil && *host.Spec.MaintenanceMode {
 		if err := s.setOwnerRemediatedConditionNew(ctx); err != nil {
 			err := fmt.Errorf("failed to set remediated condition on capi machine: %w", err)
@@ -101,7 +110,7 @@ func (s *Service) Reconcile(ctx context.Context) (res reconcile.Result, err erro
 		return res, nil
 	}
 
-	if s.scope.BareMetalRemediation.Spec.Strategy.Type != infrav1.RemediationTypeReboot {
+	if strategyType != infrav1.RemediationTypeReboot && strategyType != infrav1.RemediationTypeReprovision {
 		record.Warn(s.scope.BareMetalRemediation, "UnsupportedRemediationStrategy", "unsupported remediation strategy")
 		return res, nil
 	}
@@ -142,6 +151,12 @@ func (s *Service) handlePhaseRunning(ctx context.Context, host infrav1.HetznerBa
 		return reconcile.Result{RequeueAfter: nextRemediation}, nil
 	}
 
+	// a host can only be reprovisioned again once the previous reprovisioning has finished
+	if s.scope.BareMetalRemediation.Spec.Strategy.Type == infrav1.RemediationTypeReprovision &&
+		host.Spec.Status.ProvisioningState != infrav1.StateProvisioned {
+		return reconcile.Result{RequeueAfter: reprovisioningRequeueAfter}, nil
+	}
+
 	// remediate now
 	if err := s.remediate(ctx, host); err != nil {
 		return res, fmt.Errorf("failed remediate host: %w", err)
@@ -158,10 +173,15 @@ func (s *Service) remediate(ctx context.Context, host infrav1.HetznerBareMetalHo
 		return fmt.Errorf("failed to init patch helper: %s %s/%s %w", host.Kind, host.Namespace, host.Name, err)
 	}
 
-	// add annotation to host so that it reboots
-	host.Annotations, err = addRebootAnnotation(host.Annotations)
-	if err != nil {
-		return fmt.Errorf("failed to add reboot annotation: %w", err)
+	if s.scope.BareMetalRemediation.Spec.Strategy.Type == infrav1.RemediationTypeReprovision {
+		// add annotation to host so that it gets reprovisioned
+		host.Annotations = addReprovisionAnnotation(host.Annotations)
+	} else {
+		// add annotation to host so that it reboots
+		host.Annotations, err = addRebootAnnotation(host.Annotations)
+		if err != nil {
+			return fmt.Errorf("failed to add reboot annotation: %w", err)
+		}
 	}
 
 	if err := patchHelper.Patch(ctx, &host); err != nil {
@@ -234,7 +254,7 @@ func (s *Service) setOwnerRemediatedConditionNew(ctx context.Context) error {
 		capi.MachineOwnerRemediatedCondition,
 		capi.WaitingForRemediationReason,
 		capi.ConditionSeverityWarning,
-		"remediation through reboot failed",
+		fmt.Sprintf("remediation through %s failed", strings.ToLower(string(s.scope.BareMetalRemediation.Spec.Strategy.Type))),
 	)
 
 	if err := patchHelper.Patch(ctx, capiMachine); err != nil {
@@ -277,6 +297,16 @@ func addRebootAnnotation(annotations map[string]string) (map[string]string, erro
 	return annotations, nil
 }
 
+// addReprovisionAnnotation sets reprovision annotation on unhealthy host.
+func addReprovisionAnnotation(annotations map[string]string) map[string]string {
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+
+	annotations[infrav1.ReprovisionAnnotation] = ""
+	return annotations
+}
+
 func splitHostKey(key string) (namespace, name string, err error) {
 	parts := strings.Split(key, "/")
 	if len(parts) != 2 {
@@ -201,3 +201,28 @@ var _ = Describe("Test AddRebootAnnotation", func() {
 		}),
 	)
 })
+
+var _ = Describe("Test AddReprovisionAnnotation", func() {
+	type testCaseAddReprovisionAnnotation struct {
+		annotations       map[string]string
+		expectAnnotations map[string]string
+	}
+
+	DescribeTable("Test AddReprovisionAnnotation",
+		func(tc testCaseAddReprovisionAnnotation) {
+			Expect(addReprovisionAnnotation(tc.annotations)).To(Equal(tc.expectAnnotations))
+		},
+		Entry("nil annotations", testCaseAddReprovisionAnnotation{
+			annotations:       nil,
+			expectAnnotations: map[string]string{infrav1.ReprovisionAnnotation: ""},
+		}),
+		Entry("existing annotations", testCaseAddReprovisionAnnotation{
+			annotations:       map[string]string{"key": "value"},
+			expectAnnotations: map[string]string{"key": "value", infrav1.ReprovisionAnnotation: ""},
+		}),
+		Entry("reprovision annotation already present", testCaseAddReprovisionAnnotation{
+			annotations:       map[string]string{"key": "value", infrav1.ReprovisionAnnotation: ""},
+			expectAnnotations: map[string]string{"key": "value", infrav1.ReprovisionAnnotation: ""},
+		}),
+	)
+})
@@ -0,0 +1,179 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vswitch implements the lifecycle of the Robot vSwitch of a cluster.
+package vswitch
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/syself/hrobot-go/models"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/record"
+
+	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
+	"github.com/syself/cluster-api-provider-hetzner/pkg/scope"
+	robotclient "github.com/syself/cluster-api-provider-hetzner/pkg/services/baremetal/client/robot"
+)
+
+// errMissingRobotClient is returned if the cluster has a vSwitch but no robot credentials.
+var errMissingRobotClient = errors.New("robot credentials are required for the vSwitch")
+
+// Service struct contains cluster scope to reconcile the vSwitch.
+type Service struct {
+	scope *scope.ClusterScope
+}
+
+// NewService creates a new service object.
+func NewService(scope *scope.ClusterScope) *Service {
+	return &Service{
+		scope: scope,
+	}
+}
+
+// Reconcile implements life cycle of the vSwitch.
+func (s *Service) Reconcile() (err error) {
+	spec := s.scope.HetznerCluster.Spec.RobotVSwitch
+	if spec == nil {
+		return nil
+	}
+
+	defer func() {
+		if err != nil {
+			conditions.MarkFalse(
+				s.scope.HetznerCluster,
+				infrav1.RobotVSwitchReadyCondition,
+				infrav1.RobotVSwitchReconcileFailedReason,
+				clusterv1.ConditionSeverityWarning,
+				err.Error(),
+			)
+		}
+	}()
+
+	if s.scope.RobotClient == nil {
+		return errMissingRobotClient
+	}
+
+	vSwitch, err := s.findVSwitch()
+	if err != nil {
+		return fmt.Errorf("failed to find vSwitch: %w", err)
+	}
+
+	if vSwitch == nil {
+		vSwitch, err = s.scope.RobotClient.CreateVSwitch(s.name(), spec.VLAN)
+		if err != nil {
+			s.handleRateLimitExceeded(err, "CreateVSwitch")
+			record.Warnf(s.scope.HetznerCluster, "VSwitchCreateFailed", "Failed to create vSwitch %q with VLAN %d: %s", s.name(), spec.VLAN, err)
+			return fmt.Errorf("failed to create vSwitch: %w", err)
+		}
+		record.Eventf(s.scope.HetznerCluster, "VSwitchCreated", "Created vSwitch %q with VLAN %d", vSwitch.Name, vSwitch.VLAN)
+	}
+
+	if vSwitch.VLAN != spec.VLAN {
+		return fmt.Errorf("vSwitch %q has VLAN %d instead of %d", vSwitch.Name, vSwitch.VLAN, spec.VLAN)
+	}
+
+	s.scope.HetznerCluster.Status.RobotVSwitch = &infrav1.RobotVSwitchStatus{
+		ID:   vSwitch.ID,
+		Name: vSwitch.Name,
+		VLAN: vSwitch.VLAN,
+	}
+	conditions.MarkTrue(s.scope.HetznerCluster, infrav1.RobotVSwitchReadyCondition)
+
+	return nil
+}
+
+// Delete cancels the vSwitch. The HCloud network has to be deleted before, as the vSwitch is coupled with it.
+func (s *Service) Delete() error {
+	status := s.scope.HetznerCluster.Status.RobotVSwitch
+	if status == nil {
+		// nothing to delete
+		return nil
+	}
+
+	if s.scope.RobotClient == nil {
+		return errMissingRobotClient
+	}
+
+	if err := s.scope.RobotClient.CancelVSwitch(status.ID); err != nil {
+		s.handleRateLimitExceeded(err, "CancelVSwitch")
+		// if resource has been deleted already then do nothing
+		if !models.IsError(err, models.ErrorCodeNotFound) {
+			record.Warnf(s.scope.HetznerCluster, "VSwitchDeleteFailed", "Failed to cancel vSwitch with ID %v: %s", status.ID, err)
+			return fmt.Errorf("failed to cancel vSwitch: %w", err)
+		}
+		s.scope.V(1).Info("cancelling vSwitch failed - not found", "id", status.ID)
+	} else {
+		record.Eventf(s.scope.HetznerCluster, "VSwitchDeleted", "Cancelled vSwitch with ID %v", status.ID)
+	}
+
+	s.scope.HetznerCluster.Status.RobotVSwitch = nil
+	return nil
+}
+
+// findVSwitch returns the vSwitch of the cluster. Cancelled vSwitches are ignored, as they cannot be used anymore.
+func (s *Service) findVSwitch() (*robotclient.VSwitch, error) {
+	if status := s.scope.HetznerCluster.Status.RobotVSwitch; status != nil {
+		vSwitch, err := s.scope.RobotClient.GetVSwitch(status.ID)
+		if err != nil && !models.IsError(err, models.ErrorCodeNotFound) {
+			s.handleRateLimitExceeded(err, "GetVSwitch")
+			return nil, fmt.Errorf("failed to get vSwitch %v: %w", status.ID, err)
+		}
+		if err == nil && !vSwitch.Cancelled {
+			return vSwitch, nil
+		}
+	}
+
+	vSwitches, err := s.scope.RobotClient.ListVSwitches()
+	if err != nil {
+		s.handleRateLimitExceeded(err, "ListVSwitches")
+		return nil, fmt.Errorf("failed to list vSwitches: %w", err)
+	}
+
+	var found *robotclient.VSwitch
+	for i, vSwitch := range vSwitches {
+		if vSwitch.Name != s.name() || vSwitch.Cancelled {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("found multiple vSwitches with name %q - not allowed", s.name())
+		}
+		found = &vSwitches[i]
+	}
+
+	return found, nil
+}
+
+func (s *Service) name() string {
+	return s.scope.HetznerCluster.ClusterTagKey()
+}
+
+func (s *Service) handleRateLimitExceeded(err error, functionName string) {
+	if models.IsError(err, models.ErrorCodeRateLimitExceeded) || strings.Contains(err.Error(), "server responded with status code 403") {
+		msg := fmt.Sprintf("exceeded robot rate limit with calling function %q: %s", functionName, err.Error())
+		conditions.MarkFalse(
+			s.scope.HetznerCluster,
+			infrav1.HetznerAPIReachableCondition,
+			infrav1.RateLimitExceededReason,
+			clusterv1.ConditionSeverityWarning,
+			msg,
+		)
+		record.Warnf(s.scope.HetznerCluster, "RateLimitExceeded", msg)
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vswitch
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"github.com/syself/hrobot-go/models"
+	"k8s.io/klog/v2/klogr"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
+	"github.com/syself/cluster-api-provider-hetzner/pkg/scope"
+	robotmock "github.com/syself/cluster-api-provider-hetzner/pkg/services/baremetal/client/mocks/robot"
+	robotclient "github.com/syself/cluster-api-provider-hetzner/pkg/services/baremetal/client/robot"
+)
+
+var _ = Describe("VSwitch service", func() {
+	var (
+		robotMock *robotmock.Client
+		cluster   *infrav1.HetznerCluster
+		service   *Service
+	)
+
+	BeforeEach(func() {
+		robotMock = &robotmock.Client{}
+
+		cluster = &infrav1.HetznerCluster{}
+		cluster.Name = "my-cluster"
+		cluster.Spec.RobotVSwitch = &infrav1.RobotVSwitchSpec{VLAN: 4000, IPRange: "10.0.1.0/24"}
+
+		service = NewService(&scope.ClusterScope{Logger: klogr.New(), RobotClient: robotMock, HetznerCluster: cluster})
+	})
+
+	Context("Reconcile", func() {
+		It("does nothing without vSwitch", func() {
+			cluster.Spec.RobotVSwitch = nil
+
+			Expect(service.Reconcile()).To(Succeed())
+			Expect(cluster.Status.RobotVSwitch).To(BeNil())
+			Expect(robotMock.AssertNotCalled(GinkgoT(), "ListVSwitches")).To(BeTrue())
+		})
+
+		It("creates the vSwitch", func() {
+			robotMock.On("ListVSwitches").Return([]robotclient.VSwitch{
+				{ID: 1, Name: "other-vswitch", VLAN: 4000},
+				{ID: 2, Name: cluster.ClusterTagKey(), VLAN: 4000, Cancelled: true},
+			}, nil)
+			robotMock.On("CreateVSwitch", cluster.ClusterTagKey(), 4000).Return(&robotclient.VSwitch{ID: 3, Name: cluster.ClusterTagKey(), VLAN: 4000}, nil)
+
+			Expect(service.Reconcile()).To(Succeed())
+			Expect(cluster.Status.RobotVSwitch).To(Equal(&infrav1.RobotVSwitchStatus{ID: 3, Name: cluster.ClusterTagKey(), VLAN: 4000}))
+			Expect(conditions.IsTrue(cluster, infrav1.RobotVSwitchReadyCondition)).To(BeTrue())
+		})
+
+		It("uses the existing vSwitch", func() {
+			cluster.Status.RobotVSwitch = &infrav1.RobotVSwitchStatus{ID: 3, Name: cluster.ClusterTagKey(), VLAN: 4000}
+			robotMock.On("GetVSwitch", 3).Return(&robotclient.VSwitch{ID: 3, Name: cluster.ClusterTagKey(), VLAN: 4000}, nil)
+
+			Expect(service.Reconcile()).To(Succeed())
+			Expect(cluster.Status.RobotVSwitch.ID).To(Equal(3))
+			Expect(robotMock.AssertNotCalled(GinkgoT(), "CreateVSwitch", mock.Anything, mock.Anything)).To(BeTrue())
+		})
+
+		It("fails if the VLAN of the vSwitch does not match", func() {
+			robotMock.On("ListVSwitches").Return([]robotclient.VSwitch{
+				{ID: 1, Name: cluster.ClusterTagKey(), VLAN: 4001},
+			}, nil)
+
+			Expect(service.Reconcile()).ToNot(Succeed())
+			Expect(conditions.GetReason(cluster, infrav1.RobotVSwitchReadyCondition)).To(Equal(infrav1.RobotVSwitchReconcileFailedReason))
+		})
+
+		It("fails without robot credentials", func() {
+			service.scope.RobotClient = nil
+
+			Expect(service.Reconcile()).To(MatchError(errMissingRobotClient))
+			Expect(conditions.IsFalse(cluster, infrav1.RobotVSwitchReadyCondition)).To(BeTrue())
+		})
+	})
+
+	Context("Delete", func() {
+		BeforeEach(func() {
+			cluster.Status.RobotVSwitch = &infrav1.RobotVSwitchStatus{ID: 3, Name: cluster.ClusterTagKey(), VLAN: 4000}
+		})
+
+		It("cancels the vSwitch", func() {
+			robotMock.On("CancelVSwitch", 3).Return(nil)
+
+			Expect(service.Delete()).To(Succeed())
+			Expect(cluster.Status.RobotVSwitch).To(BeNil())
+		})
+
+		It("ignores a vSwitch that does not exist anymore", func() {
+			robotMock.On("CancelVSwitch", 3).Return(models.Error{Code: models.ErrorCodeNotFound})
+
+			Expect(service.Delete()).To(Succeed())
+			Expect(cluster.Status.RobotVSwitch).To(BeNil())
+		})
+
+		It("keeps the status if the vSwitch cannot be cancelled", func() {
+			robotMock.On("CancelVSwitch", 3).Return(models.Error{Code: models.ErrorCodeConflict})
+
+			Expect(service.Delete()).ToNot(Succeed())
+			Expect(cluster.Status.RobotVSwitch).ToNot(BeNil())
+		})
+	})
+})
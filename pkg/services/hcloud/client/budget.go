@@ -0,0 +1,224 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hcloudclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Priority defines which calls to the HCloud API may use the reserve of the rate limit budget of a token.
+type Priority int
+
+const (
+	// PriorityNormal is the priority of regular calls. They leave a reserve of the budget for calls with high priority.
+	PriorityNormal Priority = iota
+	// PriorityHigh is the priority of deletions and of calls for the control plane. They can use the whole budget.
+	PriorityHigh
+)
+
+const (
+	// budgetReserve is the share of the rate limit that is reserved for calls with high priority.
+	budgetReserve = 0.1
+	// maxBudgetWait is the longest time a call waits for budget, unless its context ends earlier.
+	maxBudgetWait = 30 * time.Second
+)
+
+// errBudgetExhausted indicates that there is no budget left for a call.
+var errBudgetExhausted = errors.New("rate limit budget exhausted")
+
+type priorityKey struct{}
+
+// WithPriority returns a context in which calls to the HCloud API are made with the given priority.
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// priorityFromRequest returns the priority of a request. Deletions always have high priority.
+func priorityFromRequest(req *http.Request) Priority {
+	if req.Method == http.MethodDelete {
+		return PriorityHigh
+	}
+	if priority, ok := req.Context().Value(priorityKey{}).(Priority); ok {
+		return priority
+	}
+	return PriorityNormal
+}
+
+// rateLimitBudget tracks the remaining rate limit of a token based on the rate limit headers of the HCloud API.
+// Calls without enough budget wait until it has been refilled, so that reconciles slow down before the API rejects
+// calls. As calls with normal priority wait for a reserve, calls with high priority go first.
+type rateLimitBudget struct {
+	mu sync.Mutex
+
+	limit      float64
+	remaining  float64
+	refillRate float64 // requests per second
+	updated    time.Time
+
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func newRateLimitBudget() *rateLimitBudget {
+	return &rateLimitBudget{now: time.Now, sleep: sleepContext}
+}
+
+// sleepContext waits for the given duration or until the context is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// update sets the budget according to the rate limit headers of a response.
+func (b *rateLimitBudget) update(header http.Header) {
+	limit, err := strconv.Atoi(header.Get("RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+	remaining, err := strconv.Atoi(header.Get("RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	reset, err := strconv.ParseInt(header.Get("RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.limit = float64(limit)
+	b.remaining = float64(remaining)
+	b.updated = now
+
+	// the budget is refilled continuously and is at the limit again at the time of the reset
+	if untilReset := time.Unix(reset, 0).Sub(now).Seconds(); untilReset > 0 && limit > remaining {
+		b.refillRate = float64(limit-remaining) / untilReset
+	}
+}
+
+// acquire takes budget for a call with the given priority and waits until there is enough budget for it. It fails
+// with errBudgetExhausted if the budget is not refilled within maxBudgetWait or before the deadline of the context.
+func (b *rateLimitBudget) acquire(ctx context.Context, priority Priority) error {
+	deadline := b.now().Add(maxBudgetWait)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+
+	for {
+		b.mu.Lock()
+		now := b.now()
+		delay := b.delay(priority, now)
+		if delay == 0 {
+			b.remaining = b.available(now) - 1
+			b.updated = now
+			b.mu.Unlock()
+			return nil
+		}
+		b.mu.Unlock()
+
+		// other calls might have taken the budget in the meantime, so it is checked again after waiting
+		if delay > deadline.Sub(now) {
+			return errBudgetExhausted
+		}
+		if err := b.sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// available returns the estimated budget at the given time.
+func (b *rateLimitBudget) available(now time.Time) float64 {
+	return math.Min(b.remaining+b.refillRate*now.Sub(b.updated).Seconds(), b.limit)
+}
+
+// delay returns how long a call with the given priority has to wait until there is budget for it.
+func (b *rateLimitBudget) delay(priority Priority, now time.Time) time.Duration {
+	// the budget is unknown until the first response has been received
+	if b.limit == 0 {
+		return 0
+	}
+
+	var reserve float64
+	if priority < PriorityHigh {
+		reserve = b.limit * budgetReserve
+	}
+
+	missing := reserve + 1 - b.available(now)
+	if missing <= 0 {
+		return 0
+	}
+	if b.refillRate <= 0 {
+		return math.MaxInt64
+	}
+	return time.Duration(missing / b.refillRate * float64(time.Second))
+}
+
+// budgetTransport sends requests once there is budget for them and updates the budget with every response.
+type budgetTransport struct {
+	budget *rateLimitBudget
+	next   http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *budgetTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.budget.acquire(req.Context(), priorityFromRequest(req)); err != nil {
+		if errors.Is(err, errBudgetExhausted) {
+			return rateLimitExceededResponse(req), nil
+		}
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	t.budget.update(resp.Header)
+	return resp, nil
+}
+
+// rateLimitExceededResponse returns the response of the HCloud API for an exceeded rate limit. This way, a budget
+// that is not refilled in time is handled like a rate limit error of the API.
+func rateLimitExceededResponse(req *http.Request) *http.Response {
+	body := `{"error":{"code":"rate_limit_exceeded","message":"rate limit budget of the token is exhausted"}}`
+	return &http.Response{
+		Status:        "429 Too Many Requests",
+		StatusCode:    http.StatusTooManyRequests,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
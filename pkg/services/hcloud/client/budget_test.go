@@ -0,0 +1,161 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hcloudclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func rateLimitHeader(limit, remaining int, reset time.Time) http.Header {
+	header := http.Header{}
+	header.Set("RateLimit-Limit", strconv.Itoa(limit))
+	header.Set("RateLimit-Remaining", strconv.Itoa(remaining))
+	header.Set("RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	return header
+}
+
+var _ = Describe("rateLimitBudget", func() {
+	var (
+		budget *rateLimitBudget
+		now    time.Time
+	)
+
+	BeforeEach(func() {
+		now = time.Unix(1700000000, 0)
+		budget = newRateLimitBudget()
+		budget.now = func() time.Time { return now }
+		budget.sleep = func(_ context.Context, d time.Duration) error {
+			now = now.Add(d)
+			return nil
+		}
+	})
+
+	It("does not delay calls as long as the budget is unknown", func() {
+		Expect(budget.delay(PriorityNormal, now)).To(BeZero())
+	})
+
+	It("keeps a reserve for calls with high priority", func() {
+		// 99 of 3600 requests left, refilled with one request per second
+		budget.update(rateLimitHeader(3600, 99, now.Add(3501*time.Second)))
+
+		Expect(budget.delay(PriorityHigh, now)).To(BeZero())
+		Expect(budget.delay(PriorityNormal, now)).To(Equal(262 * time.Second))
+
+		Expect(budget.acquire(context.Background(), PriorityHigh)).To(Succeed())
+		Expect(budget.available(now)).To(BeNumerically("~", 98, 0.001))
+		Expect(budget.acquire(context.Background(), PriorityNormal)).To(MatchError(errBudgetExhausted))
+	})
+
+	It("waits until the budget has been refilled", func() {
+		budget.update(rateLimitHeader(3600, 0, now.Add(3600*time.Second)))
+		start := now
+
+		Expect(budget.acquire(context.Background(), PriorityHigh)).To(Succeed())
+		Expect(now.Sub(start)).To(Equal(time.Second))
+	})
+
+	It("does not wait beyond the deadline of the context", func() {
+		budget.update(rateLimitHeader(3600, 0, now.Add(3600*time.Second)))
+		ctx, cancel := context.WithDeadline(context.Background(), now.Add(500*time.Millisecond))
+		defer cancel()
+		start := now
+
+		Expect(budget.acquire(ctx, PriorityHigh)).To(MatchError(errBudgetExhausted))
+		Expect(now).To(Equal(start))
+	})
+
+	It("refills the budget until the reset", func() {
+		budget.update(rateLimitHeader(3600, 0, now.Add(3600*time.Second)))
+		Expect(budget.delay(PriorityHigh, now)).To(Equal(time.Second))
+
+		now = now.Add(time.Hour)
+		Expect(budget.available(now)).To(BeNumerically("~", 3600, 0.001))
+		Expect(budget.acquire(context.Background(), PriorityNormal)).To(Succeed())
+	})
+
+	It("gives deletions high priority", func() {
+		req := httptest.NewRequest(http.MethodDelete, "/servers/1", http.NoBody)
+		Expect(priorityFromRequest(req)).To(Equal(PriorityHigh))
+
+		req = httptest.NewRequest(http.MethodGet, "/servers", http.NoBody)
+		Expect(priorityFromRequest(req)).To(Equal(PriorityNormal))
+		Expect(priorityFromRequest(req.WithContext(WithPriority(req.Context(), PriorityHigh)))).To(Equal(PriorityHigh))
+	})
+})
+
+var _ = Describe("budgetTransport", func() {
+	It("fails calls with a rate limit error when the budget is not refilled in time", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			for key, values := range rateLimitHeader(3600, 0, time.Now().Add(time.Hour)) {
+				w.Header()[key] = values
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"ssh_keys":[],"meta":{"pagination":{"page":1,"per_page":50,"last_page":1,"total_entries":0}}}`))
+		}))
+		defer server.Close()
+
+		budget := newRateLimitBudget()
+		client := &realClient{client: hcloud.NewClient(
+			hcloud.WithEndpoint(server.URL),
+			hcloud.WithHTTPClient(&http.Client{Transport: &budgetTransport{budget: budget, next: http.DefaultTransport}}),
+		)}
+
+		_, err := client.ListSSHKeys(context.Background(), hcloud.SSHKeyListOpts{})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = client.ListSSHKeys(context.Background(), hcloud.SSHKeyListOpts{})
+		Expect(hcloud.IsError(err, hcloud.ErrorCodeRateLimitExceeded)).To(BeTrue())
+	})
+})
+
+var _ = Describe("factory", func() {
+	It("shares clients per token", func() {
+		factory := NewFactory()
+		Expect(factory.NewClient("token")).To(BeIdenticalTo(factory.NewClient("token")))
+		Expect(factory.NewClient("token")).ToNot(BeIdenticalTo(factory.NewClient("other-token")))
+	})
+
+	It("drops released clients", func() {
+		factory := NewFactory()
+		client := factory.NewClient("token")
+		factory.ReleaseClient("token")
+		Expect(factory.NewClient("token")).ToNot(BeIdenticalTo(client))
+	})
+
+	It("drops idle clients", func() {
+		now := time.Now()
+		f := &factory{clients: make(map[string]*factoryEntry), now: func() time.Time { return now }}
+		client := f.NewClient("token")
+		idleClient := f.NewClient("idle-token")
+
+		now = now.Add(clientIdleTimeout)
+		Expect(f.NewClient("token")).To(BeIdenticalTo(client))
+
+		now = now.Add(time.Second)
+		Expect(f.NewClient("token")).To(BeIdenticalTo(client))
+		Expect(f.clients).To(HaveLen(1))
+		Expect(f.NewClient("idle-token")).ToNot(BeIdenticalTo(idleClient))
+	})
+})
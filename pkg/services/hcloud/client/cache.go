@@ -0,0 +1,387 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hcloudclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// serverCacheTTL is the time servers are cached. It is short, as the status of servers changes outside of
+	// the controller.
+	serverCacheTTL = 10 * time.Second
+	// resourceCacheTTL is the time images, SSH keys and certificates are cached.
+	resourceCacheTTL = time.Minute
+	// serverTypeCacheTTL is the time server types are cached.
+	serverTypeCacheTTL = 10 * time.Minute
+)
+
+const (
+	methodListServers      = "ListServers"
+	methodListImages       = "ListImages"
+	methodGetImage         = "GetImage"
+	methodListSSHKeys      = "ListSSHKeys"
+	methodListServerTypes  = "ListServerTypes"
+	methodGetServerType    = "GetServerType"
+	methodListCertificates = "ListCertificates"
+)
+
+var (
+	cacheHits = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "caph_hcloud_cache_hits_total",
+			Help: "Number of HCloud API reads that were served from the cache.",
+		},
+		[]string{"method"},
+	)
+	cacheMisses = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "caph_hcloud_cache_misses_total",
+			Help: "Number of HCloud API reads that were not found in the cache.",
+		},
+		[]string{"method"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(cacheHits, cacheMisses)
+}
+
+// readCache stores results of read calls by method and options until their TTL expires.
+type readCache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	now     func() time.Time
+}
+
+type cacheEntry struct {
+	value   any
+	expires time.Time
+}
+
+func newReadCache() *readCache {
+	return &readCache{
+		entries: make(map[string]cacheEntry),
+		now:     time.Now,
+	}
+}
+
+// cacheKey returns the key of a call. The options are part of the key, so that e.g. calls with different
+// label selectors are cached separately.
+func cacheKey(method string, opts any) string {
+	data, err := json.Marshal(opts)
+	if err != nil {
+		return fmt.Sprintf("%s/%+v", method, opts)
+	}
+	return fmt.Sprintf("%s/%s", method, data)
+}
+
+func (c *readCache) get(method, key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		cacheMisses.WithLabelValues(method).Inc()
+		return nil, false
+	}
+	cacheHits.WithLabelValues(method).Inc()
+	return entry.value, true
+}
+
+func (c *readCache) set(key string, value any, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = cacheEntry{value: value, expires: c.now().Add(ttl)}
+}
+
+// invalidate removes all cached results of a method.
+func (c *readCache) invalidate(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		if strings.HasPrefix(key, method+"/") {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// cachedRead returns the cached result of a call or makes the call and caches its result. Errors are not cached.
+func cachedRead[T any](c *readCache, method string, opts any, ttl time.Duration, read func() (T, error)) (T, error) {
+	key := cacheKey(method, opts)
+	if value, ok := c.get(method, key); ok {
+		return value.(T), nil
+	}
+
+	value, err := read()
+	if err != nil {
+		return value, err
+	}
+	c.set(key, value, ttl)
+	return value, nil
+}
+
+// cachedClient caches the results of read calls that are made on nearly every reconcile. Mutations invalidate
+// the cached results they affect. Callers get copies of the cached objects, so that they can modify them without
+// affecting other callers.
+type cachedClient struct {
+	Client
+	cache *readCache
+}
+
+var _ Client = &cachedClient{}
+
+func newCachedClient(client Client) *cachedClient {
+	return &cachedClient{Client: client, cache: newReadCache()}
+}
+
+func (c *cachedClient) ListServers(ctx context.Context, opts hcloud.ServerListOpts) ([]*hcloud.Server, error) {
+	servers, err := cachedRead(c.cache, methodListServers, opts, serverCacheTTL, func() ([]*hcloud.Server, error) {
+		return c.Client.ListServers(ctx, opts)
+	})
+	return copyAll(servers, copyServer), err
+}
+
+func (c *cachedClient) ListImages(ctx context.Context, opts hcloud.ImageListOpts) ([]*hcloud.Image, error) {
+	images, err := cachedRead(c.cache, methodListImages, opts, resourceCacheTTL, func() ([]*hcloud.Image, error) {
+		return c.Client.ListImages(ctx, opts)
+	})
+	return copyAll(images, copyImage), err
+}
+
+func (c *cachedClient) GetImage(ctx context.Context, id int64) (*hcloud.Image, error) {
+	image, err := cachedRead(c.cache, methodGetImage, id, resourceCacheTTL, func() (*hcloud.Image, error) {
+		return c.Client.GetImage(ctx, id)
+	})
+	return copyImage(image), err
+}
+
+func (c *cachedClient) ListSSHKeys(ctx context.Context, opts hcloud.SSHKeyListOpts) ([]*hcloud.SSHKey, error) {
+	sshKeys, err := cachedRead(c.cache, methodListSSHKeys, opts, resourceCacheTTL, func() ([]*hcloud.SSHKey, error) {
+		return c.Client.ListSSHKeys(ctx, opts)
+	})
+	return copyAll(sshKeys, copySSHKey), err
+}
+
+func (c *cachedClient) ListServerTypes(ctx context.Context) ([]*hcloud.ServerType, error) {
+	serverTypes, err := cachedRead(c.cache, methodListServerTypes, nil, serverTypeCacheTTL, func() ([]*hcloud.ServerType, error) {
+		return c.Client.ListServerTypes(ctx)
+	})
+	return copyAll(serverTypes, copyServerType), err
+}
+
+func (c *cachedClient) GetServerType(ctx context.Context, name string) (*hcloud.ServerType, error) {
+	serverType, err := cachedRead(c.cache, methodGetServerType, name, serverTypeCacheTTL, func() (*hcloud.ServerType, error) {
+		return c.Client.GetServerType(ctx, name)
+	})
+	return copyServerType(serverType), err
+}
+
+func (c *cachedClient) ListCertificates(ctx context.Context, opts hcloud.CertificateListOpts) ([]*hcloud.Certificate, error) {
+	certificates, err := cachedRead(c.cache, methodListCertificates, opts, resourceCacheTTL, func() ([]*hcloud.Certificate, error) {
+		return c.Client.ListCertificates(ctx, opts)
+	})
+	return copyAll(certificates, copyCertificate), err
+}
+
+// copyAll copies a list of cached objects.
+func copyAll[T any](items []*T, copyItem func(*T) *T) []*T {
+	if items == nil {
+		return nil
+	}
+	copied := make([]*T, 0, len(items))
+	for _, item := range items {
+		copied = append(copied, copyItem(item))
+	}
+	return copied
+}
+
+// copyServer copies a cached server including its addresses, labels and lists. Referenced objects like the server
+// type or the image are copied shallowly.
+func copyServer(server *hcloud.Server) *hcloud.Server {
+	if server == nil {
+		return nil
+	}
+	copied := *server
+	copied.Labels = maps.Clone(server.Labels)
+
+	copied.PublicNet.IPv4.IP = copyIP(server.PublicNet.IPv4.IP)
+	copied.PublicNet.IPv6.IP = copyIP(server.PublicNet.IPv6.IP)
+	if network := server.PublicNet.IPv6.Network; network != nil {
+		copied.PublicNet.IPv6.Network = &net.IPNet{IP: copyIP(network.IP), Mask: append(net.IPMask(nil), network.Mask...)}
+	}
+	copied.PublicNet.IPv6.DNSPtr = maps.Clone(server.PublicNet.IPv6.DNSPtr)
+	copied.PublicNet.FloatingIPs = copyAll(server.PublicNet.FloatingIPs, shallowCopy[hcloud.FloatingIP])
+	copied.PublicNet.Firewalls = copyAll(server.PublicNet.Firewalls, shallowCopy[hcloud.ServerFirewallStatus])
+
+	if server.PrivateNet != nil {
+		copied.PrivateNet = make([]hcloud.ServerPrivateNet, 0, len(server.PrivateNet))
+		for _, privateNet := range server.PrivateNet {
+			privateNet.Network = shallowCopy(privateNet.Network)
+			privateNet.IP = copyIP(privateNet.IP)
+			aliases := privateNet.Aliases
+			privateNet.Aliases = nil
+			for _, alias := range aliases {
+				privateNet.Aliases = append(privateNet.Aliases, copyIP(alias))
+			}
+			copied.PrivateNet = append(copied.PrivateNet, privateNet)
+		}
+	}
+
+	copied.ServerType = copyServerType(server.ServerType)
+	copied.Datacenter = shallowCopy(server.Datacenter)
+	copied.ISO = shallowCopy(server.ISO)
+	copied.Image = copyImage(server.Image)
+	copied.Volumes = copyAll(server.Volumes, shallowCopy[hcloud.Volume])
+	copied.PlacementGroup = shallowCopy(server.PlacementGroup)
+	return &copied
+}
+
+func copyImage(image *hcloud.Image) *hcloud.Image {
+	if image == nil {
+		return nil
+	}
+	copied := *image
+	copied.Labels = maps.Clone(image.Labels)
+	return &copied
+}
+
+func copySSHKey(sshKey *hcloud.SSHKey) *hcloud.SSHKey {
+	if sshKey == nil {
+		return nil
+	}
+	copied := *sshKey
+	copied.Labels = maps.Clone(sshKey.Labels)
+	return &copied
+}
+
+func copyServerType(serverType *hcloud.ServerType) *hcloud.ServerType {
+	if serverType == nil {
+		return nil
+	}
+	copied := *serverType
+	copied.Pricings = append([]hcloud.ServerTypeLocationPricing(nil), serverType.Pricings...)
+	return &copied
+}
+
+func copyCertificate(certificate *hcloud.Certificate) *hcloud.Certificate {
+	if certificate == nil {
+		return nil
+	}
+	copied := *certificate
+	copied.Labels = maps.Clone(certificate.Labels)
+	copied.DomainNames = append([]string(nil), certificate.DomainNames...)
+	return &copied
+}
+
+func shallowCopy[T any](item *T) *T {
+	if item == nil {
+		return nil
+	}
+	copied := *item
+	return &copied
+}
+
+func copyIP(ip net.IP) net.IP {
+	if ip == nil {
+		return nil
+	}
+	return append(net.IP(nil), ip...)
+}
+
+// The following mutations change servers, so the cached servers are invalidated, even if the call failed.
+
+func (c *cachedClient) CreateServer(ctx context.Context, opts hcloud.ServerCreateOpts) (*hcloud.Server, error) {
+	defer c.cache.invalidate(methodListServers)
+	return c.Client.CreateServer(ctx, opts)
+}
+
+func (c *cachedClient) DeleteServer(ctx context.Context, server *hcloud.Server) error {
+	defer c.cache.invalidate(methodListServers)
+	return c.Client.DeleteServer(ctx, server)
+}
+
+func (c *cachedClient) UpdateServer(ctx context.Context, server *hcloud.Server, opts hcloud.ServerUpdateOpts) (*hcloud.Server, error) {
+	defer c.cache.invalidate(methodListServers)
+	return c.Client.UpdateServer(ctx, server, opts)
+}
+
+func (c *cachedClient) PowerOnServer(ctx context.Context, server *hcloud.Server) error {
+	defer c.cache.invalidate(methodListServers)
+	return c.Client.PowerOnServer(ctx, server)
+}
+
+func (c *cachedClient) ShutdownServer(ctx context.Context, server *hcloud.Server) error {
+	defer c.cache.invalidate(methodListServers)
+	return c.Client.ShutdownServer(ctx, server)
+}
+
+func (c *cachedClient) RebootServer(ctx context.Context, server *hcloud.Server) error {
+	defer c.cache.invalidate(methodListServers)
+	return c.Client.RebootServer(ctx, server)
+}
+
+func (c *cachedClient) AttachServerToNetwork(ctx context.Context, server *hcloud.Server, opts hcloud.ServerAttachToNetworkOpts) error {
+	defer c.cache.invalidate(methodListServers)
+	return c.Client.AttachServerToNetwork(ctx, server, opts)
+}
+
+func (c *cachedClient) AddServerToPlacementGroup(ctx context.Context, server *hcloud.Server, pg *hcloud.PlacementGroup) error {
+	defer c.cache.invalidate(methodListServers)
+	return c.Client.AddServerToPlacementGroup(ctx, server, pg)
+}
+
+func (c *cachedClient) AttachVolume(ctx context.Context, volume *hcloud.Volume, server *hcloud.Server) error {
+	defer c.cache.invalidate(methodListServers)
+	return c.Client.AttachVolume(ctx, volume, server)
+}
+
+func (c *cachedClient) AssignFloatingIP(ctx context.Context, floatingIP *hcloud.FloatingIP, server *hcloud.Server) error {
+	defer c.cache.invalidate(methodListServers)
+	return c.Client.AssignFloatingIP(ctx, floatingIP, server)
+}
+
+func (c *cachedClient) UnassignPrimaryIP(ctx context.Context, primaryIP *hcloud.PrimaryIP) error {
+	defer c.cache.invalidate(methodListServers)
+	return c.Client.UnassignPrimaryIP(ctx, primaryIP)
+}
+
+// The following mutations change certificates, so the cached certificates are invalidated, even if the call failed.
+
+func (c *cachedClient) CreateCertificate(ctx context.Context, opts hcloud.CertificateCreateOpts) (*hcloud.Certificate, error) {
+	defer c.cache.invalidate(methodListCertificates)
+	return c.Client.CreateCertificate(ctx, opts)
+}
+
+func (c *cachedClient) DeleteCertificate(ctx context.Context, id int64) error {
+	defer c.cache.invalidate(methodListCertificates)
+	return c.Client.DeleteCertificate(ctx, id)
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hcloudclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// countingClient counts the calls that reach the HCloud API.
+type countingClient struct {
+	Client
+	calls int
+	err   error
+}
+
+func (c *countingClient) ListServers(context.Context, hcloud.ServerListOpts) ([]*hcloud.Server, error) {
+	c.calls++
+	return []*hcloud.Server{{
+		ID:     1,
+		Labels: map[string]string{"caph-cluster-a": "owned"},
+		PublicNet: hcloud.ServerPublicNet{
+			IPv6: hcloud.ServerPublicNetIPv6{IP: net.ParseIP("2001:db8::1")},
+		},
+	}}, c.err
+}
+
+func (c *countingClient) GetServerType(_ context.Context, name string) (*hcloud.ServerType, error) {
+	c.calls++
+	return &hcloud.ServerType{Name: name}, c.err
+}
+
+func (c *countingClient) CreateServer(context.Context, hcloud.ServerCreateOpts) (*hcloud.Server, error) {
+	return &hcloud.Server{ID: 2}, nil
+}
+
+func counterValue(counter interface{ Write(*dto.Metric) error }) float64 {
+	var metric dto.Metric
+	Expect(counter.Write(&metric)).To(Succeed())
+	return metric.GetCounter().GetValue()
+}
+
+var _ = Describe("cachedClient", func() {
+	var (
+		api    *countingClient
+		client *cachedClient
+		now    time.Time
+		ctx    context.Context
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Now()
+		api = &countingClient{}
+		client = newCachedClient(api)
+		client.cache.now = func() time.Time { return now }
+	})
+
+	It("serves repeated reads from the cache until the TTL expires", func() {
+		hits := counterValue(cacheHits.WithLabelValues(methodListServers))
+		misses := counterValue(cacheMisses.WithLabelValues(methodListServers))
+		opts := hcloud.ServerListOpts{ListOpts: hcloud.ListOpts{LabelSelector: "caph-cluster-a==owned"}}
+
+		for i := 0; i < 3; i++ {
+			servers, err := client.ListServers(ctx, opts)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(servers).To(HaveLen(1))
+		}
+		Expect(api.calls).To(Equal(1))
+		Expect(counterValue(cacheHits.WithLabelValues(methodListServers))).To(Equal(hits + 2))
+		Expect(counterValue(cacheMisses.WithLabelValues(methodListServers))).To(Equal(misses + 1))
+
+		now = now.Add(serverCacheTTL)
+		_, err := client.ListServers(ctx, opts)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(api.calls).To(Equal(2))
+	})
+
+	It("caches reads with different options separately", func() {
+		_, err := client.ListServers(ctx, hcloud.ServerListOpts{ListOpts: hcloud.ListOpts{LabelSelector: "a"}})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = client.ListServers(ctx, hcloud.ServerListOpts{ListOpts: hcloud.ListOpts{LabelSelector: "b"}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(api.calls).To(Equal(2))
+
+		_, err = client.GetServerType(ctx, "cpx31")
+		Expect(err).ToNot(HaveOccurred())
+		serverType, err := client.GetServerType(ctx, "cpx31")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(serverType.Name).To(Equal("cpx31"))
+		Expect(api.calls).To(Equal(3))
+	})
+
+	It("invalidates cached servers after mutations", func() {
+		_, err := client.ListServers(ctx, hcloud.ServerListOpts{})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = client.GetServerType(ctx, "cpx31")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = client.CreateServer(ctx, hcloud.ServerCreateOpts{})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = client.ListServers(ctx, hcloud.ServerListOpts{})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = client.GetServerType(ctx, "cpx31")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(api.calls).To(Equal(3))
+	})
+
+	It("returns copies of the cached servers", func() {
+		servers, err := client.ListServers(ctx, hcloud.ServerListOpts{})
+		Expect(err).ToNot(HaveOccurred())
+		servers[0].Name = "modified"
+		servers[0].Labels["caph-cluster-a"] = "modified"
+		servers[0].PublicNet.IPv6.IP[15]++
+
+		servers, err = client.ListServers(ctx, hcloud.ServerListOpts{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(api.calls).To(Equal(1))
+		Expect(servers[0].Name).To(BeEmpty())
+		Expect(servers[0].Labels).To(Equal(map[string]string{"caph-cluster-a": "owned"}))
+		Expect(servers[0].PublicNet.IPv6.IP.String()).To(Equal("2001:db8::1"))
+	})
+
+	It("does not cache errors", func() {
+		api.err = errors.New("unavailable")
+		_, err := client.ListServers(ctx, hcloud.ServerListOpts{})
+		Expect(err).To(HaveOccurred())
+
+		api.err = nil
+		_, err = client.ListServers(ctx, hcloud.ServerListOpts{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(api.calls).To(Equal(2))
+	})
+})
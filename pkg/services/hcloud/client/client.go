@@ -19,9 +19,14 @@ package hcloudclient
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net"
+	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	"github.com/prometheus/client_golang/prometheus"
@@ -42,22 +47,31 @@ type Client interface {
 	CreateLoadBalancer(context.Context, hcloud.LoadBalancerCreateOpts) (*hcloud.LoadBalancer, error)
 	DeleteLoadBalancer(context.Context, int64) error
 	ListLoadBalancers(context.Context, hcloud.LoadBalancerListOpts) ([]*hcloud.LoadBalancer, error)
+	GetLoadBalancer(context.Context, int64) (*hcloud.LoadBalancer, error)
 	AttachLoadBalancerToNetwork(context.Context, *hcloud.LoadBalancer, hcloud.LoadBalancerAttachToNetworkOpts) error
 	ChangeLoadBalancerType(context.Context, *hcloud.LoadBalancer, hcloud.LoadBalancerChangeTypeOpts) error
 	ChangeLoadBalancerAlgorithm(context.Context, *hcloud.LoadBalancer, hcloud.LoadBalancerChangeAlgorithmOpts) error
 	UpdateLoadBalancer(context.Context, *hcloud.LoadBalancer, hcloud.LoadBalancerUpdateOpts) (*hcloud.LoadBalancer, error)
+	EnableLoadBalancerPublicInterface(context.Context, *hcloud.LoadBalancer) error
+	DisableLoadBalancerPublicInterface(context.Context, *hcloud.LoadBalancer) error
 	AddTargetServerToLoadBalancer(context.Context, hcloud.LoadBalancerAddServerTargetOpts, *hcloud.LoadBalancer) error
 	DeleteTargetServerOfLoadBalancer(context.Context, *hcloud.LoadBalancer, *hcloud.Server) error
 	AddIPTargetToLoadBalancer(context.Context, hcloud.LoadBalancerAddIPTargetOpts, *hcloud.LoadBalancer) error
 	DeleteIPTargetOfLoadBalancer(context.Context, *hcloud.LoadBalancer, net.IP) error
 	AddServiceToLoadBalancer(context.Context, *hcloud.LoadBalancer, hcloud.LoadBalancerAddServiceOpts) error
 	DeleteServiceFromLoadBalancer(context.Context, *hcloud.LoadBalancer, int) error
+	UpdateServiceOfLoadBalancer(context.Context, *hcloud.LoadBalancer, int, hcloud.LoadBalancerUpdateServiceOpts) error
+	CreateCertificate(context.Context, hcloud.CertificateCreateOpts) (*hcloud.Certificate, error)
+	ListCertificates(context.Context, hcloud.CertificateListOpts) ([]*hcloud.Certificate, error)
+	DeleteCertificate(context.Context, int64) error
 	ListImages(context.Context, hcloud.ImageListOpts) ([]*hcloud.Image, error)
+	GetImage(context.Context, int64) (*hcloud.Image, error)
 	CreateServer(context.Context, hcloud.ServerCreateOpts) (*hcloud.Server, error)
 	AttachServerToNetwork(context.Context, *hcloud.Server, hcloud.ServerAttachToNetworkOpts) error
 	ListServers(context.Context, hcloud.ServerListOpts) ([]*hcloud.Server, error)
 	GetServer(context.Context, int64) (*hcloud.Server, error)
 	DeleteServer(context.Context, *hcloud.Server) error
+	UpdateServer(context.Context, *hcloud.Server, hcloud.ServerUpdateOpts) (*hcloud.Server, error)
 	ListServerTypes(context.Context) ([]*hcloud.ServerType, error)
 	GetServerType(context.Context, string) (*hcloud.ServerType, error)
 	PowerOnServer(context.Context, *hcloud.Server) error
@@ -66,23 +80,76 @@ type Client interface {
 	CreateNetwork(context.Context, hcloud.NetworkCreateOpts) (*hcloud.Network, error)
 	ListNetworks(context.Context, hcloud.NetworkListOpts) ([]*hcloud.Network, error)
 	DeleteNetwork(context.Context, *hcloud.Network) error
+	GetNetwork(context.Context, int64) (*hcloud.Network, error)
+	UpdateNetwork(context.Context, *hcloud.Network, hcloud.NetworkUpdateOpts) (*hcloud.Network, error)
+	AddSubnetToNetwork(context.Context, *hcloud.Network, hcloud.NetworkSubnet) error
+	DeleteSubnetFromNetwork(context.Context, *hcloud.Network, hcloud.NetworkSubnet) error
+	AddRouteToNetwork(context.Context, *hcloud.Network, hcloud.NetworkRoute) error
+	DeleteRouteFromNetwork(context.Context, *hcloud.Network, hcloud.NetworkRoute) error
 	ListSSHKeys(context.Context, hcloud.SSHKeyListOpts) ([]*hcloud.SSHKey, error)
 	CreatePlacementGroup(context.Context, hcloud.PlacementGroupCreateOpts) (*hcloud.PlacementGroup, error)
 	DeletePlacementGroup(context.Context, int64) error
 	ListPlacementGroups(context.Context, hcloud.PlacementGroupListOpts) ([]*hcloud.PlacementGroup, error)
 	AddServerToPlacementGroup(context.Context, *hcloud.Server, *hcloud.PlacementGroup) error
-}
+	UpdatePlacementGroup(context.Context, *hcloud.PlacementGroup, hcloud.PlacementGroupUpdateOpts) (*hcloud.PlacementGroup, error)
+	CreateFirewall(context.Context, hcloud.FirewallCreateOpts) (*hcloud.Firewall, error)
+	DeleteFirewall(context.Context, int64) error
+	ListFirewalls(context.Context, hcloud.FirewallListOpts) ([]*hcloud.Firewall, error)
+	SetFirewallRules(context.Context, *hcloud.Firewall, hcloud.FirewallSetRulesOpts) error
+	ApplyFirewallResources(context.Context, *hcloud.Firewall, []hcloud.FirewallResource) error
+	RemoveFirewallResources(context.Context, *hcloud.Firewall, []hcloud.FirewallResource) error
+	CreateVolume(context.Context, hcloud.VolumeCreateOpts) (*hcloud.Volume, error)
+	ListVolumes(context.Context, hcloud.VolumeListOpts) ([]*hcloud.Volume, error)
+	AttachVolume(context.Context, *hcloud.Volume, *hcloud.Server) error
+	DeleteVolume(context.Context, *hcloud.Volume) error
+	CreateFloatingIP(context.Context, hcloud.FloatingIPCreateOpts) (*hcloud.FloatingIP, error)
+	ListFloatingIPs(context.Context, hcloud.FloatingIPListOpts) ([]*hcloud.FloatingIP, error)
+	AssignFloatingIP(context.Context, *hcloud.FloatingIP, *hcloud.Server) error
+	DeleteFloatingIP(context.Context, *hcloud.FloatingIP) error
+	CreatePrimaryIP(context.Context, hcloud.PrimaryIPCreateOpts) (*hcloud.PrimaryIP, error)
+	ListPrimaryIPs(context.Context, hcloud.PrimaryIPListOpts) ([]*hcloud.PrimaryIP, error)
+	UnassignPrimaryIP(context.Context, *hcloud.PrimaryIP) error
+	DeletePrimaryIP(context.Context, *hcloud.PrimaryIP) error
+	ListDatacenters(context.Context, hcloud.DatacenterListOpts) ([]*hcloud.Datacenter, error)
+}
+
+// clientIdleTimeout is the time after which the client of a token that has not been used is dropped. The rate
+// limit of a token is refilled within an hour, so the budget of a client that has been idle that long is lost
+// anyway.
+const clientIdleTimeout = time.Hour
 
 // Factory is the interface for creating new Client objects.
 type Factory interface {
 	NewClient(hcloudToken string) Client
+	// ReleaseClient drops the shared client of a token, e.g. because the token has been retired. Holders of the
+	// client can keep using it.
+	ReleaseClient(hcloudToken string)
 }
 
-// NewClient creates new HCloud clients.
+// NewClient returns the HCloud client of the token. Clients are shared per token, so that all reconciles
+// using the same token share its rate limit budget and its cache.
 func (f *factory) NewClient(hcloudToken string) Client {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+	for key, entry := range f.clients {
+		if now.Sub(entry.lastUsed) > clientIdleTimeout {
+			delete(f.clients, key)
+		}
+	}
+
+	key := tokenKey(hcloudToken)
+	if entry, ok := f.clients[key]; ok {
+		entry.lastUsed = now
+		return entry.client
+	}
+
+	httpClient := &http.Client{}
 	opts := []hcloud.ClientOption{
 		hcloud.WithToken(hcloudToken),
 		hcloud.WithApplication("cluster-api-provider-hetzner", caphversion.Get().String()),
+		hcloud.WithHTTPClient(httpClient),
 	}
 
 	// controller-runtime hides their default prometheus registry it uses (and exposes via HTTP) behind a custom
@@ -91,16 +158,49 @@ func (f *factory) NewClient(hcloudToken string) Client {
 		opts = append(opts, hcloud.WithInstrumentation(registry))
 	}
 
-	return &realClient{client: hcloud.NewClient(opts...)}
+	c := newCachedClient(&realClient{client: hcloud.NewClient(opts...)})
+
+	// hcloud-go sets the instrumented transport when creating the client, so the budget has to wrap it afterwards
+	next := httpClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	httpClient.Transport = &budgetTransport{budget: newRateLimitBudget(), next: next}
+
+	f.clients[key] = &factoryEntry{client: c, lastUsed: now}
+	return c
 }
 
-type factory struct{}
+// ReleaseClient implements the ReleaseClient method of the Factory interface.
+func (f *factory) ReleaseClient(hcloudToken string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.clients, tokenKey(hcloudToken))
+}
+
+// tokenKey returns the key of a token in the client pool.
+func tokenKey(hcloudToken string) string {
+	hash := sha256.Sum256([]byte(hcloudToken))
+	return hex.EncodeToString(hash[:])
+}
+
+type factory struct {
+	mu      sync.Mutex
+	clients map[string]*factoryEntry
+	now     func() time.Time
+}
+
+type factoryEntry struct {
+	client   Client
+	lastUsed time.Time
+}
 
 var _ = Factory(&factory{})
 
 // NewFactory creates a new factory for HCloud clients.
 func NewFactory() Factory {
-	return &factory{}
+	return &factory{clients: make(map[string]*factoryEntry), now: time.Now}
 }
 
 var _ Client = &realClient{}
@@ -130,6 +230,11 @@ func (c *realClient) ListLoadBalancers(ctx context.Context, opts hcloud.LoadBala
 	return resp, err
 }
 
+func (c *realClient) GetLoadBalancer(ctx context.Context, id int64) (*hcloud.LoadBalancer, error) {
+	res, _, err := c.client.LoadBalancer.GetByID(ctx, id)
+	return res, err
+}
+
 func (c *realClient) AttachLoadBalancerToNetwork(ctx context.Context, lb *hcloud.LoadBalancer, opts hcloud.LoadBalancerAttachToNetworkOpts) error {
 	_, _, err := c.client.LoadBalancer.AttachToNetwork(ctx, lb, opts)
 	return err
@@ -150,6 +255,16 @@ func (c *realClient) UpdateLoadBalancer(ctx context.Context, lb *hcloud.LoadBala
 	return res, err
 }
 
+func (c *realClient) EnableLoadBalancerPublicInterface(ctx context.Context, lb *hcloud.LoadBalancer) error {
+	_, _, err := c.client.LoadBalancer.EnablePublicInterface(ctx, lb)
+	return err
+}
+
+func (c *realClient) DisableLoadBalancerPublicInterface(ctx context.Context, lb *hcloud.LoadBalancer) error {
+	_, _, err := c.client.LoadBalancer.DisablePublicInterface(ctx, lb)
+	return err
+}
+
 func (c *realClient) AddTargetServerToLoadBalancer(ctx context.Context, opts hcloud.LoadBalancerAddServerTargetOpts, lb *hcloud.LoadBalancer) error {
 	_, _, err := c.client.LoadBalancer.AddServerTarget(ctx, lb, opts)
 	return err
@@ -189,10 +304,34 @@ func (c *realClient) DeleteServiceFromLoadBalancer(ctx context.Context, lb *hclo
 	return err
 }
 
+func (c *realClient) UpdateServiceOfLoadBalancer(ctx context.Context, lb *hcloud.LoadBalancer, listenPort int, opts hcloud.LoadBalancerUpdateServiceOpts) error {
+	_, _, err := c.client.LoadBalancer.UpdateService(ctx, lb, listenPort, opts)
+	return err
+}
+
+func (c *realClient) CreateCertificate(ctx context.Context, opts hcloud.CertificateCreateOpts) (*hcloud.Certificate, error) {
+	res, _, err := c.client.Certificate.CreateCertificate(ctx, opts)
+	return res.Certificate, err
+}
+
+func (c *realClient) ListCertificates(ctx context.Context, opts hcloud.CertificateListOpts) ([]*hcloud.Certificate, error) {
+	return c.client.Certificate.AllWithOpts(ctx, opts)
+}
+
+func (c *realClient) DeleteCertificate(ctx context.Context, id int64) error {
+	_, err := c.client.Certificate.Delete(ctx, &hcloud.Certificate{ID: id})
+	return err
+}
+
 func (c *realClient) ListImages(ctx context.Context, opts hcloud.ImageListOpts) ([]*hcloud.Image, error) {
 	return c.client.Image.AllWithOpts(ctx, opts)
 }
 
+func (c *realClient) GetImage(ctx context.Context, id int64) (*hcloud.Image, error) {
+	image, _, err := c.client.Image.GetByID(ctx, id)
+	return image, err
+}
+
 func (c *realClient) CreateServer(ctx context.Context, opts hcloud.ServerCreateOpts) (*hcloud.Server, error) {
 	res, _, err := c.client.Server.Create(ctx, opts)
 	return res.Server, err
@@ -252,6 +391,11 @@ func (c *realClient) DeleteServer(ctx context.Context, server *hcloud.Server) er
 	return err
 }
 
+func (c *realClient) UpdateServer(ctx context.Context, server *hcloud.Server, opts hcloud.ServerUpdateOpts) (*hcloud.Server, error) {
+	res, _, err := c.client.Server.Update(ctx, server, opts)
+	return res, err
+}
+
 func (c *realClient) CreateNetwork(ctx context.Context, opts hcloud.NetworkCreateOpts) (*hcloud.Network, error) {
 	res, _, err := c.client.Network.Create(ctx, opts)
 	return res, err
@@ -270,6 +414,36 @@ func (c *realClient) DeleteNetwork(ctx context.Context, network *hcloud.Network)
 	return err
 }
 
+func (c *realClient) GetNetwork(ctx context.Context, id int64) (*hcloud.Network, error) {
+	res, _, err := c.client.Network.GetByID(ctx, id)
+	return res, err
+}
+
+func (c *realClient) UpdateNetwork(ctx context.Context, network *hcloud.Network, opts hcloud.NetworkUpdateOpts) (*hcloud.Network, error) {
+	res, _, err := c.client.Network.Update(ctx, network, opts)
+	return res, err
+}
+
+func (c *realClient) AddSubnetToNetwork(ctx context.Context, network *hcloud.Network, subnet hcloud.NetworkSubnet) error {
+	_, _, err := c.client.Network.AddSubnet(ctx, network, hcloud.NetworkAddSubnetOpts{Subnet: subnet})
+	return err
+}
+
+func (c *realClient) DeleteSubnetFromNetwork(ctx context.Context, network *hcloud.Network, subnet hcloud.NetworkSubnet) error {
+	_, _, err := c.client.Network.DeleteSubnet(ctx, network, hcloud.NetworkDeleteSubnetOpts{Subnet: subnet})
+	return err
+}
+
+func (c *realClient) AddRouteToNetwork(ctx context.Context, network *hcloud.Network, route hcloud.NetworkRoute) error {
+	_, _, err := c.client.Network.AddRoute(ctx, network, hcloud.NetworkAddRouteOpts{Route: route})
+	return err
+}
+
+func (c *realClient) DeleteRouteFromNetwork(ctx context.Context, network *hcloud.Network, route hcloud.NetworkRoute) error {
+	_, _, err := c.client.Network.DeleteRoute(ctx, network, hcloud.NetworkDeleteRouteOpts{Route: route})
+	return err
+}
+
 func (c *realClient) ListSSHKeys(ctx context.Context, opts hcloud.SSHKeyListOpts) ([]*hcloud.SSHKey, error) {
 	res, _, err := c.client.SSHKey.List(ctx, opts)
 	return res, err
@@ -293,3 +467,121 @@ func (c *realClient) AddServerToPlacementGroup(ctx context.Context, server *hclo
 	_, _, err := c.client.Server.AddToPlacementGroup(ctx, server, pg)
 	return err
 }
+
+func (c *realClient) UpdatePlacementGroup(ctx context.Context, pg *hcloud.PlacementGroup, opts hcloud.PlacementGroupUpdateOpts) (*hcloud.PlacementGroup, error) {
+	res, _, err := c.client.PlacementGroup.Update(ctx, pg, opts)
+	return res, err
+}
+
+func (c *realClient) CreateFirewall(ctx context.Context, opts hcloud.FirewallCreateOpts) (*hcloud.Firewall, error) {
+	res, _, err := c.client.Firewall.Create(ctx, opts)
+	return res.Firewall, err
+}
+
+func (c *realClient) DeleteFirewall(ctx context.Context, id int64) error {
+	_, err := c.client.Firewall.Delete(ctx, &hcloud.Firewall{ID: id})
+	return err
+}
+
+func (c *realClient) ListFirewalls(ctx context.Context, opts hcloud.FirewallListOpts) ([]*hcloud.Firewall, error) {
+	resp, err := c.client.Firewall.AllWithOpts(ctx, opts)
+	if err != nil && strings.Contains(err.Error(), errStringUnauthorized) {
+		return resp, fmt.Errorf("%w: %w", ErrUnauthorized, err)
+	}
+	return resp, err
+}
+
+func (c *realClient) SetFirewallRules(ctx context.Context, firewall *hcloud.Firewall, opts hcloud.FirewallSetRulesOpts) error {
+	_, _, err := c.client.Firewall.SetRules(ctx, firewall, opts)
+	return err
+}
+
+func (c *realClient) ApplyFirewallResources(ctx context.Context, firewall *hcloud.Firewall, resources []hcloud.FirewallResource) error {
+	_, _, err := c.client.Firewall.ApplyResources(ctx, firewall, resources)
+	return err
+}
+
+func (c *realClient) RemoveFirewallResources(ctx context.Context, firewall *hcloud.Firewall, resources []hcloud.FirewallResource) error {
+	_, _, err := c.client.Firewall.RemoveResources(ctx, firewall, resources)
+	return err
+}
+
+func (c *realClient) CreateVolume(ctx context.Context, opts hcloud.VolumeCreateOpts) (*hcloud.Volume, error) {
+	res, _, err := c.client.Volume.Create(ctx, opts)
+	return res.Volume, err
+}
+
+func (c *realClient) ListVolumes(ctx context.Context, opts hcloud.VolumeListOpts) ([]*hcloud.Volume, error) {
+	resp, err := c.client.Volume.AllWithOpts(ctx, opts)
+	if err != nil && strings.Contains(err.Error(), errStringUnauthorized) {
+		return resp, fmt.Errorf("%w: %w", ErrUnauthorized, err)
+	}
+	return resp, err
+}
+
+func (c *realClient) AttachVolume(ctx context.Context, volume *hcloud.Volume, server *hcloud.Server) error {
+	_, _, err := c.client.Volume.Attach(ctx, volume, server)
+	return err
+}
+
+func (c *realClient) DeleteVolume(ctx context.Context, volume *hcloud.Volume) error {
+	_, err := c.client.Volume.Delete(ctx, volume)
+	return err
+}
+
+func (c *realClient) CreateFloatingIP(ctx context.Context, opts hcloud.FloatingIPCreateOpts) (*hcloud.FloatingIP, error) {
+	res, _, err := c.client.FloatingIP.Create(ctx, opts)
+	return res.FloatingIP, err
+}
+
+func (c *realClient) ListFloatingIPs(ctx context.Context, opts hcloud.FloatingIPListOpts) ([]*hcloud.FloatingIP, error) {
+	resp, err := c.client.FloatingIP.AllWithOpts(ctx, opts)
+	if err != nil && strings.Contains(err.Error(), errStringUnauthorized) {
+		return resp, fmt.Errorf("%w: %w", ErrUnauthorized, err)
+	}
+	return resp, err
+}
+
+func (c *realClient) AssignFloatingIP(ctx context.Context, floatingIP *hcloud.FloatingIP, server *hcloud.Server) error {
+	_, _, err := c.client.FloatingIP.Assign(ctx, floatingIP, server)
+	return err
+}
+
+func (c *realClient) DeleteFloatingIP(ctx context.Context, floatingIP *hcloud.FloatingIP) error {
+	_, err := c.client.FloatingIP.Delete(ctx, floatingIP)
+	return err
+}
+
+func (c *realClient) CreatePrimaryIP(ctx context.Context, opts hcloud.PrimaryIPCreateOpts) (*hcloud.PrimaryIP, error) {
+	res, _, err := c.client.PrimaryIP.Create(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return res.PrimaryIP, nil
+}
+
+func (c *realClient) ListPrimaryIPs(ctx context.Context, opts hcloud.PrimaryIPListOpts) ([]*hcloud.PrimaryIP, error) {
+	resp, err := c.client.PrimaryIP.AllWithOpts(ctx, opts)
+	if err != nil && strings.Contains(err.Error(), errStringUnauthorized) {
+		return resp, fmt.Errorf("%w: %w", ErrUnauthorized, err)
+	}
+	return resp, err
+}
+
+func (c *realClient) UnassignPrimaryIP(ctx context.Context, primaryIP *hcloud.PrimaryIP) error {
+	_, _, err := c.client.PrimaryIP.Unassign(ctx, primaryIP.ID)
+	return err
+}
+
+func (c *realClient) DeletePrimaryIP(ctx context.Context, primaryIP *hcloud.PrimaryIP) error {
+	_, err := c.client.PrimaryIP.Delete(ctx, primaryIP)
+	return err
+}
+
+func (c *realClient) ListDatacenters(ctx context.Context, opts hcloud.DatacenterListOpts) ([]*hcloud.Datacenter, error) {
+	resp, err := c.client.Datacenter.AllWithOpts(ctx, opts)
+	if err != nil && strings.Contains(err.Error(), errStringUnauthorized) {
+		return resp, fmt.Errorf("%w: %w", ErrUnauthorized, err)
+	}
+	return resp, err
+}
@@ -43,11 +43,21 @@ type cacheHCloudClient struct {
 	placementGroupCache     placementGroupCache
 	loadBalancerCache       loadBalancerCache
 	networkCache            networkCache
+	firewallCache           firewallCache
+	volumeCache             volumeCache
+	floatingIPCache         floatingIPCache
+	primaryIPCache          primaryIPCache
+	certificateCache        certificateCache
 	counterMutex            sync.Mutex
 	serverIDCounter         int64
 	placementGroupIDCounter int64
 	loadBalancerIDCounter   int64
 	networkIDCounter        int64
+	firewallIDCounter       int64
+	volumeIDCounter         int64
+	floatingIPIDCounter     int64
+	primaryIPIDCounter      int64
+	certificateIDCounter    int64
 }
 
 // NewClient gives reference to the fake client using cache for HCloud API.
@@ -55,6 +65,9 @@ func (f *cacheHCloudClientFactory) NewClient(string) hcloudclient.Client {
 	return cacheHCloudClientInstance
 }
 
+// ReleaseClient implements the ReleaseClient method of the Factory interface. The fake client is never released.
+func (f *cacheHCloudClientFactory) ReleaseClient(string) {}
+
 // Close implements Close method of hcloud client interface.
 func (c *cacheHCloudClient) Close() {
 	c.counterMutex.Lock()
@@ -64,6 +77,11 @@ func (c *cacheHCloudClient) Close() {
 	cacheHCloudClientInstance.networkCache = networkCache{}
 	cacheHCloudClientInstance.loadBalancerCache = loadBalancerCache{}
 	cacheHCloudClientInstance.placementGroupCache = placementGroupCache{}
+	cacheHCloudClientInstance.firewallCache = firewallCache{}
+	cacheHCloudClientInstance.volumeCache = volumeCache{}
+	cacheHCloudClientInstance.floatingIPCache = floatingIPCache{}
+	cacheHCloudClientInstance.primaryIPCache = primaryIPCache{}
+	cacheHCloudClientInstance.certificateCache = certificateCache{}
 
 	cacheHCloudClientInstance.serverCache = serverCache{
 		idMap:   make(map[int64]*hcloud.Server),
@@ -81,11 +99,36 @@ func (c *cacheHCloudClient) Close() {
 		idMap:   make(map[int64]*hcloud.Network),
 		nameMap: make(map[string]struct{}),
 	}
+	cacheHCloudClientInstance.firewallCache = firewallCache{
+		idMap:   make(map[int64]*hcloud.Firewall),
+		nameMap: make(map[string]struct{}),
+	}
+	cacheHCloudClientInstance.volumeCache = volumeCache{
+		idMap:   make(map[int64]*hcloud.Volume),
+		nameMap: make(map[string]struct{}),
+	}
+	cacheHCloudClientInstance.floatingIPCache = floatingIPCache{
+		idMap:   make(map[int64]*hcloud.FloatingIP),
+		nameMap: make(map[string]struct{}),
+	}
+	cacheHCloudClientInstance.primaryIPCache = primaryIPCache{
+		idMap:   make(map[int64]*hcloud.PrimaryIP),
+		nameMap: make(map[string]struct{}),
+	}
+	cacheHCloudClientInstance.certificateCache = certificateCache{
+		idMap:   make(map[int64]*hcloud.Certificate),
+		nameMap: make(map[string]struct{}),
+	}
 
 	cacheHCloudClientInstance.serverIDCounter = 0
 	cacheHCloudClientInstance.placementGroupIDCounter = 0
 	cacheHCloudClientInstance.loadBalancerIDCounter = 0
 	cacheHCloudClientInstance.networkIDCounter = 0
+	cacheHCloudClientInstance.firewallIDCounter = 0
+	cacheHCloudClientInstance.volumeIDCounter = 0
+	cacheHCloudClientInstance.floatingIPIDCounter = 0
+	cacheHCloudClientInstance.primaryIPIDCounter = 0
+	cacheHCloudClientInstance.certificateIDCounter = 0
 }
 
 type cacheHCloudClientFactory struct{}
@@ -107,6 +150,26 @@ var cacheHCloudClientInstance = &cacheHCloudClient{
 		idMap:   make(map[int64]*hcloud.Network),
 		nameMap: make(map[string]struct{}),
 	},
+	firewallCache: firewallCache{
+		idMap:   make(map[int64]*hcloud.Firewall),
+		nameMap: make(map[string]struct{}),
+	},
+	volumeCache: volumeCache{
+		idMap:   make(map[int64]*hcloud.Volume),
+		nameMap: make(map[string]struct{}),
+	},
+	floatingIPCache: floatingIPCache{
+		idMap:   make(map[int64]*hcloud.FloatingIP),
+		nameMap: make(map[string]struct{}),
+	},
+	primaryIPCache: primaryIPCache{
+		idMap:   make(map[int64]*hcloud.PrimaryIP),
+		nameMap: make(map[string]struct{}),
+	},
+	certificateCache: certificateCache{
+		idMap:   make(map[int64]*hcloud.Certificate),
+		nameMap: make(map[string]struct{}),
+	},
 }
 
 // NewHCloudClientFactory creates new fake HCloud client factories using cache.
@@ -136,6 +199,31 @@ type placementGroupCache struct {
 	nameMap map[string]struct{}
 }
 
+type firewallCache struct {
+	idMap   map[int64]*hcloud.Firewall
+	nameMap map[string]struct{}
+}
+
+type volumeCache struct {
+	idMap   map[int64]*hcloud.Volume
+	nameMap map[string]struct{}
+}
+
+type floatingIPCache struct {
+	idMap   map[int64]*hcloud.FloatingIP
+	nameMap map[string]struct{}
+}
+
+type primaryIPCache struct {
+	idMap   map[int64]*hcloud.PrimaryIP
+	nameMap map[string]struct{}
+}
+
+type certificateCache struct {
+	idMap   map[int64]*hcloud.Certificate
+	nameMap map[string]struct{}
+}
+
 var defaultSSHKey = hcloud.SSHKey{
 	ID:          1,
 	Name:        "testsshkey",
@@ -164,14 +252,9 @@ func (c *cacheHCloudClient) CreateLoadBalancer(_ context.Context, opts hcloud.Lo
 		Algorithm:        *opts.Algorithm,
 		LoadBalancerType: opts.LoadBalancerType,
 		Location:         opts.Location,
-		PublicNet: hcloud.LoadBalancerPublicNet{
-			IPv4: hcloud.LoadBalancerPublicNetIPv4{
-				IP: net.IP("1.2.3.4"),
-			},
-			IPv6: hcloud.LoadBalancerPublicNetIPv6{
-				IP: net.IP("2001:db8::1"),
-			},
-		},
+	}
+	if opts.PublicInterface == nil || *opts.PublicInterface {
+		lb.PublicNet = loadBalancerPublicNet()
 	}
 	if opts.Network != nil {
 		lb.PrivateNet = append(lb.PrivateNet, hcloud.LoadBalancerPrivateNet{
@@ -225,6 +308,15 @@ func (c *cacheHCloudClient) ListLoadBalancers(_ context.Context, opts hcloud.Loa
 	return lbs, nil
 }
 
+func (c *cacheHCloudClient) GetLoadBalancer(_ context.Context, id int64) (*hcloud.LoadBalancer, error) {
+	lb, found := c.loadBalancerCache.idMap[id]
+	if !found {
+		// the API returns nil without error if the load balancer does not exist
+		return nil, nil
+	}
+	return lb, nil
+}
+
 func (c *cacheHCloudClient) AttachLoadBalancerToNetwork(_ context.Context, lb *hcloud.LoadBalancer, opts hcloud.LoadBalancerAttachToNetworkOpts) error {
 	// Check if loadBalancer exists
 	if _, found := c.loadBalancerCache.idMap[lb.ID]; !found {
@@ -247,7 +339,7 @@ func (c *cacheHCloudClient) AttachLoadBalancerToNetwork(_ context.Context, lb *h
 	// Add it
 	c.loadBalancerCache.idMap[lb.ID].PrivateNet = append(
 		c.loadBalancerCache.idMap[lb.ID].PrivateNet,
-		hcloud.LoadBalancerPrivateNet{IP: network.IPRange.IP},
+		hcloud.LoadBalancerPrivateNet{Network: network, IP: network.IPRange.IP},
 	)
 	return nil
 }
@@ -274,6 +366,38 @@ func (c *cacheHCloudClient) ChangeLoadBalancerAlgorithm(_ context.Context, lb *h
 	return nil
 }
 
+func (c *cacheHCloudClient) EnableLoadBalancerPublicInterface(_ context.Context, lb *hcloud.LoadBalancer) error {
+	// Check if loadBalancer exists
+	if _, found := c.loadBalancerCache.idMap[lb.ID]; !found {
+		return hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+	}
+
+	c.loadBalancerCache.idMap[lb.ID].PublicNet = loadBalancerPublicNet()
+	return nil
+}
+
+func (c *cacheHCloudClient) DisableLoadBalancerPublicInterface(_ context.Context, lb *hcloud.LoadBalancer) error {
+	// Check if loadBalancer exists
+	if _, found := c.loadBalancerCache.idMap[lb.ID]; !found {
+		return hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+	}
+
+	c.loadBalancerCache.idMap[lb.ID].PublicNet = hcloud.LoadBalancerPublicNet{}
+	return nil
+}
+
+func loadBalancerPublicNet() hcloud.LoadBalancerPublicNet {
+	return hcloud.LoadBalancerPublicNet{
+		Enabled: true,
+		IPv4: hcloud.LoadBalancerPublicNetIPv4{
+			IP: net.IP("1.2.3.4"),
+		},
+		IPv6: hcloud.LoadBalancerPublicNetIPv6{
+			IP: net.IP("2001:db8::1"),
+		},
+	}
+}
+
 func (c *cacheHCloudClient) UpdateLoadBalancer(_ context.Context, lb *hcloud.LoadBalancer, opts hcloud.LoadBalancerUpdateOpts) (*hcloud.LoadBalancer, error) {
 	// Check if loadBalancer exists
 	if _, found := c.loadBalancerCache.idMap[lb.ID]; !found {
@@ -392,9 +516,180 @@ func (c *cacheHCloudClient) AddServiceToLoadBalancer(_ context.Context, lb *hclo
 		}
 	}
 
+	service := hcloud.LoadBalancerService{
+		Protocol:        opts.Protocol,
+		ListenPort:      *opts.ListenPort,
+		DestinationPort: *opts.DestinationPort,
+	}
+	if opts.Proxyprotocol != nil {
+		service.Proxyprotocol = *opts.Proxyprotocol
+	}
+	if opts.HTTP != nil {
+		applyServiceHTTPOpts(&service.HTTP, hcloud.LoadBalancerUpdateServiceOptsHTTP(*opts.HTTP))
+	}
+	if hc := opts.HealthCheck; hc != nil {
+		applyHealthCheckOpts(&service.HealthCheck, hcloud.LoadBalancerUpdateServiceOptsHealthCheck{
+			Protocol: hc.Protocol,
+			Port:     hc.Port,
+			Interval: hc.Interval,
+			Timeout:  hc.Timeout,
+			Retries:  hc.Retries,
+			HTTP:     (*hcloud.LoadBalancerUpdateServiceOptsHealthCheckHTTP)(hc.HTTP),
+		})
+	}
+
 	// Add it
-	c.loadBalancerCache.idMap[lb.ID].Services = append(
-		c.loadBalancerCache.idMap[lb.ID].Services, hcloud.LoadBalancerService{ListenPort: *opts.ListenPort, DestinationPort: *opts.DestinationPort})
+	c.loadBalancerCache.idMap[lb.ID].Services = append(c.loadBalancerCache.idMap[lb.ID].Services, service)
+	return nil
+}
+
+func (c *cacheHCloudClient) UpdateServiceOfLoadBalancer(_ context.Context, lb *hcloud.LoadBalancer, listenPort int, opts hcloud.LoadBalancerUpdateServiceOpts) error {
+	// Check if loadBalancer exists
+	if _, found := c.loadBalancerCache.idMap[lb.ID]; !found {
+		return hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+	}
+
+	for i := range c.loadBalancerCache.idMap[lb.ID].Services {
+		service := &c.loadBalancerCache.idMap[lb.ID].Services[i]
+		if service.ListenPort != listenPort {
+			continue
+		}
+		if opts.Protocol != "" {
+			service.Protocol = opts.Protocol
+		}
+		if opts.DestinationPort != nil {
+			service.DestinationPort = *opts.DestinationPort
+		}
+		if opts.Proxyprotocol != nil {
+			service.Proxyprotocol = *opts.Proxyprotocol
+		}
+		if opts.HTTP != nil {
+			applyServiceHTTPOpts(&service.HTTP, *opts.HTTP)
+		}
+		if opts.HealthCheck != nil {
+			applyHealthCheckOpts(&service.HealthCheck, *opts.HealthCheck)
+		}
+		return nil
+	}
+
+	return hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+}
+
+func applyServiceHTTPOpts(http *hcloud.LoadBalancerServiceHTTP, opts hcloud.LoadBalancerUpdateServiceOptsHTTP) {
+	if opts.CookieName != nil {
+		http.CookieName = *opts.CookieName
+	}
+	if opts.CookieLifetime != nil {
+		http.CookieLifetime = *opts.CookieLifetime
+	}
+	if opts.Certificates != nil {
+		http.Certificates = opts.Certificates
+	}
+	if opts.RedirectHTTP != nil {
+		http.RedirectHTTP = *opts.RedirectHTTP
+	}
+	if opts.StickySessions != nil {
+		http.StickySessions = *opts.StickySessions
+	}
+}
+
+func applyHealthCheckOpts(healthCheck *hcloud.LoadBalancerServiceHealthCheck, opts hcloud.LoadBalancerUpdateServiceOptsHealthCheck) {
+	if opts.Protocol != "" {
+		healthCheck.Protocol = opts.Protocol
+	}
+	if opts.Port != nil {
+		healthCheck.Port = *opts.Port
+	}
+	if opts.Interval != nil {
+		healthCheck.Interval = *opts.Interval
+	}
+	if opts.Timeout != nil {
+		healthCheck.Timeout = *opts.Timeout
+	}
+	if opts.Retries != nil {
+		healthCheck.Retries = *opts.Retries
+	}
+	if opts.HTTP != nil {
+		if healthCheck.HTTP == nil {
+			healthCheck.HTTP = &hcloud.LoadBalancerServiceHealthCheckHTTP{}
+		}
+		if opts.HTTP.Domain != nil {
+			healthCheck.HTTP.Domain = *opts.HTTP.Domain
+		}
+		if opts.HTTP.Path != nil {
+			healthCheck.HTTP.Path = *opts.HTTP.Path
+		}
+		if opts.HTTP.Response != nil {
+			healthCheck.HTTP.Response = *opts.HTTP.Response
+		}
+		if opts.HTTP.StatusCodes != nil {
+			healthCheck.HTTP.StatusCodes = opts.HTTP.StatusCodes
+		}
+		if opts.HTTP.TLS != nil {
+			healthCheck.HTTP.TLS = *opts.HTTP.TLS
+		}
+	}
+}
+
+func (c *cacheHCloudClient) CreateCertificate(_ context.Context, opts hcloud.CertificateCreateOpts) (*hcloud.Certificate, error) {
+	c.counterMutex.Lock()
+	defer c.counterMutex.Unlock()
+
+	if _, found := c.certificateCache.nameMap[opts.Name]; found {
+		return nil, hcloud.Error{Code: hcloud.ErrorCodeUniquenessError, Message: "already exists"}
+	}
+
+	c.certificateIDCounter++
+	certificate := &hcloud.Certificate{
+		ID:          c.certificateIDCounter,
+		Name:        opts.Name,
+		Labels:      opts.Labels,
+		Type:        opts.Type,
+		Certificate: opts.Certificate,
+		DomainNames: opts.DomainNames,
+	}
+
+	// Add certificate to cache
+	c.certificateCache.idMap[certificate.ID] = certificate
+	c.certificateCache.nameMap[certificate.Name] = struct{}{}
+	return certificate, nil
+}
+
+func (c *cacheHCloudClient) ListCertificates(_ context.Context, opts hcloud.CertificateListOpts) ([]*hcloud.Certificate, error) {
+	certificates := make([]*hcloud.Certificate, 0, len(c.certificateCache.idMap))
+
+	labels, err := utils.LabelSelectorToLabels(opts.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert label selector to labels: %w", err)
+	}
+
+	for _, certificate := range c.certificateCache.idMap {
+		if opts.Name != "" && certificate.Name != opts.Name {
+			continue
+		}
+
+		allLabelsFound := true
+		for key, label := range labels {
+			if val, found := certificate.Labels[key]; !found || val != label {
+				allLabelsFound = false
+				break
+			}
+		}
+		if allLabelsFound {
+			certificates = append(certificates, certificate)
+		}
+	}
+
+	return certificates, nil
+}
+
+func (c *cacheHCloudClient) DeleteCertificate(_ context.Context, id int64) error {
+	if _, found := c.certificateCache.idMap[id]; !found {
+		return hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+	}
+
+	delete(c.certificateCache.nameMap, c.certificateCache.idMap[id].Name)
+	delete(c.certificateCache.idMap, id)
 	return nil
 }
 
@@ -423,6 +718,13 @@ func (c *cacheHCloudClient) ListImages(_ context.Context, opts hcloud.ImageListO
 	return []*hcloud.Image{&defaultImage}, nil
 }
 
+func (c *cacheHCloudClient) GetImage(_ context.Context, id int64) (*hcloud.Image, error) {
+	if id != defaultImage.ID {
+		return nil, nil
+	}
+	return &defaultImage, nil
+}
+
 func (c *cacheHCloudClient) CreateServer(_ context.Context, opts hcloud.ServerCreateOpts) (*hcloud.Server, error) {
 	c.counterMutex.Lock()
 	defer c.counterMutex.Unlock()
@@ -446,6 +748,27 @@ func (c *cacheHCloudClient) CreateServer(_ context.Context, opts hcloud.ServerCr
 		server.PrivateNet = append(server.PrivateNet, hcloud.ServerPrivateNet{IP: c.networkCache.idMap[network.ID].IPRange.IP})
 	}
 
+	for _, volume := range opts.Volumes {
+		v, found := c.volumeCache.idMap[volume.ID]
+		if !found {
+			return nil, hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+		}
+		v.Server = &hcloud.Server{ID: server.ID}
+		server.Volumes = append(server.Volumes, &hcloud.Volume{ID: v.ID})
+	}
+
+	if opts.PublicNet != nil && opts.PublicNet.IPv4 != nil {
+		primaryIP, found := c.primaryIPCache.idMap[opts.PublicNet.IPv4.ID]
+		if !found {
+			return nil, hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+		}
+		if primaryIP.AssigneeID != 0 {
+			return nil, hcloud.Error{Code: hcloud.ErrorCodeConflict, Message: "primary ip is already assigned"}
+		}
+		primaryIP.AssigneeID = server.ID
+		server.PublicNet.IPv4 = hcloud.ServerPublicNetIPv4{ID: primaryIP.ID, IP: primaryIP.IP}
+	}
+
 	// Add server to cache
 	c.serverCache.idMap[server.ID] = server
 	c.serverCache.nameMap[server.Name] = struct{}{}
@@ -527,11 +850,52 @@ func (c *cacheHCloudClient) DeleteServer(_ context.Context, server *hcloud.Serve
 		return hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
 	}
 	n := c.serverCache.idMap[server.ID]
+
+	// volumes are detached, but not deleted
+	for _, volume := range n.Volumes {
+		if v, found := c.volumeCache.idMap[volume.ID]; found {
+			v.Server = nil
+		}
+	}
+
+	// floating ips are unassigned and primary ips are unassigned or deleted
+	for _, floatingIP := range c.floatingIPCache.idMap {
+		if floatingIP.Server != nil && floatingIP.Server.ID == n.ID {
+			floatingIP.Server = nil
+		}
+	}
+	for id, primaryIP := range c.primaryIPCache.idMap {
+		if primaryIP.AssigneeID != n.ID {
+			continue
+		}
+		if primaryIP.AutoDelete {
+			delete(c.primaryIPCache.nameMap, primaryIP.Name)
+			delete(c.primaryIPCache.idMap, id)
+			continue
+		}
+		primaryIP.AssigneeID = 0
+	}
+
 	delete(c.serverCache.nameMap, n.Name)
 	delete(c.serverCache.idMap, server.ID)
 	return nil
 }
 
+func (c *cacheHCloudClient) UpdateServer(_ context.Context, server *hcloud.Server, opts hcloud.ServerUpdateOpts) (*hcloud.Server, error) {
+	if _, found := c.serverCache.idMap[server.ID]; !found {
+		return nil, hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+	}
+
+	if opts.Name != "" {
+		c.serverCache.idMap[server.ID].Name = opts.Name
+	}
+	if opts.Labels != nil {
+		c.serverCache.idMap[server.ID].Labels = opts.Labels
+	}
+
+	return c.serverCache.idMap[server.ID], nil
+}
+
 func (c *cacheHCloudClient) ListServerTypes(_ context.Context) ([]*hcloud.ServerType, error) {
 	return []*hcloud.ServerType{
 		{
@@ -596,6 +960,9 @@ func (c *cacheHCloudClient) CreateNetwork(_ context.Context, opts hcloud.Network
 		Labels:  opts.Labels,
 		IPRange: opts.IPRange,
 		Subnets: opts.Subnets,
+		Routes:  opts.Routes,
+
+		ExposeRoutesToVSwitch: opts.ExposeRoutesToVSwitch,
 	}
 
 	// Add network to cache
@@ -638,6 +1005,106 @@ func (c *cacheHCloudClient) DeleteNetwork(_ context.Context, network *hcloud.Net
 	return nil
 }
 
+func (c *cacheHCloudClient) GetNetwork(_ context.Context, id int64) (*hcloud.Network, error) {
+	network, found := c.networkCache.idMap[id]
+	if !found {
+		// the API returns nil without error if the network does not exist
+		return nil, nil
+	}
+	return network, nil
+}
+
+func (c *cacheHCloudClient) UpdateNetwork(_ context.Context, network *hcloud.Network, opts hcloud.NetworkUpdateOpts) (*hcloud.Network, error) {
+	n, found := c.networkCache.idMap[network.ID]
+	if !found {
+		return nil, hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+	}
+
+	if opts.Name != "" {
+		n.Name = opts.Name
+	}
+	if opts.Labels != nil {
+		n.Labels = opts.Labels
+	}
+	if opts.ExposeRoutesToVSwitch != nil {
+		n.ExposeRoutesToVSwitch = *opts.ExposeRoutesToVSwitch
+	}
+
+	return n, nil
+}
+
+func (c *cacheHCloudClient) AddSubnetToNetwork(_ context.Context, network *hcloud.Network, subnet hcloud.NetworkSubnet) error {
+	n, found := c.networkCache.idMap[network.ID]
+	if !found {
+		return hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+	}
+
+	for _, s := range n.Subnets {
+		if s.IPRange.String() == subnet.IPRange.String() {
+			return hcloud.Error{Code: hcloud.ErrorCodeConflict, Message: "subnet already exists"}
+		}
+	}
+
+	n.Subnets = append(n.Subnets, subnet)
+	return nil
+}
+
+func (c *cacheHCloudClient) DeleteSubnetFromNetwork(_ context.Context, network *hcloud.Network, subnet hcloud.NetworkSubnet) error {
+	n, found := c.networkCache.idMap[network.ID]
+	if !found {
+		return hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+	}
+
+	subnets := make([]hcloud.NetworkSubnet, 0, len(n.Subnets))
+	for _, s := range n.Subnets {
+		if s.IPRange.String() != subnet.IPRange.String() {
+			subnets = append(subnets, s)
+		}
+	}
+	if len(subnets) == len(n.Subnets) {
+		return hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+	}
+
+	n.Subnets = subnets
+	return nil
+}
+
+func (c *cacheHCloudClient) AddRouteToNetwork(_ context.Context, network *hcloud.Network, route hcloud.NetworkRoute) error {
+	n, found := c.networkCache.idMap[network.ID]
+	if !found {
+		return hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+	}
+
+	for _, r := range n.Routes {
+		if r.Destination.String() == route.Destination.String() {
+			return hcloud.Error{Code: hcloud.ErrorCodeConflict, Message: "route already exists"}
+		}
+	}
+
+	n.Routes = append(n.Routes, route)
+	return nil
+}
+
+func (c *cacheHCloudClient) DeleteRouteFromNetwork(_ context.Context, network *hcloud.Network, route hcloud.NetworkRoute) error {
+	n, found := c.networkCache.idMap[network.ID]
+	if !found {
+		return hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+	}
+
+	routes := make([]hcloud.NetworkRoute, 0, len(n.Routes))
+	for _, r := range n.Routes {
+		if r.Destination.String() != route.Destination.String() || !r.Gateway.Equal(route.Gateway) {
+			routes = append(routes, r)
+		}
+	}
+	if len(routes) == len(n.Routes) {
+		return hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+	}
+
+	n.Routes = routes
+	return nil
+}
+
 func (c *cacheHCloudClient) ListSSHKeys(_ context.Context, _ hcloud.SSHKeyListOpts) ([]*hcloud.SSHKey, error) {
 	return []*hcloud.SSHKey{&defaultSSHKey}, nil
 }
@@ -721,6 +1188,466 @@ func (c *cacheHCloudClient) AddServerToPlacementGroup(_ context.Context, server
 	return nil
 }
 
+func (c *cacheHCloudClient) UpdatePlacementGroup(_ context.Context, pg *hcloud.PlacementGroup, opts hcloud.PlacementGroupUpdateOpts) (*hcloud.PlacementGroup, error) {
+	placementGroup, found := c.placementGroupCache.idMap[pg.ID]
+	if !found {
+		return nil, hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+	}
+
+	if opts.Name != "" {
+		placementGroup.Name = opts.Name
+	}
+	if opts.Labels != nil {
+		placementGroup.Labels = opts.Labels
+	}
+
+	return placementGroup, nil
+}
+
+func (c *cacheHCloudClient) CreateFirewall(_ context.Context, opts hcloud.FirewallCreateOpts) (*hcloud.Firewall, error) {
+	c.counterMutex.Lock()
+	defer c.counterMutex.Unlock()
+
+	if _, found := c.firewallCache.nameMap[opts.Name]; found {
+		return nil, fmt.Errorf("already exists")
+	}
+
+	c.firewallIDCounter++
+	firewall := &hcloud.Firewall{
+		ID:        c.firewallIDCounter,
+		Name:      opts.Name,
+		Labels:    opts.Labels,
+		Rules:     opts.Rules,
+		AppliedTo: opts.ApplyTo,
+	}
+
+	// Add firewall to cache
+	c.firewallCache.idMap[firewall.ID] = firewall
+	c.firewallCache.nameMap[firewall.Name] = struct{}{}
+	return firewall, nil
+}
+
+func (c *cacheHCloudClient) DeleteFirewall(_ context.Context, id int64) error {
+	if _, found := c.firewallCache.idMap[id]; !found {
+		return hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+	}
+
+	n := c.firewallCache.idMap[id]
+
+	// firewalls cannot be deleted as long as they are applied to resources
+	if len(n.AppliedTo) > 0 {
+		return hcloud.Error{Code: hcloud.ErrorCodeResourceInUse, Message: "resource in use"}
+	}
+
+	delete(c.firewallCache.nameMap, n.Name)
+	delete(c.firewallCache.idMap, id)
+	return nil
+}
+
+func (c *cacheHCloudClient) ListFirewalls(_ context.Context, opts hcloud.FirewallListOpts) ([]*hcloud.Firewall, error) {
+	firewalls := make([]*hcloud.Firewall, 0, len(c.firewallCache.idMap))
+
+	labels, err := utils.LabelSelectorToLabels(opts.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert label selector to labels: %w", err)
+	}
+
+	for _, firewall := range c.firewallCache.idMap {
+		allLabelsFound := true
+		for key, label := range labels {
+			if val, found := firewall.Labels[key]; !found || val != label {
+				allLabelsFound = false
+				break
+			}
+		}
+		if allLabelsFound {
+			firewalls = append(firewalls, firewall)
+		}
+	}
+
+	return firewalls, nil
+}
+
+func (c *cacheHCloudClient) SetFirewallRules(_ context.Context, firewall *hcloud.Firewall, opts hcloud.FirewallSetRulesOpts) error {
+	// Check if firewall exists
+	if _, found := c.firewallCache.idMap[firewall.ID]; !found {
+		return hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+	}
+
+	// Update it
+	c.firewallCache.idMap[firewall.ID].Rules = opts.Rules
+	return nil
+}
+
+func (c *cacheHCloudClient) ApplyFirewallResources(_ context.Context, firewall *hcloud.Firewall, resources []hcloud.FirewallResource) error {
+	// Check if firewall exists
+	if _, found := c.firewallCache.idMap[firewall.ID]; !found {
+		return hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+	}
+
+	for _, resource := range resources {
+		// check if already applied
+		for _, applied := range c.firewallCache.idMap[firewall.ID].AppliedTo {
+			if isSameFirewallResource(applied, resource) {
+				return hcloud.Error{Code: hcloud.ErrorCodeFirewallAlreadyApplied, Message: "already applied"}
+			}
+		}
+
+		// Add it
+		c.firewallCache.idMap[firewall.ID].AppliedTo = append(c.firewallCache.idMap[firewall.ID].AppliedTo, resource)
+	}
+	return nil
+}
+
+func (c *cacheHCloudClient) RemoveFirewallResources(_ context.Context, firewall *hcloud.Firewall, resources []hcloud.FirewallResource) error {
+	// Check if firewall exists
+	if _, found := c.firewallCache.idMap[firewall.ID]; !found {
+		return hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+	}
+
+	// check that all resources are applied
+	for _, resource := range resources {
+		found := false
+		for _, applied := range c.firewallCache.idMap[firewall.ID].AppliedTo {
+			if isSameFirewallResource(applied, resource) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return hcloud.Error{Code: hcloud.ErrorCodeFirewallAlreadyRemoved, Message: "already removed"}
+		}
+	}
+
+	// remove them
+	appliedTo := make([]hcloud.FirewallResource, 0, len(c.firewallCache.idMap[firewall.ID].AppliedTo))
+	for _, applied := range c.firewallCache.idMap[firewall.ID].AppliedTo {
+		remove := false
+		for _, resource := range resources {
+			if isSameFirewallResource(applied, resource) {
+				remove = true
+				break
+			}
+		}
+		if !remove {
+			appliedTo = append(appliedTo, applied)
+		}
+	}
+	c.firewallCache.idMap[firewall.ID].AppliedTo = appliedTo
+	return nil
+}
+
+func isSameFirewallResource(a, b hcloud.FirewallResource) bool {
+	if a.Type != b.Type {
+		return false
+	}
+	switch a.Type {
+	case hcloud.FirewallResourceTypeServer:
+		return a.Server != nil && b.Server != nil && a.Server.ID == b.Server.ID
+	case hcloud.FirewallResourceTypeLabelSelector:
+		return a.LabelSelector != nil && b.LabelSelector != nil && a.LabelSelector.Selector == b.LabelSelector.Selector
+	}
+	return false
+}
+
+func (c *cacheHCloudClient) CreateVolume(_ context.Context, opts hcloud.VolumeCreateOpts) (*hcloud.Volume, error) {
+	c.counterMutex.Lock()
+	defer c.counterMutex.Unlock()
+
+	if _, found := c.volumeCache.nameMap[opts.Name]; found {
+		return nil, fmt.Errorf("already exists")
+	}
+
+	c.volumeIDCounter++
+	volume := &hcloud.Volume{
+		ID:          c.volumeIDCounter,
+		Name:        opts.Name,
+		Labels:      opts.Labels,
+		Size:        opts.Size,
+		Location:    opts.Location,
+		Server:      opts.Server,
+		Status:      hcloud.VolumeStatusAvailable,
+		LinuxDevice: fmt.Sprintf("/dev/disk/by-id/scsi-0HC_Volume_%d", c.volumeIDCounter),
+	}
+
+	// Add volume to cache
+	c.volumeCache.idMap[volume.ID] = volume
+	c.volumeCache.nameMap[volume.Name] = struct{}{}
+	return volume, nil
+}
+
+func (c *cacheHCloudClient) ListVolumes(_ context.Context, opts hcloud.VolumeListOpts) ([]*hcloud.Volume, error) {
+	volumes := make([]*hcloud.Volume, 0, len(c.volumeCache.idMap))
+
+	labels, err := utils.LabelSelectorToLabels(opts.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert label selector to labels: %w", err)
+	}
+
+	for _, volume := range c.volumeCache.idMap {
+		// if name is set and is not correct, continue
+		if opts.Name != "" && volume.Name != opts.Name {
+			continue
+		}
+
+		allLabelsFound := true
+		for key, label := range labels {
+			if val, found := volume.Labels[key]; !found || val != label {
+				allLabelsFound = false
+				break
+			}
+		}
+		if allLabelsFound {
+			volumes = append(volumes, volume)
+		}
+	}
+
+	return volumes, nil
+}
+
+func (c *cacheHCloudClient) AttachVolume(_ context.Context, volume *hcloud.Volume, server *hcloud.Server) error {
+	// Check if volume exists
+	v, found := c.volumeCache.idMap[volume.ID]
+	if !found {
+		return hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+	}
+
+	// Check if server exists
+	s, found := c.serverCache.idMap[server.ID]
+	if !found {
+		return hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+	}
+
+	// check if already attached
+	if v.Server != nil {
+		return hcloud.Error{Code: hcloud.ErrorCodeVolumeAlreadyAttached, Message: "already attached"}
+	}
+
+	// Attach it
+	v.Server = &hcloud.Server{ID: s.ID}
+	s.Volumes = append(s.Volumes, &hcloud.Volume{ID: v.ID})
+	return nil
+}
+
+func (c *cacheHCloudClient) DeleteVolume(_ context.Context, volume *hcloud.Volume) error {
+	if _, found := c.volumeCache.idMap[volume.ID]; !found {
+		return hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+	}
+
+	n := c.volumeCache.idMap[volume.ID]
+
+	// volumes cannot be deleted as long as they are attached
+	if n.Server != nil {
+		return hcloud.Error{Code: hcloud.ErrorCodeLocked, Message: "volume is attached"}
+	}
+
+	delete(c.volumeCache.nameMap, n.Name)
+	delete(c.volumeCache.idMap, volume.ID)
+	return nil
+}
+
+func (c *cacheHCloudClient) CreateFloatingIP(_ context.Context, opts hcloud.FloatingIPCreateOpts) (*hcloud.FloatingIP, error) {
+	c.counterMutex.Lock()
+	defer c.counterMutex.Unlock()
+
+	if opts.Name != nil {
+		if _, found := c.floatingIPCache.nameMap[*opts.Name]; found {
+			return nil, fmt.Errorf("already exists")
+		}
+	}
+
+	c.floatingIPIDCounter++
+	floatingIP := &hcloud.FloatingIP{
+		ID:           c.floatingIPIDCounter,
+		Labels:       opts.Labels,
+		Type:         opts.Type,
+		HomeLocation: opts.HomeLocation,
+		Server:       opts.Server,
+		IP:           net.ParseIP(fmt.Sprintf("198.51.100.%d", c.floatingIPIDCounter)),
+	}
+	if opts.Name != nil {
+		floatingIP.Name = *opts.Name
+	}
+
+	// Add floating ip to cache
+	c.floatingIPCache.idMap[floatingIP.ID] = floatingIP
+	c.floatingIPCache.nameMap[floatingIP.Name] = struct{}{}
+	return floatingIP, nil
+}
+
+func (c *cacheHCloudClient) ListFloatingIPs(_ context.Context, opts hcloud.FloatingIPListOpts) ([]*hcloud.FloatingIP, error) {
+	floatingIPs := make([]*hcloud.FloatingIP, 0, len(c.floatingIPCache.idMap))
+
+	labels, err := utils.LabelSelectorToLabels(opts.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert label selector to labels: %w", err)
+	}
+
+	for _, floatingIP := range c.floatingIPCache.idMap {
+		// if name is set and is not correct, continue
+		if opts.Name != "" && floatingIP.Name != opts.Name {
+			continue
+		}
+
+		allLabelsFound := true
+		for key, label := range labels {
+			if val, found := floatingIP.Labels[key]; !found || val != label {
+				allLabelsFound = false
+				break
+			}
+		}
+		if allLabelsFound {
+			floatingIPs = append(floatingIPs, floatingIP)
+		}
+	}
+
+	return floatingIPs, nil
+}
+
+func (c *cacheHCloudClient) AssignFloatingIP(_ context.Context, floatingIP *hcloud.FloatingIP, server *hcloud.Server) error {
+	// Check if floating ip exists
+	f, found := c.floatingIPCache.idMap[floatingIP.ID]
+	if !found {
+		return hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+	}
+
+	// Check if server exists
+	if _, found := c.serverCache.idMap[server.ID]; !found {
+		return hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+	}
+
+	// floating ips can be reassigned without being unassigned first
+	f.Server = &hcloud.Server{ID: server.ID}
+	return nil
+}
+
+func (c *cacheHCloudClient) DeleteFloatingIP(_ context.Context, floatingIP *hcloud.FloatingIP) error {
+	if _, found := c.floatingIPCache.idMap[floatingIP.ID]; !found {
+		return hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+	}
+
+	n := c.floatingIPCache.idMap[floatingIP.ID]
+	delete(c.floatingIPCache.nameMap, n.Name)
+	delete(c.floatingIPCache.idMap, floatingIP.ID)
+	return nil
+}
+
+func (c *cacheHCloudClient) CreatePrimaryIP(_ context.Context, opts hcloud.PrimaryIPCreateOpts) (*hcloud.PrimaryIP, error) {
+	c.counterMutex.Lock()
+	defer c.counterMutex.Unlock()
+
+	if _, found := c.primaryIPCache.nameMap[opts.Name]; found {
+		return nil, hcloud.Error{Code: hcloud.ErrorCodeUniquenessError, Message: "already exists"}
+	}
+
+	c.primaryIPIDCounter++
+	primaryIP := &hcloud.PrimaryIP{
+		ID:           c.primaryIPIDCounter,
+		Name:         opts.Name,
+		Labels:       opts.Labels,
+		Type:         opts.Type,
+		AssigneeType: opts.AssigneeType,
+		Datacenter:   &hcloud.Datacenter{Name: opts.Datacenter},
+		IP:           net.ParseIP(fmt.Sprintf("203.0.113.%d", c.primaryIPIDCounter)),
+	}
+	if opts.AutoDelete != nil {
+		primaryIP.AutoDelete = *opts.AutoDelete
+	}
+	if opts.AssigneeID != nil {
+		primaryIP.AssigneeID = *opts.AssigneeID
+	}
+
+	// Add primary ip to cache
+	c.primaryIPCache.idMap[primaryIP.ID] = primaryIP
+	c.primaryIPCache.nameMap[primaryIP.Name] = struct{}{}
+	return primaryIP, nil
+}
+
+func (c *cacheHCloudClient) ListPrimaryIPs(_ context.Context, opts hcloud.PrimaryIPListOpts) ([]*hcloud.PrimaryIP, error) {
+	primaryIPs := make([]*hcloud.PrimaryIP, 0, len(c.primaryIPCache.idMap))
+
+	labels, err := utils.LabelSelectorToLabels(opts.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert label selector to labels: %w", err)
+	}
+
+	for _, primaryIP := range c.primaryIPCache.idMap {
+		// if name is set and is not correct, continue
+		if opts.Name != "" && primaryIP.Name != opts.Name {
+			continue
+		}
+
+		allLabelsFound := true
+		for key, label := range labels {
+			if val, found := primaryIP.Labels[key]; !found || val != label {
+				allLabelsFound = false
+				break
+			}
+		}
+		if allLabelsFound {
+			primaryIPs = append(primaryIPs, primaryIP)
+		}
+	}
+
+	return primaryIPs, nil
+}
+
+func (c *cacheHCloudClient) UnassignPrimaryIP(_ context.Context, primaryIP *hcloud.PrimaryIP) error {
+	p, found := c.primaryIPCache.idMap[primaryIP.ID]
+	if !found {
+		return hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+	}
+
+	// primary ips can only be unassigned from servers that are powered off
+	if server, found := c.serverCache.idMap[p.AssigneeID]; found {
+		if server.Status != hcloud.ServerStatusOff {
+			return hcloud.Error{Code: hcloud.ErrorCodeServerNotStopped, Message: "server is not stopped"}
+		}
+		server.PublicNet.IPv4 = hcloud.ServerPublicNetIPv4{}
+	}
+
+	p.AssigneeID = 0
+	return nil
+}
+
+func (c *cacheHCloudClient) DeletePrimaryIP(_ context.Context, primaryIP *hcloud.PrimaryIP) error {
+	if _, found := c.primaryIPCache.idMap[primaryIP.ID]; !found {
+		return hcloud.Error{Code: hcloud.ErrorCodeNotFound, Message: "not found"}
+	}
+
+	n := c.primaryIPCache.idMap[primaryIP.ID]
+
+	// primary ips cannot be deleted as long as they are assigned
+	if n.AssigneeID != 0 {
+		return hcloud.Error{Code: hcloud.ErrorCodeResourceInUse, Message: "primary ip is assigned"}
+	}
+
+	delete(c.primaryIPCache.nameMap, n.Name)
+	delete(c.primaryIPCache.idMap, primaryIP.ID)
+	return nil
+}
+
+func (c *cacheHCloudClient) ListDatacenters(_ context.Context, opts hcloud.DatacenterListOpts) ([]*hcloud.Datacenter, error) {
+	datacenters := []*hcloud.Datacenter{
+		{ID: 1, Name: "fsn1-dc14", Location: &hcloud.Location{Name: "fsn1"}},
+		{ID: 2, Name: "nbg1-dc3", Location: &hcloud.Location{Name: "nbg1"}},
+		{ID: 3, Name: "hel1-dc2", Location: &hcloud.Location{Name: "hel1"}},
+		{ID: 4, Name: "ash-dc1", Location: &hcloud.Location{Name: "ash"}},
+		{ID: 5, Name: "hil-dc1", Location: &hcloud.Location{Name: "hil"}},
+	}
+
+	if opts.Name == "" {
+		return datacenters, nil
+	}
+
+	for _, datacenter := range datacenters {
+		if datacenter.Name == opts.Name {
+			return []*hcloud.Datacenter{datacenter}, nil
+		}
+	}
+	return nil, nil
+}
+
 func isIntInList(list []int64, str int64) bool {
 	for _, s := range list {
 		if s == str {
@@ -0,0 +1,397 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package firewall implements the lifecycle of HCloud firewalls.
+package firewall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/record"
+
+	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
+	"github.com/syself/cluster-api-provider-hetzner/pkg/scope"
+	hcloudutil "github.com/syself/cluster-api-provider-hetzner/pkg/services/hcloud/util"
+	"github.com/syself/cluster-api-provider-hetzner/pkg/utils"
+)
+
+// Service struct contains cluster scope to reconcile firewalls.
+type Service struct {
+	scope *scope.ClusterScope
+}
+
+// NewService creates new service object.
+func NewService(scope *scope.ClusterScope) *Service {
+	return &Service{
+		scope: scope,
+	}
+}
+
+// Reconcile implements life cycle of firewalls.
+func (s *Service) Reconcile(ctx context.Context) (err error) {
+	defer func() {
+		if err != nil {
+			conditions.MarkFalse(
+				s.scope.HetznerCluster,
+				infrav1.FirewallsSyncedCondition,
+				infrav1.FirewallsSyncFailedReason,
+				clusterv1.ConditionSeverityWarning,
+				err.Error(),
+			)
+		}
+	}()
+
+	// find firewalls
+	firewalls, err := s.findFirewalls(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to find firewalls: %w", err)
+	}
+
+	clusterName := s.scope.HetznerCluster.Name
+
+	firewallExistingMap := make(map[string]*hcloud.Firewall)
+	for i, fw := range firewalls {
+		firewallExistingMap[strings.TrimPrefix(fw.Name, clusterName+"-")] = firewalls[i]
+	}
+
+	var multierr error
+	changed := false
+
+	for _, fwSpec := range s.scope.HetznerCluster.Spec.HCloudFirewalls {
+		rules, err := hcloudFirewallRules(fwSpec.Rules)
+		if err != nil {
+			multierr = errors.Join(multierr, fmt.Errorf("invalid rules of firewall %q: %w", fwSpec.Name, err))
+			continue
+		}
+
+		resource := hcloud.FirewallResource{
+			Type:          hcloud.FirewallResourceTypeLabelSelector,
+			LabelSelector: &hcloud.FirewallResourceLabelSelector{Selector: s.labelSelector(fwSpec)},
+		}
+
+		fw, found := firewallExistingMap[fwSpec.Name]
+		if !found {
+			// create new firewall
+			opts := hcloud.FirewallCreateOpts{
+				Name:    fmt.Sprintf("%s-%s", clusterName, fwSpec.Name),
+				Labels:  map[string]string{s.scope.HetznerCluster.ClusterTagKey(): string(infrav1.ResourceLifecycleOwned)},
+				Rules:   rules,
+				ApplyTo: []hcloud.FirewallResource{resource},
+			}
+
+			if _, err := s.scope.HCloudClient.CreateFirewall(ctx, opts); err != nil {
+				hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "CreateFirewall")
+				multierr = errors.Join(multierr, fmt.Errorf("failed to create firewall %q: %w", fwSpec.Name, err))
+				continue
+			}
+
+			record.Eventf(s.scope.HetznerCluster, "FirewallCreated", "Created firewall %s", fwSpec.Name)
+			changed = true
+			continue
+		}
+
+		// update rules of existing firewall if they drifted
+		if !rulesEqual(fw.Rules, rules) {
+			if err := s.scope.HCloudClient.SetFirewallRules(ctx, fw, hcloud.FirewallSetRulesOpts{Rules: rules}); err != nil {
+				hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "SetFirewallRules")
+				multierr = errors.Join(multierr, fmt.Errorf("failed to set rules of firewall %q: %w", fwSpec.Name, err))
+				continue
+			}
+			record.Eventf(s.scope.HetznerCluster, "FirewallRulesUpdated", "Updated rules of firewall %s", fwSpec.Name)
+		}
+
+		// make sure the firewall is applied to the desired servers and nothing else
+		resourcesChanged, err := s.reconcileResources(ctx, fw, resource)
+		if err != nil {
+			multierr = errors.Join(multierr, fmt.Errorf("failed to reconcile resources of firewall %q: %w", fwSpec.Name, err))
+			continue
+		}
+		if resourcesChanged {
+			changed = true
+		}
+	}
+
+	// delete firewalls that are not desired anymore
+	for name, fw := range firewallExistingMap {
+		if isFirewallInSpec(s.scope.HetznerCluster.Spec.HCloudFirewalls, name) {
+			continue
+		}
+
+		if err := s.deleteFirewall(ctx, fw); err != nil {
+			multierr = errors.Join(multierr, fmt.Errorf("failed to delete firewall %q: %w", name, err))
+			continue
+		}
+
+		record.Eventf(s.scope.HetznerCluster, "FirewallDeleted", "Deleted firewall %s", name)
+		changed = true
+	}
+
+	if multierr != nil {
+		return fmt.Errorf("aggregate error - reconciling firewalls: %w", multierr)
+	}
+
+	// Update status
+	if changed {
+		// No need to update status if nothing changed
+		firewalls, err = s.findFirewalls(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to find firewalls: %w", err)
+		}
+	}
+
+	s.scope.HetznerCluster.Status.HCloudFirewalls = statusFromHCloudFirewalls(firewalls, clusterName)
+	conditions.MarkTrue(s.scope.HetznerCluster, infrav1.FirewallsSyncedCondition)
+
+	return nil
+}
+
+// Delete implements deletion of firewalls.
+func (s *Service) Delete(ctx context.Context) error {
+	// Delete firewalls which are owned by the cluster, regardless of whether they are in status
+	firewalls, err := s.findFirewalls(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to find firewalls: %w", err)
+	}
+
+	var multierr error
+	for _, fw := range firewalls {
+		if err := s.deleteFirewall(ctx, fw); err != nil {
+			multierr = errors.Join(multierr, err)
+		}
+	}
+
+	if multierr != nil {
+		return fmt.Errorf("aggregate error - deleting firewalls: %w", multierr)
+	}
+
+	if len(firewalls) > 0 {
+		record.Eventf(s.scope.HetznerCluster, "FirewallsDeleted", "Deleted firewalls")
+	}
+
+	return nil
+}
+
+// deleteFirewall removes the firewall from all resources and deletes it afterwards.
+// HCloud does not allow deleting firewalls that are still applied.
+func (s *Service) deleteFirewall(ctx context.Context, fw *hcloud.Firewall) error {
+	if len(fw.AppliedTo) > 0 {
+		if err := s.scope.HCloudClient.RemoveFirewallResources(ctx, fw, fw.AppliedTo); err != nil {
+			hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "RemoveFirewallResources")
+			if !hcloud.IsError(err, hcloud.ErrorCodeNotFound) && !hcloud.IsError(err, hcloud.ErrorCodeFirewallAlreadyRemoved) {
+				return fmt.Errorf("failed to remove resources of firewall %v: %w", fw.ID, err)
+			}
+		}
+	}
+
+	if err := s.scope.HCloudClient.DeleteFirewall(ctx, fw.ID); err != nil {
+		hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "DeleteFirewall")
+		if !hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
+			return fmt.Errorf("failed to delete firewall %v: %w", fw.ID, err)
+		}
+	}
+	return nil
+}
+
+// reconcileResources applies the desired resource to the firewall and removes all others.
+// It returns whether anything has been changed.
+func (s *Service) reconcileResources(ctx context.Context, fw *hcloud.Firewall, desired hcloud.FirewallResource) (bool, error) {
+	var toRemove []hcloud.FirewallResource
+	found := false
+
+	for _, applied := range fw.AppliedTo {
+		if isSameLabelSelector(applied, desired) {
+			found = true
+			continue
+		}
+		toRemove = append(toRemove, applied)
+	}
+
+	if len(toRemove) > 0 {
+		if err := s.scope.HCloudClient.RemoveFirewallResources(ctx, fw, toRemove); err != nil {
+			hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "RemoveFirewallResources")
+			return false, fmt.Errorf("failed to remove resources: %w", err)
+		}
+	}
+
+	if !found {
+		if err := s.scope.HCloudClient.ApplyFirewallResources(ctx, fw, []hcloud.FirewallResource{desired}); err != nil {
+			hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "ApplyFirewallResources")
+			return false, fmt.Errorf("failed to apply resources: %w", err)
+		}
+	}
+
+	return len(toRemove) > 0 || !found, nil
+}
+
+// labelSelector returns the label selector of the servers the firewall is applied to.
+// It always contains the cluster tag, so that firewalls never select servers of other clusters.
+func (s *Service) labelSelector(fwSpec infrav1.HCloudFirewallSpec) string {
+	labels := make(map[string]string, len(fwSpec.ApplyTo)+1)
+	for key, val := range fwSpec.ApplyTo {
+		labels[key] = val
+	}
+	labels[s.scope.HetznerCluster.ClusterTagKey()] = string(infrav1.ResourceLifecycleOwned)
+	return utils.LabelsToLabelSelector(labels)
+}
+
+func (s *Service) findFirewalls(ctx context.Context) ([]*hcloud.Firewall, error) {
+	clusterTagKey := s.scope.HetznerCluster.ClusterTagKey()
+	labels := map[string]string{clusterTagKey: string(infrav1.ResourceLifecycleOwned)}
+	opts := hcloud.FirewallListOpts{}
+	opts.LabelSelector = utils.LabelsToLabelSelector(labels)
+
+	firewalls, err := s.scope.HCloudClient.ListFirewalls(ctx, opts)
+	if err != nil {
+		hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "ListFirewalls")
+		return nil, fmt.Errorf("failed to list firewalls: %w", err)
+	}
+	return firewalls, nil
+}
+
+// hcloudFirewallRules converts the rules of the spec to HCloud firewall rules.
+func hcloudFirewallRules(rulesSpec []infrav1.HCloudFirewallRule) ([]hcloud.FirewallRule, error) {
+	rules := make([]hcloud.FirewallRule, len(rulesSpec))
+	for i, ruleSpec := range rulesSpec {
+		sourceIPs, err := parseCIDRs(ruleSpec.SourceIPs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source IPs: %w", err)
+		}
+
+		destinationIPs, err := parseCIDRs(ruleSpec.DestinationIPs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid destination IPs: %w", err)
+		}
+
+		rules[i] = hcloud.FirewallRule{
+			Direction:      hcloud.FirewallRuleDirection(ruleSpec.Direction),
+			Protocol:       hcloud.FirewallRuleProtocol(ruleSpec.Protocol),
+			Port:           ruleSpec.Port,
+			SourceIPs:      sourceIPs,
+			DestinationIPs: destinationIPs,
+			Description:    ruleSpec.Description,
+		}
+	}
+	return rules, nil
+}
+
+func parseCIDRs(cidrs []string) ([]net.IPNet, error) {
+	ipNets := make([]net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CIDR %q: %w", cidr, err)
+		}
+		ipNets = append(ipNets, *ipNet)
+	}
+	return ipNets, nil
+}
+
+// rulesEqual compares firewall rules by their string representation, as the
+// HCloud API returns IPs in a normalized form. The order of the rules does not
+// matter.
+func rulesEqual(a, b []hcloud.FirewallRule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	return slices.Equal(sortedRuleStrings(a), sortedRuleStrings(b))
+}
+
+func sortedRuleStrings(rules []hcloud.FirewallRule) []string {
+	ruleStrings := make([]string, len(rules))
+	for i, rule := range rules {
+		ruleStrings[i] = ruleToString(rule)
+	}
+	slices.Sort(ruleStrings)
+	return ruleStrings
+}
+
+func ruleToString(rule hcloud.FirewallRule) string {
+	sourceIPs := make([]string, len(rule.SourceIPs))
+	for i, ipNet := range rule.SourceIPs {
+		sourceIPs[i] = ipNet.String()
+	}
+
+	destinationIPs := make([]string, len(rule.DestinationIPs))
+	for i, ipNet := range rule.DestinationIPs {
+		destinationIPs[i] = ipNet.String()
+	}
+
+	var port, description string
+	if rule.Port != nil {
+		port = *rule.Port
+	}
+	if rule.Description != nil {
+		description = *rule.Description
+	}
+
+	return fmt.Sprintf("%s/%s/%s/%s/%s/%s",
+		rule.Direction, rule.Protocol, port, strings.Join(sourceIPs, ","), strings.Join(destinationIPs, ","), description,
+	)
+}
+
+// isSameLabelSelector checks whether both resources are label selectors selecting the same labels.
+func isSameLabelSelector(a, b hcloud.FirewallResource) bool {
+	if a.Type != hcloud.FirewallResourceTypeLabelSelector || b.Type != hcloud.FirewallResourceTypeLabelSelector ||
+		a.LabelSelector == nil || b.LabelSelector == nil {
+		return false
+	}
+
+	labelsA, err := utils.LabelSelectorToLabels(a.LabelSelector.Selector)
+	if err != nil {
+		return false
+	}
+	labelsB, err := utils.LabelSelectorToLabels(b.LabelSelector.Selector)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(labelsA, labelsB)
+}
+
+func isFirewallInSpec(firewallsSpec []infrav1.HCloudFirewallSpec, name string) bool {
+	for _, fwSpec := range firewallsSpec {
+		if fwSpec.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// statusFromHCloudFirewalls gets the information of the HCloud firewalls and returns it in our status object.
+func statusFromHCloudFirewalls(firewalls []*hcloud.Firewall, clusterName string) []infrav1.HCloudFirewallStatus {
+	status := make([]infrav1.HCloudFirewallStatus, len(firewalls))
+	for i, fw := range firewalls {
+		status[i] = infrav1.HCloudFirewallStatus{
+			ID:   fw.ID,
+			Name: strings.TrimPrefix(fw.Name, clusterName+"-"),
+		}
+		for _, resource := range fw.AppliedTo {
+			if resource.Type == hcloud.FirewallResourceTypeLabelSelector && resource.LabelSelector != nil {
+				status[i].LabelSelector = resource.LabelSelector.Selector
+				break
+			}
+		}
+	}
+	return status
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package firewall
+
+import (
+	"context"
+	"net"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
+	"github.com/syself/cluster-api-provider-hetzner/pkg/scope"
+	fakeclient "github.com/syself/cluster-api-provider-hetzner/pkg/services/hcloud/client/fake"
+	"github.com/syself/cluster-api-provider-hetzner/pkg/utils"
+)
+
+var _ = Describe("Firewall service", func() {
+	var (
+		ctx     context.Context
+		service *Service
+		cluster *infrav1.HetznerCluster
+		port    = "22"
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		hcloudClient := fakeclient.NewHCloudClientFactory().NewClient("")
+		hcloudClient.Close()
+
+		cluster = &infrav1.HetznerCluster{}
+		cluster.Name = "my-cluster"
+		cluster.Spec.HCloudFirewalls = []infrav1.HCloudFirewallSpec{
+			{
+				Name: "ssh",
+				Rules: []infrav1.HCloudFirewallRule{
+					{Direction: "in", Protocol: "tcp", Port: &port, SourceIPs: []string{"10.0.0.0/8"}},
+				},
+				ApplyTo: map[string]string{"machine_type": "control_plane"},
+			},
+		}
+
+		service = NewService(&scope.ClusterScope{HCloudClient: hcloudClient, HetznerCluster: cluster})
+	})
+
+	It("creates, updates and deletes owned firewalls", func() {
+		Expect(service.Reconcile(ctx)).To(Succeed())
+		Expect(conditions.IsTrue(cluster, infrav1.FirewallsSyncedCondition)).To(BeTrue())
+		Expect(cluster.Status.HCloudFirewalls).To(HaveLen(1))
+		Expect(cluster.Status.HCloudFirewalls[0].Name).To(Equal("ssh"))
+
+		labels, err := utils.LabelSelectorToLabels(cluster.Status.HCloudFirewalls[0].LabelSelector)
+		Expect(err).To(BeNil())
+		Expect(labels).To(Equal(map[string]string{
+			"machine_type":          "control_plane",
+			cluster.ClusterTagKey(): string(infrav1.ResourceLifecycleOwned),
+		}))
+
+		// change rules and selector
+		cluster.Spec.HCloudFirewalls[0].Rules[0].SourceIPs = []string{"192.168.0.0/16"}
+		cluster.Spec.HCloudFirewalls[0].ApplyTo = nil
+		Expect(service.Reconcile(ctx)).To(Succeed())
+
+		firewalls, err := service.findFirewalls(ctx)
+		Expect(err).To(BeNil())
+		Expect(firewalls).To(HaveLen(1))
+		Expect(firewalls[0].Rules[0].SourceIPs[0].String()).To(Equal("192.168.0.0/16"))
+		Expect(firewalls[0].AppliedTo).To(HaveLen(1))
+		Expect(cluster.Status.HCloudFirewalls[0].LabelSelector).To(Equal(cluster.ClusterTagKey() + "==owned"))
+
+		// remove firewall from spec
+		cluster.Spec.HCloudFirewalls = nil
+		Expect(service.Reconcile(ctx)).To(Succeed())
+		Expect(cluster.Status.HCloudFirewalls).To(BeEmpty())
+	})
+
+	It("deletes all owned firewalls", func() {
+		Expect(service.Reconcile(ctx)).To(Succeed())
+		Expect(service.Delete(ctx)).To(Succeed())
+
+		firewalls, err := service.findFirewalls(ctx)
+		Expect(err).To(BeNil())
+		Expect(firewalls).To(BeEmpty())
+	})
+
+	It("fails on invalid CIDRs", func() {
+		cluster.Spec.HCloudFirewalls[0].Rules[0].SourceIPs = []string{"10.0.0.0"}
+		Expect(service.Reconcile(ctx)).ToNot(Succeed())
+		Expect(conditions.IsFalse(cluster, infrav1.FirewallsSyncedCondition)).To(BeTrue())
+	})
+})
+
+var _ = Describe("rulesEqual", func() {
+	port := "80"
+	otherPort := "443"
+	_, ipNet, err := net.ParseCIDR("10.0.0.0/8")
+	Expect(err).To(BeNil())
+
+	DescribeTable("rulesEqual",
+		func(a, b []hcloud.FirewallRule, expectEqual bool) {
+			Expect(rulesEqual(a, b)).To(Equal(expectEqual))
+		},
+		Entry("both empty", nil, []hcloud.FirewallRule{}, true),
+		Entry("same rules",
+			[]hcloud.FirewallRule{{Direction: "in", Protocol: "tcp", Port: &port, SourceIPs: []net.IPNet{*ipNet}}},
+			[]hcloud.FirewallRule{{Direction: "in", Protocol: "tcp", Port: &port, SourceIPs: []net.IPNet{*ipNet}}},
+			true),
+		Entry("different port",
+			[]hcloud.FirewallRule{{Direction: "in", Protocol: "tcp", Port: &port, SourceIPs: []net.IPNet{*ipNet}}},
+			[]hcloud.FirewallRule{{Direction: "in", Protocol: "tcp", Port: &otherPort, SourceIPs: []net.IPNet{*ipNet}}},
+			false),
+		Entry("same rules in different order",
+			[]hcloud.FirewallRule{
+				{Direction: "in", Protocol: "tcp", Port: &port, SourceIPs: []net.IPNet{*ipNet}},
+				{Direction: "in", Protocol: "tcp", Port: &otherPort, SourceIPs: []net.IPNet{*ipNet}},
+			},
+			[]hcloud.FirewallRule{
+				{Direction: "in", Protocol: "tcp", Port: &otherPort, SourceIPs: []net.IPNet{*ipNet}},
+				{Direction: "in", Protocol: "tcp", Port: &port, SourceIPs: []net.IPNet{*ipNet}},
+			},
+			true),
+		Entry("different number of rules",
+			[]hcloud.FirewallRule{{Direction: "in", Protocol: "icmp", SourceIPs: []net.IPNet{*ipNet}}},
+			nil,
+			false),
+	)
+})
@@ -0,0 +1,199 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package floatingip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/syself/hrobot-go/models"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
+)
+
+// errMissingRobotClient is returned if the cluster uses a failover IP but has no robot credentials.
+var errMissingRobotClient = errors.New("robot credentials are required for the failover IP")
+
+func (s *Service) reconcileFailoverIP(ctx context.Context) (err error) {
+	ip := s.scope.HetznerCluster.Spec.ControlPlaneLoadBalancer.FloatingIP.FailoverIP
+
+	s.scope.HetznerCluster.Status.ControlPlaneFloatingIP = &infrav1.ControlPlaneFloatingIPStatus{
+		Type: infrav1.ControlPlaneFloatingIPTypeFailover,
+		IP:   ip,
+	}
+
+	defer func() {
+		if err != nil {
+			conditions.MarkFalse(
+				s.scope.HetznerCluster,
+				infrav1.ControlPlaneFloatingIPReadyCondition,
+				infrav1.FloatingIPAssignFailedReason,
+				clusterv1.ConditionSeverityError,
+				err.Error(),
+			)
+		}
+	}()
+
+	if s.scope.RobotClient == nil {
+		return errMissingRobotClient
+	}
+
+	hosts, err := s.findControlPlaneHosts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to find control plane hosts: %w", err)
+	}
+
+	failover, err := s.scope.RobotClient.GetFailoverIP(ip)
+	if err != nil {
+		s.handleRobotRateLimitExceeded(err, "GetFailoverIP")
+		return fmt.Errorf("failed to get failover IP %s: %w", ip, err)
+	}
+
+	if activeHost := findHostByIP(hosts, failover.ActiveServerIP); activeHost != nil {
+		s.scope.HetznerCluster.Status.ControlPlaneFloatingIP.ServerID = int64(activeHost.Spec.ServerID)
+	}
+
+	healthyHosts := s.healthyHosts(ctx, hosts)
+
+	// nothing to do if the failover IP is routed to a healthy host
+	if findHostByIP(healthyHosts, failover.ActiveServerIP) != nil {
+		conditions.MarkTrue(s.scope.HetznerCluster, infrav1.ControlPlaneFloatingIPReadyCondition)
+		return nil
+	}
+
+	if len(healthyHosts) == 0 {
+		conditions.MarkFalse(
+			s.scope.HetznerCluster,
+			infrav1.ControlPlaneFloatingIPReadyCondition,
+			infrav1.NoHealthyControlPlaneServerReason,
+			clusterv1.ConditionSeverityWarning,
+			"no bare metal control plane server with a ready API server found",
+		)
+		return nil
+	}
+
+	// route the failover IP to the first healthy host
+	host := healthyHosts[0]
+	if _, err := s.scope.RobotClient.SetFailoverIP(ip, host.Spec.Status.IPv4); err != nil {
+		s.handleRobotRateLimitExceeded(err, "SetFailoverIP")
+		err = fmt.Errorf("failed to route failover IP to bare metal server %d: %w", host.Spec.ServerID, err)
+		record.Warnf(s.scope.HetznerCluster, "FailedRouteFailoverIP", err.Error())
+		return err
+	}
+
+	record.Eventf(s.scope.HetznerCluster, "FailoverIPRouted", "Routed failover IP %s to bare metal server %d", ip, host.Spec.ServerID)
+
+	s.scope.HetznerCluster.Status.ControlPlaneFloatingIP.ServerID = int64(host.Spec.ServerID)
+	conditions.MarkTrue(s.scope.HetznerCluster, infrav1.ControlPlaneFloatingIPReadyCondition)
+	return nil
+}
+
+// findControlPlaneHosts returns the bare metal hosts that are consumed by the control plane machines of the cluster.
+func (s *Service) findControlPlaneHosts(ctx context.Context) ([]*infrav1.HetznerBareMetalHost, error) {
+	var machineList clusterv1.MachineList
+	if err := s.scope.Client.List(
+		ctx,
+		&machineList,
+		client.InNamespace(s.scope.Namespace()),
+		client.MatchingLabels{clusterv1.ClusterNameLabel: s.scope.Cluster.Name},
+		client.HasLabels{clusterv1.MachineControlPlaneLabel},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list machines: %w", err)
+	}
+
+	bareMetalMachineNames := make(map[string]struct{}, len(machineList.Items))
+	for _, machine := range machineList.Items {
+		if machine.Spec.InfrastructureRef.Kind == "HetznerBareMetalMachine" {
+			bareMetalMachineNames[machine.Spec.InfrastructureRef.Name] = struct{}{}
+		}
+	}
+
+	var hostList infrav1.HetznerBareMetalHostList
+	if err := s.scope.Client.List(ctx, &hostList, client.InNamespace(s.scope.Namespace())); err != nil {
+		return nil, fmt.Errorf("failed to list bare metal hosts: %w", err)
+	}
+
+	hosts := make([]*infrav1.HetznerBareMetalHost, 0, len(bareMetalMachineNames))
+	for i := range hostList.Items {
+		host := &hostList.Items[i]
+		if host.Spec.ConsumerRef == nil || host.Spec.ConsumerRef.Kind != "HetznerBareMetalMachine" {
+			continue
+		}
+		if _, found := bareMetalMachineNames[host.Spec.ConsumerRef.Name]; found {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts, nil
+}
+
+// healthyHosts returns the provisioned hosts with a ready API server sorted by their server ID.
+func (s *Service) healthyHosts(ctx context.Context, hosts []*infrav1.HetznerBareMetalHost) []*infrav1.HetznerBareMetalHost {
+	port := strconv.Itoa(s.scope.HetznerCluster.Spec.ControlPlaneLoadBalancer.Port)
+
+	healthyHosts := make([]*infrav1.HetznerBareMetalHost, 0, len(hosts))
+	for _, host := range hosts {
+		if host.Spec.Status.ProvisioningState != infrav1.StateProvisioned || host.Spec.Status.IPv4 == "" {
+			continue
+		}
+
+		if err := s.healthCheck(ctx, net.JoinHostPort(host.Spec.Status.IPv4, port)); err != nil {
+			s.scope.V(1).Info("API server of bare metal control plane server not ready", "host", host.Name, "err", err)
+			continue
+		}
+		healthyHosts = append(healthyHosts, host)
+	}
+
+	sort.Slice(healthyHosts, func(i, j int) bool {
+		return healthyHosts[i].Spec.ServerID < healthyHosts[j].Spec.ServerID
+	})
+	return healthyHosts
+}
+
+func (s *Service) handleRobotRateLimitExceeded(err error, functionName string) {
+	if models.IsError(err, models.ErrorCodeRateLimitExceeded) || strings.Contains(err.Error(), "server responded with status code 403") {
+		msg := fmt.Sprintf("exceeded robot rate limit with calling function %q: %s", functionName, err.Error())
+		conditions.MarkFalse(
+			s.scope.HetznerCluster,
+			infrav1.HetznerAPIReachableCondition,
+			infrav1.RateLimitExceededReason,
+			clusterv1.ConditionSeverityWarning,
+			msg,
+		)
+		record.Warnf(s.scope.HetznerCluster, "RateLimitExceeded", msg)
+	}
+}
+
+func findHostByIP(hosts []*infrav1.HetznerBareMetalHost, ip string) *infrav1.HetznerBareMetalHost {
+	if ip == "" {
+		return nil
+	}
+	for _, host := range hosts {
+		if host.Spec.Status.IPv4 == ip {
+			return host
+		}
+	}
+	return nil
+}
@@ -0,0 +1,563 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package floatingip implements the lifecycle of the Floating IP, Primary IP or failover IP used as control plane endpoint.
+package floatingip
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/record"
+	"sigs.k8s.io/cluster-api/util/secret"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
+	"github.com/syself/cluster-api-provider-hetzner/pkg/scope"
+	hcloudutil "github.com/syself/cluster-api-provider-hetzner/pkg/services/hcloud/util"
+	"github.com/syself/cluster-api-provider-hetzner/pkg/utils"
+)
+
+// HealthCheckInterval defines how often the readiness of the API servers of the control plane servers is checked.
+const HealthCheckInterval = 30 * time.Second
+
+const healthCheckTimeout = 5 * time.Second
+
+// ErrNoDatacenterFound indicates that no datacenter could be found in the region of the control plane.
+var ErrNoDatacenterFound = fmt.Errorf("no datacenter found")
+
+// HealthCheckFunc checks whether the API server under the given address is ready.
+type HealthCheckFunc func(ctx context.Context, address string) error
+
+// Service is a struct with the cluster scope to reconcile the IP used as control plane endpoint.
+type Service struct {
+	scope       *scope.ClusterScope
+	healthCheck HealthCheckFunc
+
+	// rootCAs contains the CA of the cluster once it has been loaded by the health check.
+	rootCAs *x509.CertPool
+}
+
+// NewService creates a new service object.
+func NewService(scope *scope.ClusterScope) *Service {
+	s := &Service{scope: scope}
+	s.healthCheck = s.checkAPIServer
+	return s
+}
+
+// Reconcile implements the life cycle of the IP used as control plane endpoint.
+func (s *Service) Reconcile(ctx context.Context) error {
+	spec := s.scope.HetznerCluster.Spec.ControlPlaneLoadBalancer.FloatingIP
+	if spec == nil {
+		return nil
+	}
+
+	// failover IPs can only be routed to bare metal servers
+	if spec.Type == infrav1.ControlPlaneFloatingIPTypeFailover {
+		return s.reconcileFailoverIP(ctx)
+	}
+
+	servers, err := s.findControlPlaneServers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to find control plane servers: %w", err)
+	}
+
+	healthyServers := s.healthyServers(ctx, servers)
+
+	if spec.Type == infrav1.ControlPlaneFloatingIPTypePrimary {
+		return s.reconcilePrimaryIP(ctx, servers, healthyServers)
+	}
+	return s.reconcileFloatingIP(ctx, healthyServers)
+}
+
+func (s *Service) reconcileFloatingIP(ctx context.Context, healthyServers []*hcloud.Server) error {
+	floatingIP, err := s.findFloatingIP(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to find floating IP: %w", err)
+	}
+
+	if floatingIP == nil {
+		floatingIP, err = s.createFloatingIP(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create floating IP: %w", err)
+		}
+	}
+
+	s.scope.HetznerCluster.Status.ControlPlaneFloatingIP = statusFromHCloudFloatingIP(floatingIP)
+
+	// nothing to do if the floating IP is assigned to a healthy server
+	if floatingIP.Server != nil && findServer(healthyServers, floatingIP.Server.ID) != nil {
+		conditions.MarkTrue(s.scope.HetznerCluster, infrav1.ControlPlaneFloatingIPReadyCondition)
+		return nil
+	}
+
+	if len(healthyServers) == 0 {
+		conditions.MarkFalse(
+			s.scope.HetznerCluster,
+			infrav1.ControlPlaneFloatingIPReadyCondition,
+			infrav1.NoHealthyControlPlaneServerReason,
+			clusterv1.ConditionSeverityWarning,
+			"no control plane server with a ready API server found",
+		)
+		return nil
+	}
+
+	// assign the floating IP to the first healthy server
+	server := healthyServers[0]
+	if err := s.scope.HCloudClient.AssignFloatingIP(ctx, floatingIP, server); err != nil {
+		hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "AssignFloatingIP")
+		err = fmt.Errorf("failed to assign floating IP to server %d: %w", server.ID, err)
+		record.Warnf(s.scope.HetznerCluster, "FailedAssignFloatingIP", err.Error())
+		conditions.MarkFalse(
+			s.scope.HetznerCluster,
+			infrav1.ControlPlaneFloatingIPReadyCondition,
+			infrav1.FloatingIPAssignFailedReason,
+			clusterv1.ConditionSeverityError,
+			err.Error(),
+		)
+		return err
+	}
+
+	record.Eventf(s.scope.HetznerCluster, "FloatingIPAssigned", "Assigned floating IP %s to server %s", floatingIP.IP, server.Name)
+
+	s.scope.HetznerCluster.Status.ControlPlaneFloatingIP.ServerID = server.ID
+	conditions.MarkTrue(s.scope.HetznerCluster, infrav1.ControlPlaneFloatingIPReadyCondition)
+	return nil
+}
+
+func (s *Service) reconcilePrimaryIP(ctx context.Context, servers, healthyServers []*hcloud.Server) error {
+	primaryIP, err := s.findPrimaryIP(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to find primary IP: %w", err)
+	}
+
+	if primaryIP == nil {
+		primaryIP, err = s.createPrimaryIP(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create primary IP: %w", err)
+		}
+	}
+
+	s.scope.HetznerCluster.Status.ControlPlaneFloatingIP = statusFromHCloudPrimaryIP(primaryIP)
+
+	// the primary IP is assigned by the server service to the next control plane server that is created
+	if primaryIP.AssigneeID == 0 {
+		conditions.MarkFalse(
+			s.scope.HetznerCluster,
+			infrav1.ControlPlaneFloatingIPReadyCondition,
+			infrav1.NoHealthyControlPlaneServerReason,
+			clusterv1.ConditionSeverityWarning,
+			"primary IP is not assigned. Waiting for the next control plane server to be created",
+		)
+		return nil
+	}
+
+	// nothing to do if the primary IP is assigned to a healthy server
+	if findServer(healthyServers, primaryIP.AssigneeID) != nil {
+		conditions.MarkTrue(s.scope.HetznerCluster, infrav1.ControlPlaneFloatingIPReadyCondition)
+		return nil
+	}
+
+	// primary IPs can only be unassigned from servers that are powered off
+	serverID := primaryIP.AssigneeID
+	server := findServer(servers, serverID)
+	if server != nil && server.Status != hcloud.ServerStatusOff {
+		conditions.MarkFalse(
+			s.scope.HetznerCluster,
+			infrav1.ControlPlaneFloatingIPReadyCondition,
+			infrav1.NoHealthyControlPlaneServerReason,
+			clusterv1.ConditionSeverityWarning,
+			"API server of server %d with primary IP is not ready. The IP is released once the server is powered off",
+			serverID,
+		)
+		return nil
+	}
+
+	if err := s.scope.HCloudClient.UnassignPrimaryIP(ctx, primaryIP); err != nil {
+		hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "UnassignPrimaryIP")
+		err = fmt.Errorf("failed to unassign primary IP from server %d: %w", serverID, err)
+		record.Warnf(s.scope.HetznerCluster, "FailedUnassignPrimaryIP", err.Error())
+		conditions.MarkFalse(
+			s.scope.HetznerCluster,
+			infrav1.ControlPlaneFloatingIPReadyCondition,
+			infrav1.FloatingIPAssignFailedReason,
+			clusterv1.ConditionSeverityError,
+			err.Error(),
+		)
+		return err
+	}
+
+	record.Eventf(s.scope.HetznerCluster, "PrimaryIPUnassigned", "Unassigned primary IP %s from unhealthy server %d", primaryIP.IP, serverID)
+
+	s.scope.HetznerCluster.Status.ControlPlaneFloatingIP.ServerID = 0
+	conditions.MarkFalse(
+		s.scope.HetznerCluster,
+		infrav1.ControlPlaneFloatingIPReadyCondition,
+		infrav1.NoHealthyControlPlaneServerReason,
+		clusterv1.ConditionSeverityWarning,
+		"primary IP is not assigned. Waiting for the next control plane server to be created",
+	)
+	return nil
+}
+
+// Delete implements the deletion of the IP used as control plane endpoint.
+func (s *Service) Delete(ctx context.Context) error {
+	spec := s.scope.HetznerCluster.Spec.ControlPlaneLoadBalancer.FloatingIP
+	if spec == nil {
+		return nil
+	}
+
+	// failover IPs are ordered in Robot and stay with the user
+	if spec.Type == infrav1.ControlPlaneFloatingIPTypeFailover {
+		s.scope.HetznerCluster.Status.ControlPlaneFloatingIP = nil
+		return nil
+	}
+
+	floatingIP, err := s.findFloatingIP(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to find floating IP: %w", err)
+	}
+
+	primaryIP, err := s.findPrimaryIP(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to find primary IP: %w", err)
+	}
+
+	var multierr error
+	if floatingIP != nil {
+		if err := s.scope.HCloudClient.DeleteFloatingIP(ctx, floatingIP); err != nil {
+			hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "DeleteFloatingIP")
+			if !hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
+				multierr = errors.Join(multierr, fmt.Errorf("failed to delete floating IP %d: %w", floatingIP.ID, err))
+			}
+		}
+	}
+
+	if primaryIP != nil {
+		if err := s.scope.HCloudClient.DeletePrimaryIP(ctx, primaryIP); err != nil {
+			hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "DeletePrimaryIP")
+			if !hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
+				multierr = errors.Join(multierr, fmt.Errorf("failed to delete primary IP %d: %w", primaryIP.ID, err))
+			}
+		}
+	}
+
+	if multierr != nil {
+		conditions.MarkFalse(
+			s.scope.HetznerCluster,
+			infrav1.ControlPlaneFloatingIPReadyCondition,
+			infrav1.FloatingIPDeleteFailedReason,
+			clusterv1.ConditionSeverityWarning,
+			multierr.Error(),
+		)
+		return fmt.Errorf("aggregate error - deleting control plane IPs: %w", multierr)
+	}
+
+	s.scope.HetznerCluster.Status.ControlPlaneFloatingIP = nil
+	record.Eventf(s.scope.HetznerCluster, "ControlPlaneIPDeleted", "Deleted control plane IP")
+	return nil
+}
+
+func (s *Service) createFloatingIP(ctx context.Context) (*hcloud.FloatingIP, error) {
+	name := s.ipName()
+	opts := hcloud.FloatingIPCreateOpts{
+		Type:         hcloud.FloatingIPTypeIPv4,
+		HomeLocation: &hcloud.Location{Name: string(s.region())},
+		Name:         &name,
+		Labels:       s.createLabels(),
+	}
+
+	floatingIP, err := s.scope.HCloudClient.CreateFloatingIP(ctx, opts)
+	if err != nil {
+		hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "CreateFloatingIP")
+		record.Warnf(s.scope.HetznerCluster, "FailedCreateFloatingIP", "Failed to create floating IP: %s", err)
+		conditions.MarkFalse(
+			s.scope.HetznerCluster,
+			infrav1.ControlPlaneFloatingIPReadyCondition,
+			infrav1.FloatingIPCreateFailedReason,
+			clusterv1.ConditionSeverityError,
+			err.Error(),
+		)
+		return nil, err
+	}
+
+	record.Eventf(s.scope.HetznerCluster, "CreateFloatingIP", "Created floating IP %s", floatingIP.IP)
+	return floatingIP, nil
+}
+
+func (s *Service) createPrimaryIP(ctx context.Context) (*hcloud.PrimaryIP, error) {
+	// primary IPs are bound to a datacenter, so the first datacenter of the region is taken
+	datacenter, err := s.findDatacenter(ctx, s.region())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find datacenter: %w", err)
+	}
+
+	autoDelete := false
+	opts := hcloud.PrimaryIPCreateOpts{
+		Name:         s.ipName(),
+		Type:         hcloud.PrimaryIPTypeIPv4,
+		AssigneeType: "server",
+		Datacenter:   datacenter.Name,
+		AutoDelete:   &autoDelete,
+		Labels:       s.createLabels(),
+	}
+
+	primaryIP, err := s.scope.HCloudClient.CreatePrimaryIP(ctx, opts)
+	if err != nil {
+		hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "CreatePrimaryIP")
+		record.Warnf(s.scope.HetznerCluster, "FailedCreatePrimaryIP", "Failed to create primary IP: %s", err)
+		conditions.MarkFalse(
+			s.scope.HetznerCluster,
+			infrav1.ControlPlaneFloatingIPReadyCondition,
+			infrav1.FloatingIPCreateFailedReason,
+			clusterv1.ConditionSeverityError,
+			err.Error(),
+		)
+		return nil, err
+	}
+
+	record.Eventf(s.scope.HetznerCluster, "CreatePrimaryIP", "Created primary IP %s", primaryIP.IP)
+	return primaryIP, nil
+}
+
+func (s *Service) findFloatingIP(ctx context.Context) (*hcloud.FloatingIP, error) {
+	opts := hcloud.FloatingIPListOpts{}
+	opts.LabelSelector = utils.LabelsToLabelSelector(s.createLabels())
+
+	floatingIPs, err := s.scope.HCloudClient.ListFloatingIPs(ctx, opts)
+	if err != nil {
+		hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "ListFloatingIPs")
+		return nil, fmt.Errorf("failed to list floating IPs: %w", err)
+	}
+
+	if len(floatingIPs) > 1 {
+		return nil, fmt.Errorf("found %d floating IPs of cluster, expected at most one", len(floatingIPs))
+	}
+	if len(floatingIPs) == 0 {
+		return nil, nil
+	}
+	return floatingIPs[0], nil
+}
+
+func (s *Service) findPrimaryIP(ctx context.Context) (*hcloud.PrimaryIP, error) {
+	opts := hcloud.PrimaryIPListOpts{}
+	opts.LabelSelector = utils.LabelsToLabelSelector(s.createLabels())
+
+	primaryIPs, err := s.scope.HCloudClient.ListPrimaryIPs(ctx, opts)
+	if err != nil {
+		hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "ListPrimaryIPs")
+		return nil, fmt.Errorf("failed to list primary IPs: %w", err)
+	}
+
+	if len(primaryIPs) > 1 {
+		return nil, fmt.Errorf("found %d primary IPs of cluster, expected at most one", len(primaryIPs))
+	}
+	if len(primaryIPs) == 0 {
+		return nil, nil
+	}
+	return primaryIPs[0], nil
+}
+
+func (s *Service) findDatacenter(ctx context.Context, region infrav1.Region) (*hcloud.Datacenter, error) {
+	datacenters, err := s.scope.HCloudClient.ListDatacenters(ctx, hcloud.DatacenterListOpts{})
+	if err != nil {
+		hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "ListDatacenters")
+		return nil, fmt.Errorf("failed to list datacenters: %w", err)
+	}
+
+	for _, datacenter := range datacenters {
+		if datacenter.Location != nil && datacenter.Location.Name == string(region) {
+			return datacenter, nil
+		}
+	}
+	return nil, fmt.Errorf("%w in region %s", ErrNoDatacenterFound, region)
+}
+
+func (s *Service) findControlPlaneServers(ctx context.Context) ([]*hcloud.Server, error) {
+	labels := map[string]string{
+		s.scope.HetznerCluster.ClusterTagKey(): string(infrav1.ResourceLifecycleOwned),
+		"machine_type":                         "control_plane",
+	}
+	opts := hcloud.ServerListOpts{}
+	opts.LabelSelector = utils.LabelsToLabelSelector(labels)
+
+	servers, err := s.scope.HCloudClient.ListServers(ctx, opts)
+	if err != nil {
+		hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "ListServers")
+		return nil, fmt.Errorf("failed to list servers: %w", err)
+	}
+	return servers, nil
+}
+
+// healthyServers returns the running servers with a ready API server sorted by their ID.
+func (s *Service) healthyServers(ctx context.Context, servers []*hcloud.Server) []*hcloud.Server {
+	port := strconv.Itoa(s.scope.HetznerCluster.Spec.ControlPlaneLoadBalancer.Port)
+
+	healthyServers := make([]*hcloud.Server, 0, len(servers))
+	for _, server := range servers {
+		if server.Status != hcloud.ServerStatusRunning {
+			continue
+		}
+
+		ip := serverIP(server)
+		if ip == nil {
+			continue
+		}
+
+		if err := s.healthCheck(ctx, net.JoinHostPort(ip.String(), port)); err != nil {
+			s.scope.V(1).Info("API server of control plane server not ready", "server", server.Name, "err", err)
+			continue
+		}
+		healthyServers = append(healthyServers, server)
+	}
+
+	sort.Slice(healthyServers, func(i, j int) bool {
+		return healthyServers[i].ID < healthyServers[j].ID
+	})
+	return healthyServers
+}
+
+func (s *Service) createLabels() map[string]string {
+	return map[string]string{
+		s.scope.HetznerCluster.ClusterTagKey(): string(infrav1.ResourceLifecycleOwned),
+	}
+}
+
+func (s *Service) ipName() string {
+	return fmt.Sprintf("%s-kube-apiserver", s.scope.HetznerCluster.Name)
+}
+
+// region returns the region in which the IP is created, which is the first control plane region.
+func (s *Service) region() infrav1.Region {
+	if len(s.scope.HetznerCluster.Spec.ControlPlaneRegions) == 0 {
+		return ""
+	}
+	return s.scope.HetznerCluster.Spec.ControlPlaneRegions[0]
+}
+
+// serverIP returns the public IPv4 of the server, or the private IP if the server has no public IPv4.
+func serverIP(server *hcloud.Server) net.IP {
+	if ip := server.PublicNet.IPv4.IP; ip != nil && !ip.IsUnspecified() {
+		return ip
+	}
+	if len(server.PrivateNet) > 0 {
+		return server.PrivateNet[0].IP
+	}
+	return nil
+}
+
+func findServer(servers []*hcloud.Server, id int64) *hcloud.Server {
+	for _, server := range servers {
+		if server.ID == id {
+			return server
+		}
+	}
+	return nil
+}
+
+// checkAPIServer checks the readyz endpoint of the API server under the given address. The certificate of the
+// API server is verified with the CA of the cluster against the host of the control plane endpoint, as the
+// certificates of all API servers of the cluster have to be valid for it.
+func (s *Service) checkAPIServer(ctx context.Context, address string) error {
+	if s.rootCAs == nil {
+		// the CA is not labeled for the cache of the controller, so it is read from the API server directly
+		caSecret, err := secret.GetFromNamespacedName(ctx, s.scope.APIReader, client.ObjectKeyFromObject(s.scope.Cluster), secret.ClusterCA)
+		if err != nil {
+			return fmt.Errorf("failed to get CA of cluster: %w", err)
+		}
+
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(caSecret.Data[secret.TLSCrtDataName]) {
+			return fmt.Errorf("failed to parse CA of cluster")
+		}
+		s.rootCAs = rootCAs
+	}
+
+	httpClient := &http.Client{
+		Timeout: healthCheckTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:    s.rootCAs,
+				ServerName: s.endpointHost(),
+				MinVersion: tls.VersionTLS12,
+			},
+			DisableKeepAlives: true,
+		},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("https://%s/readyz", address), http.NoBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API server is not ready: status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// endpointHost returns the host of the control plane endpoint, or the IP if the endpoint has not been set yet.
+func (s *Service) endpointHost() string {
+	if endpoint := s.scope.HetznerCluster.Spec.ControlPlaneEndpoint; endpoint != nil && endpoint.Host != "" {
+		return endpoint.Host
+	}
+	if status := s.scope.HetznerCluster.Status.ControlPlaneFloatingIP; status != nil {
+		return status.IP
+	}
+	return ""
+}
+
+func statusFromHCloudFloatingIP(floatingIP *hcloud.FloatingIP) *infrav1.ControlPlaneFloatingIPStatus {
+	status := &infrav1.ControlPlaneFloatingIPStatus{
+		ID:   floatingIP.ID,
+		Type: infrav1.ControlPlaneFloatingIPTypeFloating,
+		IP:   floatingIP.IP.String(),
+	}
+	if floatingIP.Server != nil {
+		status.ServerID = floatingIP.Server.ID
+	}
+	return status
+}
+
+func statusFromHCloudPrimaryIP(primaryIP *hcloud.PrimaryIP) *infrav1.ControlPlaneFloatingIPStatus {
+	status := &infrav1.ControlPlaneFloatingIPStatus{
+		ID:       primaryIP.ID,
+		Type:     infrav1.ControlPlaneFloatingIPTypePrimary,
+		IP:       primaryIP.IP.String(),
+		ServerID: primaryIP.AssigneeID,
+	}
+	if primaryIP.Datacenter != nil {
+		status.Datacenter = primaryIP.Datacenter.Name
+	}
+	return status
+}
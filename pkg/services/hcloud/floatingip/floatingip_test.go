@@ -0,0 +1,346 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package floatingip
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"github.com/syself/hrobot-go/models"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/klog/v2/klogr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/secret"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	k8sfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
+	"github.com/syself/cluster-api-provider-hetzner/pkg/scope"
+	robotmock "github.com/syself/cluster-api-provider-hetzner/pkg/services/baremetal/client/mocks/robot"
+	hcloudclient "github.com/syself/cluster-api-provider-hetzner/pkg/services/hcloud/client"
+	fakeclient "github.com/syself/cluster-api-provider-hetzner/pkg/services/hcloud/client/fake"
+)
+
+var _ = Describe("FloatingIP service", func() {
+	var (
+		ctx            context.Context
+		hcloudClient   hcloudclient.Client
+		service        *Service
+		cluster        *infrav1.HetznerCluster
+		unhealthyHosts map[string]struct{}
+	)
+
+	createControlPlaneServer := func(name, ip string) *hcloud.Server {
+		server, err := hcloudClient.CreateServer(ctx, hcloud.ServerCreateOpts{
+			Name: name,
+			Labels: map[string]string{
+				cluster.ClusterTagKey(): string(infrav1.ResourceLifecycleOwned),
+				"machine_type":          "control_plane",
+			},
+		})
+		Expect(err).To(Succeed())
+		server.PublicNet.IPv4.IP = net.ParseIP(ip)
+		return server
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		hcloudClient = fakeclient.NewHCloudClientFactory().NewClient("")
+		hcloudClient.Close()
+
+		cluster = &infrav1.HetznerCluster{}
+		cluster.Name = "my-cluster"
+		cluster.Spec.ControlPlaneRegions = []infrav1.Region{"fsn1"}
+		cluster.Spec.ControlPlaneLoadBalancer.Port = 6443
+		cluster.Spec.ControlPlaneLoadBalancer.FloatingIP = &infrav1.ControlPlaneFloatingIPSpec{
+			Type: infrav1.ControlPlaneFloatingIPTypeFloating,
+		}
+
+		unhealthyHosts = make(map[string]struct{})
+
+		service = NewService(&scope.ClusterScope{Logger: klogr.New(), HCloudClient: hcloudClient, HetznerCluster: cluster})
+		service.healthCheck = func(_ context.Context, address string) error {
+			host, _, err := net.SplitHostPort(address)
+			Expect(err).To(Succeed())
+			if _, found := unhealthyHosts[host]; found {
+				return fmt.Errorf("connection refused")
+			}
+			return nil
+		}
+	})
+
+	Context("health check", func() {
+		var (
+			apiServer *httptest.Server
+			ready     bool
+		)
+
+		BeforeEach(func() {
+			ready = true
+			apiServer = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/readyz" || !ready {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				fmt.Fprint(w, "ok")
+			}))
+
+			// the certificate of the test server is self-signed and valid for example.com
+			caSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: secret.Name("my-cluster", secret.ClusterCA), Namespace: "default"},
+				Data: map[string][]byte{
+					secret.TLSCrtDataName: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: apiServer.Certificate().Raw}),
+				},
+			}
+			scheme := runtime.NewScheme()
+			utilruntime.Must(corev1.AddToScheme(scheme))
+
+			service.scope.APIReader = k8sfake.NewClientBuilder().WithScheme(scheme).WithObjects(caSecret).Build()
+			service.scope.Cluster = &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"}}
+			cluster.Spec.ControlPlaneEndpoint = &clusterv1.APIEndpoint{Host: "example.com", Port: 6443}
+		})
+
+		AfterEach(func() {
+			apiServer.Close()
+		})
+
+		It("checks whether the API server is ready", func() {
+			address := strings.TrimPrefix(apiServer.URL, "https://")
+
+			Expect(service.checkAPIServer(ctx, address)).To(Succeed())
+
+			ready = false
+			Expect(service.checkAPIServer(ctx, address)).ToNot(Succeed())
+		})
+
+		It("does not trust API servers whose certificate is not valid for the control plane endpoint", func() {
+			cluster.Spec.ControlPlaneEndpoint.Host = "my-cluster.example.org"
+
+			Expect(service.checkAPIServer(ctx, strings.TrimPrefix(apiServer.URL, "https://"))).ToNot(Succeed())
+		})
+	})
+
+	Context("floating IP", func() {
+		It("creates the floating IP without any control plane server", func() {
+			Expect(service.Reconcile(ctx)).To(Succeed())
+
+			Expect(cluster.Status.ControlPlaneFloatingIP).ToNot(BeNil())
+			Expect(cluster.Status.ControlPlaneFloatingIP.IP).ToNot(BeEmpty())
+			Expect(cluster.Status.ControlPlaneFloatingIP.ServerID).To(BeZero())
+			Expect(conditions.GetReason(cluster, infrav1.ControlPlaneFloatingIPReadyCondition)).To(Equal(infrav1.NoHealthyControlPlaneServerReason))
+		})
+
+		It("assigns the floating IP to a healthy server and reassigns it if the server becomes unhealthy", func() {
+			server1 := createControlPlaneServer("cp-1", "192.0.2.1")
+			server2 := createControlPlaneServer("cp-2", "192.0.2.2")
+
+			Expect(service.Reconcile(ctx)).To(Succeed())
+			Expect(cluster.Status.ControlPlaneFloatingIP.ServerID).To(Equal(server1.ID))
+			Expect(conditions.IsTrue(cluster, infrav1.ControlPlaneFloatingIPReadyCondition)).To(BeTrue())
+
+			unhealthyHosts["192.0.2.1"] = struct{}{}
+
+			Expect(service.Reconcile(ctx)).To(Succeed())
+			Expect(cluster.Status.ControlPlaneFloatingIP.ServerID).To(Equal(server2.ID))
+			Expect(conditions.IsTrue(cluster, infrav1.ControlPlaneFloatingIPReadyCondition)).To(BeTrue())
+
+			// the floating IP stays on the healthy server once the other one has recovered
+			delete(unhealthyHosts, "192.0.2.1")
+
+			Expect(service.Reconcile(ctx)).To(Succeed())
+			Expect(cluster.Status.ControlPlaneFloatingIP.ServerID).To(Equal(server2.ID))
+		})
+
+		It("deletes the floating IP", func() {
+			Expect(service.Reconcile(ctx)).To(Succeed())
+			Expect(service.Delete(ctx)).To(Succeed())
+
+			floatingIPs, err := hcloudClient.ListFloatingIPs(ctx, hcloud.FloatingIPListOpts{})
+			Expect(err).To(Succeed())
+			Expect(floatingIPs).To(BeEmpty())
+			Expect(cluster.Status.ControlPlaneFloatingIP).To(BeNil())
+		})
+	})
+
+	Context("primary IP", func() {
+		BeforeEach(func() {
+			cluster.Spec.ControlPlaneLoadBalancer.FloatingIP.Type = infrav1.ControlPlaneFloatingIPTypePrimary
+		})
+
+		It("creates an unassigned primary IP in a datacenter of the control plane region", func() {
+			Expect(service.Reconcile(ctx)).To(Succeed())
+
+			Expect(cluster.Status.ControlPlaneFloatingIP).ToNot(BeNil())
+			Expect(cluster.Status.ControlPlaneFloatingIP.Type).To(Equal(infrav1.ControlPlaneFloatingIPTypePrimary))
+			Expect(cluster.Status.ControlPlaneFloatingIP.Datacenter).To(HavePrefix("fsn1-"))
+			Expect(cluster.Status.ControlPlaneFloatingIP.ServerID).To(BeZero())
+		})
+
+		It("releases the primary IP only if the unhealthy server is powered off", func() {
+			Expect(service.Reconcile(ctx)).To(Succeed())
+
+			server, err := hcloudClient.CreateServer(ctx, hcloud.ServerCreateOpts{
+				Name: "cp-1",
+				Labels: map[string]string{
+					cluster.ClusterTagKey(): string(infrav1.ResourceLifecycleOwned),
+					"machine_type":          "control_plane",
+				},
+				PublicNet: &hcloud.ServerCreatePublicNet{
+					EnableIPv4: true,
+					IPv4:       &hcloud.PrimaryIP{ID: cluster.Status.ControlPlaneFloatingIP.ID},
+				},
+			})
+			Expect(err).To(Succeed())
+
+			Expect(service.Reconcile(ctx)).To(Succeed())
+			Expect(cluster.Status.ControlPlaneFloatingIP.ServerID).To(Equal(server.ID))
+			Expect(conditions.IsTrue(cluster, infrav1.ControlPlaneFloatingIPReadyCondition)).To(BeTrue())
+
+			unhealthyHosts[cluster.Status.ControlPlaneFloatingIP.IP] = struct{}{}
+
+			Expect(service.Reconcile(ctx)).To(Succeed())
+			Expect(cluster.Status.ControlPlaneFloatingIP.ServerID).To(Equal(server.ID))
+			Expect(conditions.IsFalse(cluster, infrav1.ControlPlaneFloatingIPReadyCondition)).To(BeTrue())
+
+			Expect(hcloudClient.ShutdownServer(ctx, server)).To(Succeed())
+
+			Expect(service.Reconcile(ctx)).To(Succeed())
+			Expect(cluster.Status.ControlPlaneFloatingIP.ServerID).To(BeZero())
+		})
+	})
+
+	Context("failover IP", func() {
+		const failoverIP = "198.51.100.1"
+
+		var (
+			robotClient    *robotmock.Client
+			activeServerIP string
+		)
+
+		newControlPlaneHost := func(name string, serverID int, ip string) []client.Object {
+			machine := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "default",
+					Labels: map[string]string{
+						clusterv1.ClusterNameLabel:         "my-cluster",
+						clusterv1.MachineControlPlaneLabel: "",
+					},
+				},
+				Spec: clusterv1.MachineSpec{
+					ClusterName:       "my-cluster",
+					InfrastructureRef: corev1.ObjectReference{Kind: "HetznerBareMetalMachine", Name: name},
+				},
+			}
+			host := &infrav1.HetznerBareMetalHost{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("host-%d", serverID), Namespace: "default"},
+				Spec: infrav1.HetznerBareMetalHostSpec{
+					ServerID:    serverID,
+					ConsumerRef: &corev1.ObjectReference{Kind: "HetznerBareMetalMachine", Name: name, Namespace: "default"},
+					Status: infrav1.ControllerGeneratedStatus{
+						ProvisioningState: infrav1.StateProvisioned,
+						IPv4:              ip,
+					},
+				},
+			}
+			return []client.Object{machine, host}
+		}
+
+		BeforeEach(func() {
+			cluster.Namespace = "default"
+			cluster.Spec.ControlPlaneLoadBalancer.FloatingIP = &infrav1.ControlPlaneFloatingIPSpec{
+				Type:       infrav1.ControlPlaneFloatingIPTypeFailover,
+				FailoverIP: failoverIP,
+			}
+
+			activeServerIP = ""
+			robotClient = &robotmock.Client{}
+			robotClient.On("GetFailoverIP", failoverIP).Return(func(string) *models.Failover {
+				return &models.Failover{IP: failoverIP, ActiveServerIP: activeServerIP}
+			}, nil)
+			robotClient.On("SetFailoverIP", failoverIP, mock.Anything).Return(func(_, ip string) *models.Failover {
+				activeServerIP = ip
+				return &models.Failover{IP: failoverIP, ActiveServerIP: activeServerIP}
+			}, nil)
+
+			scheme := runtime.NewScheme()
+			utilruntime.Must(infrav1.AddToScheme(scheme))
+			utilruntime.Must(clusterv1.AddToScheme(scheme))
+
+			var objects []client.Object
+			objects = append(objects, newControlPlaneHost("cp-1", 1, "192.0.2.1")...)
+			objects = append(objects, newControlPlaneHost("cp-2", 2, "192.0.2.2")...)
+			c := k8sfake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+			capiCluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"}}
+			service.scope.Client = c
+			service.scope.Cluster = capiCluster
+			service.scope.RobotClient = robotClient
+		})
+
+		It("routes the failover IP to a healthy bare metal server and reroutes it if the server becomes unhealthy", func() {
+			Expect(service.Reconcile(ctx)).To(Succeed())
+			Expect(cluster.Status.ControlPlaneFloatingIP.IP).To(Equal(failoverIP))
+			Expect(cluster.Status.ControlPlaneFloatingIP.ServerID).To(Equal(int64(1)))
+			Expect(activeServerIP).To(Equal("192.0.2.1"))
+			Expect(conditions.IsTrue(cluster, infrav1.ControlPlaneFloatingIPReadyCondition)).To(BeTrue())
+
+			unhealthyHosts["192.0.2.1"] = struct{}{}
+
+			Expect(service.Reconcile(ctx)).To(Succeed())
+			Expect(cluster.Status.ControlPlaneFloatingIP.ServerID).To(Equal(int64(2)))
+			Expect(activeServerIP).To(Equal("192.0.2.2"))
+
+			// the failover IP stays on the healthy server once the other one has recovered
+			delete(unhealthyHosts, "192.0.2.1")
+
+			Expect(service.Reconcile(ctx)).To(Succeed())
+			Expect(activeServerIP).To(Equal("192.0.2.2"))
+			robotClient.AssertNumberOfCalls(GinkgoT(), "SetFailoverIP", 2)
+		})
+
+		It("does not route the failover IP without a healthy bare metal server", func() {
+			unhealthyHosts["192.0.2.1"] = struct{}{}
+			unhealthyHosts["192.0.2.2"] = struct{}{}
+
+			Expect(service.Reconcile(ctx)).To(Succeed())
+			Expect(conditions.GetReason(cluster, infrav1.ControlPlaneFloatingIPReadyCondition)).To(Equal(infrav1.NoHealthyControlPlaneServerReason))
+			robotClient.AssertNotCalled(GinkgoT(), "SetFailoverIP", failoverIP, mock.Anything)
+		})
+
+		It("fails without robot credentials", func() {
+			service.scope.RobotClient = nil
+
+			Expect(service.Reconcile(ctx)).To(MatchError(errMissingRobotClient))
+			Expect(conditions.GetReason(cluster, infrav1.ControlPlaneFloatingIPReadyCondition)).To(Equal(infrav1.FloatingIPAssignFailedReason))
+		})
+	})
+})
@@ -0,0 +1,180 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancer
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/cluster-api/util/record"
+
+	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
+	secretutil "github.com/syself/cluster-api-provider-hetzner/pkg/secrets"
+	hcloudutil "github.com/syself/cluster-api-provider-hetzner/pkg/services/hcloud/util"
+	"github.com/syself/cluster-api-provider-hetzner/pkg/utils"
+)
+
+// reconcileCertificates returns the certificates of the services by listen port. Certificates that are issued by
+// HCloud or uploaded from a Secret are owned by the cluster and are created if they do not exist yet.
+func (s *Service) reconcileCertificates(ctx context.Context, services []infrav1.LoadBalancerServiceSpec) (map[int][]*hcloud.Certificate, error) {
+	var ownedByName map[string]*hcloud.Certificate
+	certificatesByPort := make(map[int][]*hcloud.Certificate)
+
+	for _, service := range services {
+		if service.HTTP == nil || len(service.HTTP.Certificates) == 0 {
+			continue
+		}
+
+		certificates := make([]*hcloud.Certificate, 0, len(service.HTTP.Certificates))
+		for _, spec := range service.HTTP.Certificates {
+			// certificates that are referenced by ID are not owned by the cluster
+			if spec.ID != nil {
+				certificates = append(certificates, &hcloud.Certificate{ID: *spec.ID})
+				continue
+			}
+
+			// list the owned certificates only if they are needed
+			if ownedByName == nil {
+				owned, err := s.listOwnedCertificates(ctx)
+				if err != nil {
+					return nil, err
+				}
+				ownedByName = make(map[string]*hcloud.Certificate, len(owned))
+				for _, certificate := range owned {
+					ownedByName[certificate.Name] = certificate
+				}
+			}
+
+			opts, err := s.certificateCreateOpts(ctx, spec)
+			if err != nil {
+				return nil, err
+			}
+
+			certificate, found := ownedByName[opts.Name]
+			if !found {
+				certificate, err = s.scope.HCloudClient.CreateCertificate(ctx, opts)
+				if err != nil {
+					hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "CreateCertificate")
+					err = fmt.Errorf("failed to create certificate %q: %w", opts.Name, err)
+					record.Warnf(s.scope.HetznerCluster, "FailedCreateCertificate", err.Error())
+					return nil, err
+				}
+				record.Eventf(s.scope.HetznerCluster, "CreateCertificate", "Created certificate %s", opts.Name)
+				ownedByName[opts.Name] = certificate
+			}
+			certificates = append(certificates, certificate)
+		}
+		certificatesByPort[service.ListenPort] = certificates
+	}
+
+	return certificatesByPort, nil
+}
+
+// certificateCreateOpts returns the options to create an owned certificate.
+func (s *Service) certificateCreateOpts(ctx context.Context, spec infrav1.LoadBalancerCertificateSpec) (hcloud.CertificateCreateOpts, error) {
+	hc := s.scope.HetznerCluster
+	opts := hcloud.CertificateCreateOpts{Labels: hcloudLabels(hc)}
+
+	if len(spec.DomainNames) > 0 {
+		opts.Type = hcloud.CertificateTypeManaged
+		opts.DomainNames = sorted(spec.DomainNames)
+		opts.Name = certificateName(hc, opts.Type, strings.Join(opts.DomainNames, ","))
+		return opts, nil
+	}
+
+	secretManager := secretutil.NewSecretManager(s.scope.Logger, s.scope.Client, s.scope.APIReader)
+	secret, err := secretManager.ObtainSecret(ctx, types.NamespacedName{Namespace: hc.Namespace, Name: spec.SecretName})
+	if err != nil {
+		return opts, fmt.Errorf("failed to get secret of certificate: %w", err)
+	}
+
+	opts.Type = hcloud.CertificateTypeUploaded
+	opts.Certificate = string(secret.Data[corev1.TLSCertKey])
+	opts.PrivateKey = string(secret.Data[corev1.TLSPrivateKeyKey])
+	if opts.Certificate == "" || opts.PrivateKey == "" {
+		return opts, fmt.Errorf("secret %q has to contain %s and %s", spec.SecretName, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+	}
+	opts.Name = certificateName(hc, opts.Type, opts.Certificate)
+	return opts, nil
+}
+
+// certificateName returns the name of an owned certificate. It contains a hash of the content of the certificate,
+// so that a changed certificate is created under a new name and replaces the old one.
+func certificateName(hc *infrav1.HetznerCluster, certificateType hcloud.CertificateType, content string) string {
+	hash := sha256.Sum256([]byte(content))
+	return fmt.Sprintf("%s-%s-%x", hc.Name, certificateType, hash[:5])
+}
+
+func (s *Service) listOwnedCertificates(ctx context.Context) ([]*hcloud.Certificate, error) {
+	opts := hcloud.CertificateListOpts{
+		ListOpts: hcloud.ListOpts{
+			LabelSelector: utils.LabelsToLabelSelector(map[string]string{
+				s.scope.HetznerCluster.ClusterTagKey(): string(infrav1.ResourceLifecycleOwned),
+			}),
+		},
+	}
+	certificates, err := s.scope.HCloudClient.ListCertificates(ctx, opts)
+	if err != nil {
+		hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "ListCertificates")
+		return nil, fmt.Errorf("failed to list certificates: %w", err)
+	}
+	return certificates, nil
+}
+
+// deleteUnusedCertificates deletes the owned certificates that are not used by any service. It has to be called
+// after the services have been updated, as certificates that are in use cannot be deleted.
+func (s *Service) deleteUnusedCertificates(ctx context.Context, certificatesByPort map[int][]*hcloud.Certificate) error {
+	used := make(map[int64]struct{})
+	for _, certificates := range certificatesByPort {
+		for _, certificate := range certificates {
+			used[certificate.ID] = struct{}{}
+		}
+	}
+
+	owned, err := s.listOwnedCertificates(ctx)
+	if err != nil {
+		return err
+	}
+
+	var multierr error
+	for _, certificate := range owned {
+		if _, found := used[certificate.ID]; found {
+			continue
+		}
+
+		if err := s.scope.HCloudClient.DeleteCertificate(ctx, certificate.ID); err != nil {
+			hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "DeleteCertificate")
+			if hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
+				continue
+			}
+			multierr = errors.Join(multierr, fmt.Errorf("failed to delete certificate %q: %w", certificate.Name, err))
+			if hcloud.IsError(err, hcloud.ErrorCodeRateLimitExceeded) {
+				return multierr
+			}
+			continue
+		}
+		record.Eventf(s.scope.HetznerCluster, "DeleteCertificate", "Deleted certificate %s", certificate.Name)
+	}
+
+	return multierr
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -60,6 +61,7 @@ func (s *Service) Reconcile(ctx context.Context) (reconcile.Result, error) {
 	}
 
 	log := s.scope.Logger.WithValues("reconciler", "load balancer")
+	lbSpec := s.scope.HetznerCluster.Spec.ControlPlaneLoadBalancer
 
 	// find load balancer
 	lb, err := s.findLoadBalancer(ctx)
@@ -68,18 +70,21 @@ func (s *Service) Reconcile(ctx context.Context) (reconcile.Result, error) {
 	}
 
 	if lb == nil {
-		// fixed name is set - we expect a load balancer with this name to exist
-
-		if s.scope.HetznerCluster.Spec.ControlPlaneLoadBalancer.Name != nil {
+		switch {
+		case lbSpec.Shared:
+			// a shared load balancer is not labeled, so it is looked up via its reference in every reconcile loop
+			lb, err = s.findExistingLoadBalancer(ctx)
+		case hasExistingLoadBalancerRef(lbSpec):
+			// reference is set - we expect a load balancer to exist
 			lb, err = s.ownExistingLoadBalancer(ctx)
-
-			// if load balancer is not found even though we expect it to exist, wait and reconcile until user creates it
-			if errors.Is(err, ErrNoLoadBalancerAvailable) {
-				return reconcile.Result{RequeueAfter: 1 * time.Minute}, nil
-			}
-		} else {
+		default:
 			lb, err = s.createLoadBalancer(ctx)
 		}
+
+		// if load balancer is not found even though we expect it to exist, wait and reconcile until user creates it
+		if errors.Is(err, ErrNoLoadBalancerAvailable) {
+			return reconcile.Result{RequeueAfter: 1 * time.Minute}, nil
+		}
 		if err != nil {
 			return reconcile.Result{}, fmt.Errorf("failed to own/create load balancer: %w", err)
 		}
@@ -87,19 +92,35 @@ func (s *Service) Reconcile(ctx context.Context) (reconcile.Result, error) {
 
 	s.scope.HetznerCluster.Status.ControlPlaneLoadBalancer = statusFromHCloudLB(lb, s.scope.HetznerCluster.Status.Network != nil, log)
 
-	// check whether load balancer name, algorithm or type has been changed
-	if err := s.reconcileLBProperties(ctx, lb); err != nil {
-		conditions.MarkFalse(
-			s.scope.HetznerCluster,
-			infrav1.LoadBalancerReadyCondition,
-			infrav1.LoadBalancerUpdateFailedReason,
-			clusterv1.ConditionSeverityWarning,
-			err.Error(),
-		)
-		return reconcile.Result{}, fmt.Errorf("failed to reconcile load balancer properties: %w", err)
+	// check whether load balancer name, algorithm or type has been changed. A shared load balancer is left as it is.
+	if !lbSpec.Shared {
+		if err := s.reconcileLBProperties(ctx, lb); err != nil {
+			conditions.MarkFalse(
+				s.scope.HetznerCluster,
+				infrav1.LoadBalancerReadyCondition,
+				infrav1.LoadBalancerUpdateFailedReason,
+				clusterv1.ConditionSeverityWarning,
+				err.Error(),
+			)
+			return reconcile.Result{}, fmt.Errorf("failed to reconcile load balancer properties: %w", err)
+		}
 	}
 
-	if err := s.reconcileNetworkAttachement(ctx, lb); err != nil {
+	if lbSpec.Shared {
+		// a shared load balancer is attached to the network by its owner, as the attachment cannot be undone
+		// without affecting other users of the load balancer
+		if !s.isAttachedToNetwork(lb) {
+			conditions.MarkFalse(
+				s.scope.HetznerCluster,
+				infrav1.LoadBalancerReadyCondition,
+				infrav1.LoadBalancerNotAttachedToNetworkReason,
+				clusterv1.ConditionSeverityWarning,
+				"shared load balancer %d is not attached to network %d of the cluster",
+				lb.ID, s.scope.HetznerCluster.Status.Network.ID,
+			)
+			return reconcile.Result{RequeueAfter: 1 * time.Minute}, nil
+		}
+	} else if err := s.reconcileNetworkAttachement(ctx, lb); err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to reconcile network attachment: %w", err)
 	}
 
@@ -173,6 +194,20 @@ func (s *Service) reconcileNetworkAttachement(ctx context.Context, lb *hcloud.Lo
 	return nil
 }
 
+// isAttachedToNetwork checks whether the load balancer is attached to the network of the cluster. It returns true
+// if the cluster has no network (yet).
+func (s *Service) isAttachedToNetwork(lb *hcloud.LoadBalancer) bool {
+	if !s.scope.HetznerCluster.Spec.HCloudNetwork.Enabled || s.scope.HetznerCluster.Status.Network == nil {
+		return true
+	}
+	for _, privateNet := range lb.PrivateNet {
+		if privateNet.Network != nil && privateNet.Network.ID == s.scope.HetznerCluster.Status.Network.ID {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Service) reconcileLBProperties(ctx context.Context, lb *hcloud.LoadBalancer) error {
 	var multierr error
 	lbSpec := s.scope.HetznerCluster.Spec.ControlPlaneLoadBalancer
@@ -188,6 +223,23 @@ func (s *Service) reconcileLBProperties(ctx context.Context, lb *hcloud.LoadBala
 		}
 	}
 
+	// check if the public interface has to be disabled or enabled
+	if lbSpec.Private && lb.PublicNet.Enabled {
+		if err := s.scope.HCloudClient.DisableLoadBalancerPublicInterface(ctx, lb); err != nil {
+			hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "DisableLoadBalancerPublicInterface")
+			multierr = errors.Join(multierr, fmt.Errorf("failed to disable public interface of load balancer: %w", err))
+		} else {
+			record.Eventf(s.scope.HetznerCluster, "DisableLoadBalancerPublicInterface", "Disabled public interface of load balancer")
+		}
+	} else if !lbSpec.Private && !lb.PublicNet.Enabled {
+		if err := s.scope.HCloudClient.EnableLoadBalancerPublicInterface(ctx, lb); err != nil {
+			hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "EnableLoadBalancerPublicInterface")
+			multierr = errors.Join(multierr, fmt.Errorf("failed to enable public interface of load balancer: %w", err))
+		} else {
+			record.Eventf(s.scope.HetznerCluster, "EnableLoadBalancerPublicInterface", "Enabled public interface of load balancer")
+		}
+	}
+
 	// check if algorithm has been updated
 	if string(lbSpec.Algorithm) != string(lb.Algorithm.Type) {
 		opts := hcloud.LoadBalancerChangeAlgorithmOpts{Type: hcloud.LoadBalancerAlgorithmType(lbSpec.Algorithm)}
@@ -199,6 +251,17 @@ func (s *Service) reconcileLBProperties(ctx context.Context, lb *hcloud.LoadBala
 		}
 	}
 
+	// check if labels have been updated
+	if labels, changed := utils.UpdateLabels(lb.Labels, hcloudLabels(s.scope.HetznerCluster)); changed {
+		opts := hcloud.LoadBalancerUpdateOpts{Labels: labels}
+		if _, err := s.scope.HCloudClient.UpdateLoadBalancer(ctx, lb, opts); err != nil {
+			hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "UpdateLoadBalancer")
+			multierr = errors.Join(multierr, fmt.Errorf("failed to update load balancer labels: %w", err))
+		} else {
+			record.Eventf(s.scope.HetznerCluster, "ChangeLoadBalancerLabels", "Changed load balancer labels")
+		}
+	}
+
 	// check if name has been updated
 	if lbSpec.Name != nil && *lbSpec.Name != lb.Name {
 		opts := hcloud.LoadBalancerUpdateOpts{Name: *lbSpec.Name}
@@ -214,75 +277,102 @@ func (s *Service) reconcileLBProperties(ctx context.Context, lb *hcloud.LoadBala
 }
 
 func (s *Service) reconcileServices(ctx context.Context, lb *hcloud.LoadBalancer) error {
-	extraServicesSpec := s.scope.HetznerCluster.Spec.ControlPlaneLoadBalancer.ExtraServices
+	wantServices := slices.Clone(s.scope.HetznerCluster.Spec.ControlPlaneLoadBalancer.ExtraServices)
 
-	// build slices and maps to make diffs
-	haveServiceListenPorts := make([]int, 0, len(lb.Services))
-	wantServiceListenPorts := make([]int, 0, len(extraServicesSpec)+1)
-	wantServiceListenPortsMap := make(map[int]infrav1.LoadBalancerServiceSpec, len(extraServicesSpec)+1)
+	// add kubeAPI service if exists
+	if s.scope.HetznerCluster.Spec.ControlPlaneEndpoint != nil && s.scope.HetznerCluster.Spec.ControlPlaneEndpoint.Port != 0 {
+		wantServices = append(wantServices, apiServerService(s.scope.HetznerCluster))
+	}
 
-	// filter kubeAPI service out
+	certificates, err := s.reconcileCertificates(ctx, wantServices)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile certificates: %w", err)
+	}
+
+	// build maps to make diffs
+	haveServices := make(map[int]hcloud.LoadBalancerService, len(lb.Services))
 	for _, service := range lb.Services {
-		haveServiceListenPorts = append(haveServiceListenPorts, service.ListenPort)
+		haveServices[service.ListenPort] = service
 	}
 
-	for _, serviceInSpec := range extraServicesSpec {
-		wantServiceListenPorts = append(wantServiceListenPorts, serviceInSpec.ListenPort)
-		wantServiceListenPortsMap[serviceInSpec.ListenPort] = serviceInSpec
+	wantListenPorts := make(map[int]struct{}, len(wantServices))
+	for _, service := range wantServices {
+		wantListenPorts[service.ListenPort] = struct{}{}
 	}
 
-	// add kubeAPI service if exists
-	if s.scope.HetznerCluster.Spec.ControlPlaneEndpoint != nil && s.scope.HetznerCluster.Spec.ControlPlaneEndpoint.Port != 0 {
-		kubeAPIServicePort := int(s.scope.HetznerCluster.Spec.ControlPlaneEndpoint.Port)
-		wantServiceListenPorts = append(wantServiceListenPorts, kubeAPIServicePort)
-		wantServiceListenPortsMap[kubeAPIServicePort] = infrav1.LoadBalancerServiceSpec{
-			Protocol:        "tcp",
-			ListenPort:      kubeAPIServicePort,
-			DestinationPort: s.scope.HetznerCluster.Spec.ControlPlaneLoadBalancer.Port,
+	// delete services which are registered for lb but are not in specs. Services of a shared load balancer
+	// that are not part of the specs belong to someone else.
+	var multierr error
+
+	for _, service := range lb.Services {
+		if _, ok := wantListenPorts[service.ListenPort]; ok || s.scope.HetznerCluster.Spec.ControlPlaneLoadBalancer.Shared {
+			continue
+		}
+		if err := s.scope.HCloudClient.DeleteServiceFromLoadBalancer(ctx, lb, service.ListenPort); err != nil {
+			// return immediately on rate limit
+			hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "DeleteServiceFromLoadBalancer")
+			multierr = errors.Join(multierr, fmt.Errorf("failed to delete service from load balancer: %w", err))
+			if hcloud.IsError(err, hcloud.ErrorCodeRateLimitExceeded) {
+				return multierr
+			}
 		}
 	}
 
-	toCreate, toDelete := utils.DifferenceOfIntSlices(wantServiceListenPorts, haveServiceListenPorts)
-
-	// delete services which are registered for lb but are not in specs
-	var multierr error
+	// create services which are in specs and not yet in API and update services which differ from the specs
+	for _, serviceInSpec := range wantServices {
+		serviceOpts := addServiceOpts(serviceInSpec, certificates[serviceInSpec.ListenPort])
 
-	for _, listenPort := range toDelete {
-		if _, ok := wantServiceListenPortsMap[listenPort]; !ok {
-			if err := s.scope.HCloudClient.DeleteServiceFromLoadBalancer(ctx, lb, listenPort); err != nil {
+		service, found := haveServices[serviceInSpec.ListenPort]
+		if !found {
+			if err := s.scope.HCloudClient.AddServiceToLoadBalancer(ctx, lb, serviceOpts); err != nil {
 				// return immediately on rate limit
-				hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "DeleteServiceFromLoadBalancer")
-				multierr = errors.Join(multierr, fmt.Errorf("failed to delete service from load balancer: %w", err))
+				hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "AddServiceToLoadBalancer")
+				multierr = errors.Join(multierr, fmt.Errorf("failed to add service to load balancer: %w", err))
 				if hcloud.IsError(err, hcloud.ErrorCodeRateLimitExceeded) {
 					return multierr
 				}
 			}
+			continue
 		}
-	}
 
-	// create services which are in specs and not yet in API
-	for i, listenPort := range toCreate {
-		proxyProtocol := false
-		destinationPort := wantServiceListenPortsMap[listenPort].DestinationPort
-		serviceOpts := hcloud.LoadBalancerAddServiceOpts{
-			Protocol:        hcloud.LoadBalancerServiceProtocol(wantServiceListenPortsMap[listenPort].Protocol),
-			ListenPort:      &toCreate[i],
-			DestinationPort: &destinationPort,
-			Proxyprotocol:   &proxyProtocol,
+		if serviceUpToDate(service, serviceOpts) {
+			continue
 		}
-		if err := s.scope.HCloudClient.AddServiceToLoadBalancer(ctx, lb, serviceOpts); err != nil {
+
+		if err := s.scope.HCloudClient.UpdateServiceOfLoadBalancer(ctx, lb, serviceInSpec.ListenPort, updateServiceOpts(serviceOpts)); err != nil {
 			// return immediately on rate limit
-			hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "AddServiceToLoadBalancer")
-			multierr = errors.Join(multierr, fmt.Errorf("failed to add service to load balancer: %w", err))
+			hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "UpdateServiceOfLoadBalancer")
+			multierr = errors.Join(multierr, fmt.Errorf("failed to update service of load balancer: %w", err))
 			if hcloud.IsError(err, hcloud.ErrorCodeRateLimitExceeded) {
 				return multierr
 			}
+			continue
 		}
+		record.Eventf(s.scope.HetznerCluster, "UpdateLoadBalancerService", "Updated service with listen port %d", serviceInSpec.ListenPort)
 	}
-	return multierr
+
+	if multierr != nil {
+		return multierr
+	}
+
+	// certificates can only be deleted once the services do not use them anymore
+	return s.deleteUnusedCertificates(ctx, certificates)
 }
 
 func (s *Service) createLoadBalancer(ctx context.Context) (*hcloud.LoadBalancer, error) {
+	// a private load balancer can only be reached via the network, so it has to be attached right away
+	if s.scope.HetznerCluster.Spec.ControlPlaneLoadBalancer.Private && s.scope.HetznerCluster.Status.Network == nil {
+		err := fmt.Errorf("private load balancer requires a network, but no network found in object status")
+		conditions.MarkFalse(
+			s.scope.HetznerCluster,
+			infrav1.LoadBalancerReadyCondition,
+			infrav1.LoadBalancerCreateFailedReason,
+			clusterv1.ConditionSeverityError,
+			err.Error(),
+		)
+		return nil, err
+	}
+
 	opts := createOptsFromSpec(s.scope.HetznerCluster)
 	lb, err := s.scope.HCloudClient.CreateLoadBalancer(ctx, opts)
 	if err != nil {
@@ -311,34 +401,35 @@ func createOptsFromSpec(hc *infrav1.HetznerCluster) hcloud.LoadBalancerCreateOpt
 	// Set name
 	name := utils.GenerateName(nil, fmt.Sprintf("%s-kube-apiserver-", hc.Name))
 
-	proxyprotocol := false
-
 	var network *hcloud.Network
 	if hc.Status.Network != nil {
 		network = &hcloud.Network{ID: hc.Status.Network.ID}
 	}
 
-	listenPort := int(hc.Spec.ControlPlaneEndpoint.Port)
-	publicInterface := true
+	publicInterface := !hc.Spec.ControlPlaneLoadBalancer.Private
 	return hcloud.LoadBalancerCreateOpts{
 		LoadBalancerType: &hcloud.LoadBalancerType{Name: hc.Spec.ControlPlaneLoadBalancer.Type},
 		Name:             name,
 		Algorithm:        &hcloud.LoadBalancerAlgorithm{Type: algorithmType},
 		Location:         &hcloud.Location{Name: string(hc.Spec.ControlPlaneLoadBalancer.Region)},
 		Network:          network,
-		Labels:           map[string]string{hc.ClusterTagKey(): string(infrav1.ResourceLifecycleOwned)},
+		Labels:           hcloudLabels(hc),
 		PublicInterface:  &publicInterface,
 		Services: []hcloud.LoadBalancerCreateOptsService{
-			{
-				Protocol:        hcloud.LoadBalancerServiceProtocolTCP,
-				ListenPort:      &listenPort,
-				DestinationPort: &hc.Spec.ControlPlaneLoadBalancer.Port,
-				Proxyprotocol:   &proxyprotocol,
-			},
+			createServiceOpts(addServiceOpts(apiServerService(hc), nil)),
 		},
 	}
 }
 
+// hcloudLabels returns the labels of the load balancer.
+func hcloudLabels(hc *infrav1.HetznerCluster) map[string]string {
+	return utils.MergeLabels(
+		hc.Spec.HCloudLabels,
+		hc.Spec.ControlPlaneLoadBalancer.HCloudLabels,
+		map[string]string{hc.ClusterTagKey(): string(infrav1.ResourceLifecycleOwned)},
+	)
+}
+
 // Delete implements the deletion of HCloud load balancers.
 func (s *Service) Delete(ctx context.Context) (err error) {
 	if s.scope.HetznerCluster.Status.ControlPlaneLoadBalancer == nil {
@@ -346,26 +437,30 @@ func (s *Service) Delete(ctx context.Context) (err error) {
 		return nil
 	}
 
-	// do not delete a protected load balancer or one that has not been created by this controller
-	if s.scope.HetznerCluster.Status.ControlPlaneLoadBalancer.Protected || s.scope.HetznerCluster.Spec.ControlPlaneLoadBalancer.Name != nil {
-		name := *s.scope.HetznerCluster.Spec.ControlPlaneLoadBalancer.Name
+	lbSpec := s.scope.HetznerCluster.Spec.ControlPlaneLoadBalancer
 
-		loadBalancers, err := s.scope.HCloudClient.ListLoadBalancers(ctx, hcloud.LoadBalancerListOpts{Name: name})
-		if err != nil {
-			hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "ListLoadBalancers")
-			return fmt.Errorf("failed to list load balancers: %w", err)
-		}
+	// only the API server service is removed from a shared load balancer
+	if lbSpec.Shared {
+		return s.deleteAPIServerService(ctx)
+	}
 
-		if len(loadBalancers) > 1 {
-			return fmt.Errorf("found %v load balancers in HCloud with name %q", len(loadBalancers), name)
+	// do not delete a protected load balancer or one that has not been created by this controller
+	if s.scope.HetznerCluster.Status.ControlPlaneLoadBalancer.Protected || hasExistingLoadBalancerRef(lbSpec) {
+		lb, err := s.getLoadBalancer(ctx)
+		if err != nil {
+			return err
 		}
 
 		// nothing to do if load balancer is not found
-		if len(loadBalancers) == 0 {
+		if lb == nil {
+			s.scope.HetznerCluster.Status.ControlPlaneLoadBalancer = nil
 			return nil
 		}
 
-		lb := loadBalancers[0]
+		// the services of the cluster are removed, so that their certificates can be deleted
+		if err := s.deleteServices(ctx, lb, managedListenPorts(s.scope.HetznerCluster)); err != nil {
+			return err
+		}
 
 		// remove owned label and update
 		delete(lb.Labels, s.scope.HetznerCluster.ClusterTagKey())
@@ -384,37 +479,105 @@ func (s *Service) Delete(ctx context.Context) (err error) {
 			return err
 		}
 
+		record.Eventf(s.scope.HetznerCluster, "LoadBalancerOwnedLabelRemoved", "removed owned label of load balancer")
+
+		if err := s.deleteUnusedCertificates(ctx, nil); err != nil {
+			return fmt.Errorf("failed to delete certificates: %w", err)
+		}
+
 		// Delete lb information from cluster status
 		s.scope.HetznerCluster.Status.ControlPlaneLoadBalancer = nil
-
-		record.Eventf(s.scope.HetznerCluster, "LoadBalancerOwnedLabelRemoved", "removed owned label of load balancer")
 		return nil
 	}
 
 	if err := s.scope.HCloudClient.DeleteLoadBalancer(ctx, s.scope.HetznerCluster.Status.ControlPlaneLoadBalancer.ID); err != nil {
 		hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "DeleteLoadBalancer")
-		if hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
-			return nil
+		if !hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
+			err = fmt.Errorf("failed to delete load balancer: %w", err)
+			record.Warnf(s.scope.HetznerCluster, "FailedLoadBalancerDelete", err.Error())
+			conditions.MarkFalse(
+				s.scope.HetznerCluster,
+				infrav1.LoadBalancerReadyCondition,
+				infrav1.LoadBalancerDeleteFailedReason,
+				clusterv1.ConditionSeverityWarning,
+				err.Error(),
+			)
+			return err
 		}
-		err = fmt.Errorf("failed to delete load balancer: %w", err)
-		record.Warnf(s.scope.HetznerCluster, "FailedLoadBalancerDelete", err.Error())
-		conditions.MarkFalse(
-			s.scope.HetznerCluster,
-			infrav1.LoadBalancerReadyCondition,
-			infrav1.LoadBalancerDeleteFailedReason,
-			clusterv1.ConditionSeverityWarning,
-			err.Error(),
-		)
+	} else {
+		record.Eventf(s.scope.HetznerCluster, "DeleteLoadBalancer", "Deleted load balancer")
+	}
+
+	// the certificates of the services are not used anymore once the load balancer is deleted
+	if err := s.deleteUnusedCertificates(ctx, nil); err != nil {
+		return fmt.Errorf("failed to delete certificates: %w", err)
+	}
+
+	// Delete lb information from cluster status
+	s.scope.HetznerCluster.Status.ControlPlaneLoadBalancer = nil
+
+	return nil
+}
+
+// deleteAPIServerService removes the API server service from a shared load balancer.
+func (s *Service) deleteAPIServerService(ctx context.Context) error {
+	lb, err := s.getLoadBalancer(ctx)
+	if err != nil {
 		return err
 	}
 
+	if lb != nil && s.scope.HetznerCluster.Spec.ControlPlaneEndpoint != nil {
+		listenPort := int(s.scope.HetznerCluster.Spec.ControlPlaneEndpoint.Port)
+		if err := s.deleteServices(ctx, lb, map[int]struct{}{listenPort: {}}); err != nil {
+			return err
+		}
+	}
+
 	// Delete lb information from cluster status
 	s.scope.HetznerCluster.Status.ControlPlaneLoadBalancer = nil
+	return nil
+}
+
+// managedListenPorts returns the listen ports of the services that are managed for the cluster.
+func managedListenPorts(hc *infrav1.HetznerCluster) map[int]struct{} {
+	listenPorts := make(map[int]struct{}, len(hc.Spec.ControlPlaneLoadBalancer.ExtraServices)+1)
+	for _, service := range hc.Spec.ControlPlaneLoadBalancer.ExtraServices {
+		listenPorts[service.ListenPort] = struct{}{}
+	}
+	if hc.Spec.ControlPlaneEndpoint != nil && hc.Spec.ControlPlaneEndpoint.Port != 0 {
+		listenPorts[int(hc.Spec.ControlPlaneEndpoint.Port)] = struct{}{}
+	}
+	return listenPorts
+}
 
-	record.Eventf(s.scope.HetznerCluster, "DeleteLoadBalancer", "Deleted load balancer")
+// deleteServices removes the services with the given listen ports from the load balancer.
+func (s *Service) deleteServices(ctx context.Context, lb *hcloud.LoadBalancer, listenPorts map[int]struct{}) error {
+	for _, service := range lb.Services {
+		if _, found := listenPorts[service.ListenPort]; !found {
+			continue
+		}
+		if err := s.scope.HCloudClient.DeleteServiceFromLoadBalancer(ctx, lb, service.ListenPort); err != nil {
+			hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "DeleteServiceFromLoadBalancer")
+			err = fmt.Errorf("failed to delete service from load balancer: %w", err)
+			record.Warnf(s.scope.HetznerCluster, "FailedDeleteLoadBalancerService", err.Error())
+			return err
+		}
+		record.Eventf(s.scope.HetznerCluster, "DeleteLoadBalancerService", "Deleted service with listen port %d from load balancer", service.ListenPort)
+	}
 	return nil
 }
 
+// getLoadBalancer returns the load balancer of the status. It returns nil if the load balancer does not exist.
+func (s *Service) getLoadBalancer(ctx context.Context) (*hcloud.LoadBalancer, error) {
+	id := s.scope.HetznerCluster.Status.ControlPlaneLoadBalancer.ID
+	lb, err := s.scope.HCloudClient.GetLoadBalancer(ctx, id)
+	if err != nil {
+		hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "GetLoadBalancer")
+		return nil, fmt.Errorf("failed to get load balancer %v: %w", id, err)
+	}
+	return lb, nil
+}
+
 func (s *Service) findLoadBalancer(ctx context.Context) (*hcloud.LoadBalancer, error) {
 	clusterTagKey := s.scope.HetznerCluster.ClusterTagKey()
 	opts := hcloud.LoadBalancerListOpts{
@@ -439,16 +602,48 @@ func (s *Service) findLoadBalancer(ctx context.Context) (*hcloud.LoadBalancer, e
 	return loadBalancers[0], nil
 }
 
-func (s *Service) ownExistingLoadBalancer(ctx context.Context) (*hcloud.LoadBalancer, error) {
-	name := *s.scope.HetznerCluster.Spec.ControlPlaneLoadBalancer.Name
-	loadBalancers, err := s.scope.HCloudClient.ListLoadBalancers(ctx, hcloud.LoadBalancerListOpts{Name: name})
-	if err != nil {
-		hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "ListLoadBalancers")
-		return nil, fmt.Errorf("failed to list load balancers: %w", err)
+// hasExistingLoadBalancerRef reports whether the spec references an existing load balancer.
+func hasExistingLoadBalancerRef(lbSpec infrav1.LoadBalancerSpec) bool {
+	return lbSpec.Name != nil || lbSpec.ID != nil || len(lbSpec.Selector) > 0
+}
+
+// findExistingLoadBalancer returns the existing load balancer that is referenced by name, ID or selector. It
+// returns ErrNoLoadBalancerAvailable if the load balancer does not exist.
+func (s *Service) findExistingLoadBalancer(ctx context.Context) (*hcloud.LoadBalancer, error) {
+	lbSpec := s.scope.HetznerCluster.Spec.ControlPlaneLoadBalancer
+
+	var loadBalancers []*hcloud.LoadBalancer
+	var ref string
+	if lbSpec.ID != nil {
+		ref = fmt.Sprintf("with ID %v", *lbSpec.ID)
+		lb, err := s.scope.HCloudClient.GetLoadBalancer(ctx, *lbSpec.ID)
+		if err != nil {
+			hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "GetLoadBalancer")
+			return nil, fmt.Errorf("failed to get load balancer %v: %w", *lbSpec.ID, err)
+		}
+		if lb != nil {
+			loadBalancers = append(loadBalancers, lb)
+		}
+	} else {
+		opts := hcloud.LoadBalancerListOpts{}
+		if len(lbSpec.Selector) > 0 {
+			opts.LabelSelector = utils.LabelsToLabelSelector(lbSpec.Selector)
+			ref = fmt.Sprintf("with selector %q", opts.LabelSelector)
+		} else {
+			opts.Name = *lbSpec.Name
+			ref = fmt.Sprintf("%q", opts.Name)
+		}
+
+		var err error
+		loadBalancers, err = s.scope.HCloudClient.ListLoadBalancers(ctx, opts)
+		if err != nil {
+			hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "ListLoadBalancers")
+			return nil, fmt.Errorf("failed to list load balancers: %w", err)
+		}
 	}
 
 	if len(loadBalancers) > 1 {
-		return nil, fmt.Errorf("found %v load balancers in HCloud with name %q", len(loadBalancers), name)
+		return nil, fmt.Errorf("found %v load balancers in HCloud %s", len(loadBalancers), ref)
 	}
 
 	if len(loadBalancers) == 0 {
@@ -457,12 +652,19 @@ func (s *Service) ownExistingLoadBalancer(ctx context.Context) (*hcloud.LoadBala
 			infrav1.LoadBalancerReadyCondition,
 			infrav1.LoadBalancerFailedToOwnReason,
 			clusterv1.ConditionSeverityError,
-			fmt.Sprintf("load balancer %q not found", name),
+			fmt.Sprintf("load balancer %s not found", ref),
 		)
 		return nil, ErrNoLoadBalancerAvailable
 	}
 
-	lb := loadBalancers[0]
+	return loadBalancers[0], nil
+}
+
+func (s *Service) ownExistingLoadBalancer(ctx context.Context) (*hcloud.LoadBalancer, error) {
+	lb, err := s.findExistingLoadBalancer(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	for label := range lb.Labels {
 		if strings.HasPrefix(label, infrav1.NameHetznerProviderOwned) {
@@ -471,18 +673,13 @@ func (s *Service) ownExistingLoadBalancer(ctx context.Context) (*hcloud.LoadBala
 				infrav1.LoadBalancerReadyCondition,
 				infrav1.LoadBalancerFailedToOwnReason,
 				clusterv1.ConditionSeverityError,
-				fmt.Sprintf("load balancer %q already owned with label %q", name, label),
+				fmt.Sprintf("load balancer %q already owned with label %q", lb.Name, label),
 			)
 			return nil, ErrNoLoadBalancerAvailable
 		}
 	}
 
-	newLabels := make(map[string]string)
-	for key, val := range lb.Labels {
-		newLabels[key] = val
-	}
-
-	newLabels[s.scope.HetznerCluster.ClusterTagKey()] = string(infrav1.ResourceLifecycleOwned)
+	newLabels := utils.MergeLabels(lb.Labels, hcloudLabels(s.scope.HetznerCluster))
 
 	lb, err = s.scope.HCloudClient.UpdateLoadBalancer(ctx, lb, hcloud.LoadBalancerUpdateOpts{Labels: newLabels})
 	if err != nil {
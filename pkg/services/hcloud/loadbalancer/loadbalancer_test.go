@@ -17,13 +17,23 @@ limitations under the License.
 package loadbalancer
 
 import (
+	"context"
+	"net"
+	"time"
+
 	"github.com/go-logr/logr"
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"k8s.io/klog/v2/klogr"
+	"k8s.io/utils/ptr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 
 	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
+	"github.com/syself/cluster-api-provider-hetzner/pkg/scope"
+	hcloudclient "github.com/syself/cluster-api-provider-hetzner/pkg/services/hcloud/client"
+	fakeclient "github.com/syself/cluster-api-provider-hetzner/pkg/services/hcloud/client/fake"
 )
 
 var _ = Describe("Loadbalancer", func() {
@@ -139,6 +149,50 @@ var _ = Describe("createOptsFromSpec", func() {
 		Expect(createOpts).To(Equal(wantCreateOpts))
 	})
 
+	It("creates specs with the labels of the spec", func() {
+		hetznerCluster.Spec.HCloudLabels = map[string]string{"team": "infra", "cost-center": "cluster"}
+		hetznerCluster.Spec.ControlPlaneLoadBalancer.HCloudLabels = map[string]string{"cost-center": "lb"}
+		wantCreateOpts.Labels = map[string]string{
+			hetznerCluster.ClusterTagKey(): string(infrav1.ResourceLifecycleOwned),
+			"team":                         "infra",
+			"cost-center":                  "lb",
+		}
+
+		createOpts := createOptsFromSpec(hetznerCluster)
+
+		// ignore random name
+		createOpts.Name = ""
+
+		Expect(createOpts).To(Equal(wantCreateOpts))
+	})
+
+	It("creates specs with the health check of the API server", func() {
+		hetznerCluster.Spec.ControlPlaneLoadBalancer.APIServerHealthCheck = &infrav1.LoadBalancerHealthCheckSpec{
+			Protocol:        infrav1.LoadBalancerHealthCheckProtocolTCP,
+			IntervalSeconds: 5,
+		}
+
+		createOpts := createOptsFromSpec(hetznerCluster)
+
+		Expect(createOpts.Services).To(HaveLen(1))
+		Expect(createOpts.Services[0].HealthCheck).ToNot(BeNil())
+		Expect(*createOpts.Services[0].HealthCheck.Port).To(Equal(6443))
+		Expect(*createOpts.Services[0].HealthCheck.Interval).To(Equal(5 * time.Second))
+	})
+
+	It("creates specs for private load balancer", func() {
+		hetznerCluster.Spec.ControlPlaneLoadBalancer.Private = true
+		publicInterface := false
+		wantCreateOpts.PublicInterface = &publicInterface
+
+		createOpts := createOptsFromSpec(hetznerCluster)
+
+		// ignore random name
+		createOpts.Name = ""
+
+		Expect(createOpts).To(Equal(wantCreateOpts))
+	})
+
 	It("creates specs for cluster without load balancer name set", func() {
 		hetznerCluster.Spec.ControlPlaneLoadBalancer.Name = nil
 
@@ -153,3 +207,227 @@ var _ = Describe("createOptsFromSpec", func() {
 		Expect(createOpts).To(Equal(wantCreateOpts))
 	})
 })
+
+var _ = Describe("private load balancer", func() {
+	var (
+		ctx     context.Context
+		cluster *infrav1.HetznerCluster
+		service *Service
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		hcloudClient := fakeclient.NewHCloudClientFactory().NewClient("")
+		hcloudClient.Close()
+
+		cluster = &infrav1.HetznerCluster{}
+		cluster.Name = "hetzner-cluster"
+		cluster.Spec.ControlPlaneEndpoint = &clusterv1.APIEndpoint{Port: 443}
+		cluster.Spec.ControlPlaneLoadBalancer = infrav1.LoadBalancerSpec{
+			Enabled:   true,
+			Algorithm: infrav1.LoadBalancerAlgorithmTypeRoundRobin,
+			Type:      "lb11",
+			Region:    "fsn1",
+			Port:      6443,
+			Private:   true,
+		}
+
+		service = NewService(&scope.ClusterScope{Logger: klogr.New(), HCloudClient: hcloudClient, HetznerCluster: cluster})
+	})
+
+	It("is not created without a network", func() {
+		_, err := service.createLoadBalancer(ctx)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("is created without public interface", func() {
+		cluster.Status.Network = &infrav1.NetworkStatus{ID: 42}
+
+		lb, err := service.createLoadBalancer(ctx)
+		Expect(err).To(Succeed())
+		Expect(lb.PublicNet.Enabled).To(BeFalse())
+	})
+
+	It("disables and enables the public interface of an existing load balancer", func() {
+		cluster.Spec.ControlPlaneLoadBalancer.Private = false
+		lb, err := service.createLoadBalancer(ctx)
+		Expect(err).To(Succeed())
+		Expect(lb.PublicNet.Enabled).To(BeTrue())
+
+		cluster.Spec.ControlPlaneLoadBalancer.Private = true
+		Expect(service.reconcileLBProperties(ctx, lb)).To(Succeed())
+		Expect(lb.PublicNet.Enabled).To(BeFalse())
+
+		cluster.Spec.ControlPlaneLoadBalancer.Private = false
+		Expect(service.reconcileLBProperties(ctx, lb)).To(Succeed())
+		Expect(lb.PublicNet.Enabled).To(BeTrue())
+	})
+})
+
+var _ = Describe("existing load balancer", func() {
+	var (
+		ctx          context.Context
+		hcloudClient hcloudclient.Client
+		cluster      *infrav1.HetznerCluster
+		service      *Service
+		existing     *hcloud.LoadBalancer
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		hcloudClient = fakeclient.NewHCloudClientFactory().NewClient("")
+		hcloudClient.Close()
+
+		var err error
+		existing, err = hcloudClient.CreateLoadBalancer(ctx, hcloud.LoadBalancerCreateOpts{
+			Name:             "ingress",
+			LoadBalancerType: &hcloud.LoadBalancerType{Name: "lb21"},
+			Algorithm:        &hcloud.LoadBalancerAlgorithm{Type: hcloud.LoadBalancerAlgorithmTypeLeastConnections},
+			Labels:           map[string]string{"team": "infra"},
+		})
+		Expect(err).To(Succeed())
+		Expect(hcloudClient.AddServiceToLoadBalancer(ctx, existing, hcloud.LoadBalancerAddServiceOpts{
+			Protocol:        hcloud.LoadBalancerServiceProtocolTCP,
+			ListenPort:      ptr.To(80),
+			DestinationPort: ptr.To(30080),
+		})).To(Succeed())
+
+		cluster = &infrav1.HetznerCluster{}
+		cluster.Name = "hetzner-cluster"
+		cluster.Spec.ControlPlaneEndpoint = &clusterv1.APIEndpoint{Port: 443}
+		cluster.Spec.ControlPlaneLoadBalancer = infrav1.LoadBalancerSpec{
+			Enabled:   true,
+			Algorithm: infrav1.LoadBalancerAlgorithmTypeRoundRobin,
+			Type:      "lb11",
+			Port:      6443,
+		}
+
+		service = NewService(&scope.ClusterScope{Logger: klogr.New(), HCloudClient: hcloudClient, HetznerCluster: cluster})
+	})
+
+	listenPorts := func() []int {
+		lb, err := hcloudClient.GetLoadBalancer(ctx, existing.ID)
+		Expect(err).To(Succeed())
+		ports := make([]int, 0, len(lb.Services))
+		for _, s := range lb.Services {
+			ports = append(ports, s.ListenPort)
+		}
+		return ports
+	}
+
+	It("is adopted by ID", func() {
+		cluster.Spec.ControlPlaneLoadBalancer.ID = ptr.To(existing.ID)
+
+		_, err := service.Reconcile(ctx)
+		Expect(err).To(Succeed())
+
+		lb, err := hcloudClient.GetLoadBalancer(ctx, existing.ID)
+		Expect(err).To(Succeed())
+		Expect(lb.Labels).To(HaveKeyWithValue(cluster.ClusterTagKey(), string(infrav1.ResourceLifecycleOwned)))
+		Expect(lb.LoadBalancerType.Name).To(Equal("lb11"))
+		Expect(listenPorts()).To(ConsistOf(443))
+
+		Expect(service.Delete(ctx)).To(Succeed())
+
+		lb, err = hcloudClient.GetLoadBalancer(ctx, existing.ID)
+		Expect(err).To(Succeed())
+		Expect(lb).ToNot(BeNil())
+		Expect(lb.Labels).ToNot(HaveKey(cluster.ClusterTagKey()))
+	})
+
+	It("is released without the services and certificates of the cluster", func() {
+		cluster.Spec.ControlPlaneLoadBalancer.ID = ptr.To(existing.ID)
+		cluster.Spec.ControlPlaneLoadBalancer.ExtraServices = []infrav1.LoadBalancerServiceSpec{
+			{
+				Protocol:        "https",
+				ListenPort:      8443,
+				DestinationPort: 8080,
+				HTTP: &infrav1.LoadBalancerServiceHTTPSpec{
+					Certificates: []infrav1.LoadBalancerCertificateSpec{{DomainNames: []string{"example.com"}}},
+				},
+			},
+		}
+
+		_, err := service.Reconcile(ctx)
+		Expect(err).To(Succeed())
+		Expect(listenPorts()).To(ConsistOf(443, 8443))
+
+		certificates, err := hcloudClient.ListCertificates(ctx, hcloud.CertificateListOpts{})
+		Expect(err).To(Succeed())
+		Expect(certificates).To(HaveLen(1))
+
+		Expect(service.Delete(ctx)).To(Succeed())
+
+		Expect(listenPorts()).To(BeEmpty())
+		certificates, err = hcloudClient.ListCertificates(ctx, hcloud.CertificateListOpts{})
+		Expect(err).To(Succeed())
+		Expect(certificates).To(BeEmpty())
+		Expect(cluster.Status.ControlPlaneLoadBalancer).To(BeNil())
+	})
+
+	It("is shared by selector", func() {
+		cluster.Spec.ControlPlaneLoadBalancer.Selector = map[string]string{"team": "infra"}
+		cluster.Spec.ControlPlaneLoadBalancer.Shared = true
+
+		_, err := service.Reconcile(ctx)
+		Expect(err).To(Succeed())
+		Expect(cluster.Status.ControlPlaneLoadBalancer.ID).To(Equal(existing.ID))
+
+		lb, err := hcloudClient.GetLoadBalancer(ctx, existing.ID)
+		Expect(err).To(Succeed())
+		Expect(lb.Labels).To(Equal(map[string]string{"team": "infra"}))
+		Expect(lb.LoadBalancerType.Name).To(Equal("lb21"))
+		Expect(lb.Algorithm.Type).To(Equal(hcloud.LoadBalancerAlgorithmTypeLeastConnections))
+		Expect(listenPorts()).To(ConsistOf(80, 443))
+
+		Expect(service.Delete(ctx)).To(Succeed())
+
+		lb, err = hcloudClient.GetLoadBalancer(ctx, existing.ID)
+		Expect(err).To(Succeed())
+		Expect(lb).ToNot(BeNil())
+		Expect(listenPorts()).To(ConsistOf(80))
+		Expect(cluster.Status.ControlPlaneLoadBalancer).To(BeNil())
+	})
+
+	It("is not attached to the network of the cluster if shared", func() {
+		network, err := hcloudClient.CreateNetwork(ctx, hcloud.NetworkCreateOpts{
+			Name:    "network",
+			IPRange: &net.IPNet{IP: net.IPv4(10, 0, 0, 0), Mask: net.CIDRMask(16, 32)},
+		})
+		Expect(err).To(Succeed())
+		cluster.Spec.HCloudNetwork.Enabled = true
+		cluster.Status.Network = &infrav1.NetworkStatus{ID: network.ID}
+		cluster.Spec.ControlPlaneLoadBalancer.ID = ptr.To(existing.ID)
+		cluster.Spec.ControlPlaneLoadBalancer.Shared = true
+
+		res, err := service.Reconcile(ctx)
+		Expect(err).To(Succeed())
+		Expect(res.RequeueAfter).ToNot(BeZero())
+		Expect(conditions.GetReason(cluster, infrav1.LoadBalancerReadyCondition)).To(Equal(infrav1.LoadBalancerNotAttachedToNetworkReason))
+
+		lb, err := hcloudClient.GetLoadBalancer(ctx, existing.ID)
+		Expect(err).To(Succeed())
+		Expect(lb.PrivateNet).To(BeEmpty())
+		Expect(listenPorts()).To(ConsistOf(80))
+
+		// the owner of the load balancer attaches it
+		Expect(hcloudClient.AttachLoadBalancerToNetwork(ctx, lb, hcloud.LoadBalancerAttachToNetworkOpts{Network: network})).To(Succeed())
+
+		res, err = service.Reconcile(ctx)
+		Expect(err).To(Succeed())
+		Expect(res.RequeueAfter).To(BeZero())
+		Expect(conditions.IsTrue(cluster, infrav1.LoadBalancerReadyCondition)).To(BeTrue())
+		Expect(listenPorts()).To(ConsistOf(80, 443))
+	})
+
+	It("is waited for if it does not exist", func() {
+		cluster.Spec.ControlPlaneLoadBalancer.ID = ptr.To(existing.ID + 1)
+
+		res, err := service.Reconcile(ctx)
+		Expect(err).To(Succeed())
+		Expect(res.RequeueAfter).ToNot(BeZero())
+		Expect(conditions.GetReason(cluster, infrav1.LoadBalancerReadyCondition)).To(Equal(infrav1.LoadBalancerFailedToOwnReason))
+	})
+})
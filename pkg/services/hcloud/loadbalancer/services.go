@@ -0,0 +1,239 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancer
+
+import (
+	"slices"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"k8s.io/utils/ptr"
+
+	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
+)
+
+// Defaults of HCloud health checks. They are used if a health check is defined without these settings.
+const (
+	defaultHealthCheckInterval = 15 * time.Second
+	defaultHealthCheckTimeout  = 10 * time.Second
+	defaultHealthCheckRetries  = 3
+	defaultHealthCheckPath     = "/"
+)
+
+// apiServerService returns the spec of the service that forwards traffic to the API servers.
+func apiServerService(hc *infrav1.HetznerCluster) infrav1.LoadBalancerServiceSpec {
+	return infrav1.LoadBalancerServiceSpec{
+		Protocol:        string(hcloud.LoadBalancerServiceProtocolTCP),
+		ListenPort:      int(hc.Spec.ControlPlaneEndpoint.Port),
+		DestinationPort: hc.Spec.ControlPlaneLoadBalancer.Port,
+		HealthCheck:     hc.Spec.ControlPlaneLoadBalancer.APIServerHealthCheck,
+	}
+}
+
+// addServiceOpts returns the options to add a service. Settings that are not part of the spec are nil, so that
+// HCloud uses its defaults and serviceUpToDate ignores them.
+func addServiceOpts(spec infrav1.LoadBalancerServiceSpec, certificates []*hcloud.Certificate) hcloud.LoadBalancerAddServiceOpts {
+	opts := hcloud.LoadBalancerAddServiceOpts{
+		Protocol:        hcloud.LoadBalancerServiceProtocol(spec.Protocol),
+		ListenPort:      ptr.To(spec.ListenPort),
+		DestinationPort: ptr.To(spec.DestinationPort),
+		Proxyprotocol:   ptr.To(spec.ProxyProtocol),
+		HealthCheck:     healthCheckOpts(spec.HealthCheck, spec.DestinationPort),
+	}
+
+	if spec.HTTP != nil {
+		opts.HTTP = &hcloud.LoadBalancerAddServiceOptsHTTP{
+			RedirectHTTP:   ptr.To(spec.HTTP.RedirectHTTP),
+			StickySessions: ptr.To(spec.HTTP.StickySessions),
+		}
+		if spec.HTTP.CookieName != "" {
+			opts.HTTP.CookieName = ptr.To(spec.HTTP.CookieName)
+		}
+		if spec.HTTP.CookieLifetimeSeconds != 0 {
+			opts.HTTP.CookieLifetime = ptr.To(time.Duration(spec.HTTP.CookieLifetimeSeconds) * time.Second)
+		}
+		if spec.Protocol == string(hcloud.LoadBalancerServiceProtocolHTTPS) {
+			opts.HTTP.Certificates = certificates
+		}
+	}
+
+	return opts
+}
+
+func healthCheckOpts(spec *infrav1.LoadBalancerHealthCheckSpec, destinationPort int) *hcloud.LoadBalancerAddServiceOptsHealthCheck {
+	if spec == nil {
+		return nil
+	}
+
+	opts := &hcloud.LoadBalancerAddServiceOptsHealthCheck{
+		Protocol: hcloud.LoadBalancerServiceProtocolTCP,
+		Port:     ptr.To(destinationPort),
+		Interval: ptr.To(defaultHealthCheckInterval),
+		Timeout:  ptr.To(defaultHealthCheckTimeout),
+		Retries:  ptr.To(defaultHealthCheckRetries),
+	}
+	if spec.Protocol != "" {
+		opts.Protocol = hcloud.LoadBalancerServiceProtocol(spec.Protocol)
+	}
+	if spec.Port != 0 {
+		opts.Port = ptr.To(spec.Port)
+	}
+	if spec.IntervalSeconds != 0 {
+		opts.Interval = ptr.To(time.Duration(spec.IntervalSeconds) * time.Second)
+	}
+	if spec.TimeoutSeconds != 0 {
+		opts.Timeout = ptr.To(time.Duration(spec.TimeoutSeconds) * time.Second)
+	}
+	if spec.Retries != nil {
+		opts.Retries = ptr.To(*spec.Retries)
+	}
+
+	if opts.Protocol != hcloud.LoadBalancerServiceProtocolHTTP {
+		return opts
+	}
+
+	httpSpec := spec.HTTP
+	if httpSpec == nil {
+		httpSpec = &infrav1.LoadBalancerHealthCheckHTTPSpec{}
+	}
+	opts.HTTP = &hcloud.LoadBalancerAddServiceOptsHealthCheckHTTP{
+		Domain:   ptr.To(httpSpec.Domain),
+		Path:     ptr.To(defaultHealthCheckPath),
+		Response: ptr.To(httpSpec.Response),
+		TLS:      ptr.To(httpSpec.TLS),
+	}
+	if httpSpec.Path != "" {
+		opts.HTTP.Path = ptr.To(httpSpec.Path)
+	}
+	if len(httpSpec.StatusCodes) > 0 {
+		opts.HTTP.StatusCodes = slices.Clone(httpSpec.StatusCodes)
+	}
+
+	return opts
+}
+
+// updateServiceOpts converts the options to add a service into the options to update an existing service.
+func updateServiceOpts(opts hcloud.LoadBalancerAddServiceOpts) hcloud.LoadBalancerUpdateServiceOpts {
+	updateOpts := hcloud.LoadBalancerUpdateServiceOpts{
+		Protocol:        opts.Protocol,
+		DestinationPort: opts.DestinationPort,
+		Proxyprotocol:   opts.Proxyprotocol,
+		HTTP:            (*hcloud.LoadBalancerUpdateServiceOptsHTTP)(opts.HTTP),
+	}
+	if hc := opts.HealthCheck; hc != nil {
+		updateOpts.HealthCheck = &hcloud.LoadBalancerUpdateServiceOptsHealthCheck{
+			Protocol: hc.Protocol,
+			Port:     hc.Port,
+			Interval: hc.Interval,
+			Timeout:  hc.Timeout,
+			Retries:  hc.Retries,
+			HTTP:     (*hcloud.LoadBalancerUpdateServiceOptsHealthCheckHTTP)(hc.HTTP),
+		}
+	}
+	return updateOpts
+}
+
+// createServiceOpts converts the options to add a service into the options of a service of a new load balancer.
+func createServiceOpts(opts hcloud.LoadBalancerAddServiceOpts) hcloud.LoadBalancerCreateOptsService {
+	createOpts := hcloud.LoadBalancerCreateOptsService{
+		Protocol:        opts.Protocol,
+		ListenPort:      opts.ListenPort,
+		DestinationPort: opts.DestinationPort,
+		Proxyprotocol:   opts.Proxyprotocol,
+		HTTP:            (*hcloud.LoadBalancerCreateOptsServiceHTTP)(opts.HTTP),
+	}
+	if hc := opts.HealthCheck; hc != nil {
+		createOpts.HealthCheck = &hcloud.LoadBalancerCreateOptsServiceHealthCheck{
+			Protocol: hc.Protocol,
+			Port:     hc.Port,
+			Interval: hc.Interval,
+			Timeout:  hc.Timeout,
+			Retries:  hc.Retries,
+			HTTP:     (*hcloud.LoadBalancerCreateOptsServiceHealthCheckHTTP)(hc.HTTP),
+		}
+	}
+	return createOpts
+}
+
+// serviceUpToDate reports whether an existing service has all settings of the desired service. Settings that are
+// nil in the desired service are not compared.
+func serviceUpToDate(have hcloud.LoadBalancerService, want hcloud.LoadBalancerAddServiceOpts) bool {
+	if have.Protocol != want.Protocol ||
+		!upToDate(have.DestinationPort, want.DestinationPort) ||
+		!upToDate(have.Proxyprotocol, want.Proxyprotocol) {
+		return false
+	}
+
+	if want.HTTP != nil {
+		if !upToDate(have.HTTP.CookieName, want.HTTP.CookieName) ||
+			!upToDate(have.HTTP.CookieLifetime, want.HTTP.CookieLifetime) ||
+			!upToDate(have.HTTP.RedirectHTTP, want.HTTP.RedirectHTTP) ||
+			!upToDate(have.HTTP.StickySessions, want.HTTP.StickySessions) {
+			return false
+		}
+		if want.HTTP.Certificates != nil && !slices.Equal(certificateIDs(have.HTTP.Certificates), certificateIDs(want.HTTP.Certificates)) {
+			return false
+		}
+	}
+
+	if hc := want.HealthCheck; hc != nil {
+		if have.HealthCheck.Protocol != hc.Protocol ||
+			!upToDate(have.HealthCheck.Port, hc.Port) ||
+			!upToDate(have.HealthCheck.Interval, hc.Interval) ||
+			!upToDate(have.HealthCheck.Timeout, hc.Timeout) ||
+			!upToDate(have.HealthCheck.Retries, hc.Retries) {
+			return false
+		}
+		if hc.HTTP != nil {
+			if have.HealthCheck.HTTP == nil {
+				return false
+			}
+			if !upToDate(have.HealthCheck.HTTP.Domain, hc.HTTP.Domain) ||
+				!upToDate(have.HealthCheck.HTTP.Path, hc.HTTP.Path) ||
+				!upToDate(have.HealthCheck.HTTP.Response, hc.HTTP.Response) ||
+				!upToDate(have.HealthCheck.HTTP.TLS, hc.HTTP.TLS) {
+				return false
+			}
+			if hc.HTTP.StatusCodes != nil && !slices.Equal(sorted(have.HealthCheck.HTTP.StatusCodes), sorted(hc.HTTP.StatusCodes)) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// upToDate reports whether a value equals the desired value. It is always true if no value is desired.
+func upToDate[T comparable](have T, want *T) bool {
+	return want == nil || have == *want
+}
+
+// certificateIDs returns the sorted IDs of certificates.
+func certificateIDs(certificates []*hcloud.Certificate) []int64 {
+	ids := make([]int64, 0, len(certificates))
+	for _, certificate := range certificates {
+		ids = append(ids, certificate.ID)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func sorted(s []string) []string {
+	s = slices.Clone(s)
+	slices.Sort(s)
+	return s
+}
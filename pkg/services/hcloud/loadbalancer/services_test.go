@@ -0,0 +1,321 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancer
+
+import (
+	"context"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/klog/v2/klogr"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
+	"github.com/syself/cluster-api-provider-hetzner/pkg/scope"
+	hcloudclient "github.com/syself/cluster-api-provider-hetzner/pkg/services/hcloud/client"
+	fakeclient "github.com/syself/cluster-api-provider-hetzner/pkg/services/hcloud/client/fake"
+)
+
+var _ = Describe("addServiceOpts", func() {
+	It("leaves the health check to HCloud if it is not part of the spec", func() {
+		opts := addServiceOpts(infrav1.LoadBalancerServiceSpec{Protocol: "tcp", ListenPort: 80, DestinationPort: 8080}, nil)
+		Expect(opts.HealthCheck).To(BeNil())
+		Expect(opts.HTTP).To(BeNil())
+		Expect(*opts.Proxyprotocol).To(BeFalse())
+	})
+
+	It("uses the destination port and the defaults of HCloud for unset health check settings", func() {
+		opts := addServiceOpts(infrav1.LoadBalancerServiceSpec{
+			Protocol:        "tcp",
+			ListenPort:      80,
+			DestinationPort: 8080,
+			HealthCheck:     &infrav1.LoadBalancerHealthCheckSpec{Protocol: infrav1.LoadBalancerHealthCheckProtocolHTTP},
+		}, nil)
+
+		Expect(opts.HealthCheck).To(Equal(&hcloud.LoadBalancerAddServiceOptsHealthCheck{
+			Protocol: hcloud.LoadBalancerServiceProtocolHTTP,
+			Port:     ptr.To(8080),
+			Interval: ptr.To(15 * time.Second),
+			Timeout:  ptr.To(10 * time.Second),
+			Retries:  ptr.To(3),
+			HTTP: &hcloud.LoadBalancerAddServiceOptsHealthCheckHTTP{
+				Domain:   ptr.To(""),
+				Path:     ptr.To("/"),
+				Response: ptr.To(""),
+				TLS:      ptr.To(false),
+			},
+		}))
+	})
+
+	It("sets certificates only for https services", func() {
+		certificates := []*hcloud.Certificate{{ID: 1}}
+		spec := infrav1.LoadBalancerServiceSpec{
+			Protocol:        "http",
+			ListenPort:      80,
+			DestinationPort: 8080,
+			HTTP:            &infrav1.LoadBalancerServiceHTTPSpec{StickySessions: true, CookieLifetimeSeconds: 60},
+		}
+		opts := addServiceOpts(spec, certificates)
+		Expect(opts.HTTP.Certificates).To(BeNil())
+		Expect(*opts.HTTP.StickySessions).To(BeTrue())
+		Expect(*opts.HTTP.CookieLifetime).To(Equal(time.Minute))
+		Expect(opts.HTTP.CookieName).To(BeNil())
+
+		spec.Protocol = "https"
+		Expect(addServiceOpts(spec, certificates).HTTP.Certificates).To(Equal(certificates))
+	})
+})
+
+var _ = Describe("serviceUpToDate", func() {
+	// have is a service as returned by HCloud, including its defaults
+	var have hcloud.LoadBalancerService
+
+	BeforeEach(func() {
+		have = hcloud.LoadBalancerService{
+			Protocol:        hcloud.LoadBalancerServiceProtocolHTTPS,
+			ListenPort:      443,
+			DestinationPort: 8080,
+			HTTP: hcloud.LoadBalancerServiceHTTP{
+				CookieName:     "HCLBSTICKY",
+				CookieLifetime: 300 * time.Second,
+				Certificates:   []*hcloud.Certificate{{ID: 2}, {ID: 1}},
+			},
+			HealthCheck: hcloud.LoadBalancerServiceHealthCheck{
+				Protocol: hcloud.LoadBalancerServiceProtocolHTTP,
+				Port:     8080,
+				Interval: 15 * time.Second,
+				Timeout:  10 * time.Second,
+				Retries:  3,
+				HTTP: &hcloud.LoadBalancerServiceHealthCheckHTTP{
+					Path:        "/",
+					StatusCodes: []string{"2??", "3??"},
+				},
+			},
+		}
+	})
+
+	DescribeTable("compares the settings of the spec",
+		func(spec infrav1.LoadBalancerServiceSpec, certificates []*hcloud.Certificate, want bool) {
+			spec.ListenPort = 443
+			spec.DestinationPort = 8080
+			Expect(serviceUpToDate(have, addServiceOpts(spec, certificates))).To(Equal(want))
+		},
+		Entry("ignores the defaults of HCloud",
+			infrav1.LoadBalancerServiceSpec{Protocol: "https", HTTP: &infrav1.LoadBalancerServiceHTTPSpec{}},
+			[]*hcloud.Certificate{{ID: 1}, {ID: 2}},
+			true,
+		),
+		Entry("detects a changed protocol",
+			infrav1.LoadBalancerServiceSpec{Protocol: "tcp"},
+			nil,
+			false,
+		),
+		Entry("detects a changed proxy protocol",
+			infrav1.LoadBalancerServiceSpec{Protocol: "https", ProxyProtocol: true, HTTP: &infrav1.LoadBalancerServiceHTTPSpec{}},
+			[]*hcloud.Certificate{{ID: 1}, {ID: 2}},
+			false,
+		),
+		Entry("detects changed certificates",
+			infrav1.LoadBalancerServiceSpec{Protocol: "https", HTTP: &infrav1.LoadBalancerServiceHTTPSpec{}},
+			[]*hcloud.Certificate{{ID: 1}, {ID: 3}},
+			false,
+		),
+		Entry("detects changed sticky sessions",
+			infrav1.LoadBalancerServiceSpec{Protocol: "https", HTTP: &infrav1.LoadBalancerServiceHTTPSpec{StickySessions: true}},
+			[]*hcloud.Certificate{{ID: 1}, {ID: 2}},
+			false,
+		),
+		Entry("compares status codes regardless of their order",
+			infrav1.LoadBalancerServiceSpec{
+				Protocol: "https",
+				HealthCheck: &infrav1.LoadBalancerHealthCheckSpec{
+					Protocol: infrav1.LoadBalancerHealthCheckProtocolHTTP,
+					HTTP:     &infrav1.LoadBalancerHealthCheckHTTPSpec{StatusCodes: []string{"3??", "2??"}},
+				},
+			},
+			nil,
+			true,
+		),
+		Entry("detects a changed health check interval",
+			infrav1.LoadBalancerServiceSpec{
+				Protocol:    "https",
+				HealthCheck: &infrav1.LoadBalancerHealthCheckSpec{Protocol: infrav1.LoadBalancerHealthCheckProtocolHTTP, IntervalSeconds: 5},
+			},
+			nil,
+			false,
+		),
+		Entry("detects a changed health check path",
+			infrav1.LoadBalancerServiceSpec{
+				Protocol: "https",
+				HealthCheck: &infrav1.LoadBalancerHealthCheckSpec{
+					Protocol: infrav1.LoadBalancerHealthCheckProtocolHTTP,
+					HTTP:     &infrav1.LoadBalancerHealthCheckHTTPSpec{Path: "/readyz"},
+				},
+			},
+			nil,
+			false,
+		),
+	)
+})
+
+var _ = Describe("reconcileServices", func() {
+	var (
+		ctx          context.Context
+		hcloudClient hcloudclient.Client
+		cluster      *infrav1.HetznerCluster
+		service      *Service
+		lbID         int64
+	)
+
+	getLoadBalancer := func() *hcloud.LoadBalancer {
+		loadBalancers, err := hcloudClient.ListLoadBalancers(ctx, hcloud.LoadBalancerListOpts{})
+		Expect(err).To(Succeed())
+		for _, lb := range loadBalancers {
+			if lb.ID == lbID {
+				return lb
+			}
+		}
+		Fail("load balancer not found")
+		return nil
+	}
+
+	listCertificates := func() []*hcloud.Certificate {
+		certificates, err := hcloudClient.ListCertificates(ctx, hcloud.CertificateListOpts{})
+		Expect(err).To(Succeed())
+		return certificates
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		hcloudClient = fakeclient.NewHCloudClientFactory().NewClient("")
+		hcloudClient.Close()
+
+		cluster = &infrav1.HetznerCluster{}
+		cluster.Name = "hetzner-cluster"
+		cluster.Spec.ControlPlaneEndpoint = &clusterv1.APIEndpoint{Port: 443}
+		cluster.Spec.ControlPlaneLoadBalancer = infrav1.LoadBalancerSpec{
+			Enabled:   true,
+			Algorithm: infrav1.LoadBalancerAlgorithmTypeRoundRobin,
+			Type:      "lb11",
+			Region:    "fsn1",
+			Port:      6443,
+		}
+
+		lb, err := hcloudClient.CreateLoadBalancer(ctx, createOptsFromSpec(cluster))
+		Expect(err).To(Succeed())
+		lbID = lb.ID
+
+		service = NewService(&scope.ClusterScope{Logger: klogr.New(), HCloudClient: hcloudClient, HetznerCluster: cluster})
+	})
+
+	It("configures the health check of the API server", func() {
+		cluster.Spec.ControlPlaneLoadBalancer.APIServerHealthCheck = &infrav1.LoadBalancerHealthCheckSpec{
+			Protocol: infrav1.LoadBalancerHealthCheckProtocolHTTP,
+			HTTP:     &infrav1.LoadBalancerHealthCheckHTTPSpec{Path: "/readyz", TLS: true},
+		}
+
+		Expect(service.reconcileServices(ctx, getLoadBalancer())).To(Succeed())
+
+		lb := getLoadBalancer()
+		Expect(lb.Services).To(HaveLen(1))
+		Expect(lb.Services[0].HealthCheck.Protocol).To(Equal(hcloud.LoadBalancerServiceProtocolHTTP))
+		Expect(lb.Services[0].HealthCheck.Port).To(Equal(6443))
+		Expect(lb.Services[0].HealthCheck.HTTP.Path).To(Equal("/readyz"))
+		Expect(lb.Services[0].HealthCheck.HTTP.TLS).To(BeTrue())
+	})
+
+	It("updates services whose settings differ from the spec", func() {
+		cluster.Spec.ControlPlaneLoadBalancer.ExtraServices = []infrav1.LoadBalancerServiceSpec{
+			{Protocol: "tcp", ListenPort: 80, DestinationPort: 8080},
+		}
+		Expect(service.reconcileServices(ctx, getLoadBalancer())).To(Succeed())
+
+		cluster.Spec.ControlPlaneLoadBalancer.ExtraServices[0].ProxyProtocol = true
+		Expect(service.reconcileServices(ctx, getLoadBalancer())).To(Succeed())
+
+		lb := getLoadBalancer()
+		Expect(lb.Services).To(HaveLen(2))
+		for _, s := range lb.Services {
+			Expect(s.Proxyprotocol).To(Equal(s.ListenPort == 80))
+		}
+	})
+
+	It("creates managed certificates and deletes them once they are unused", func() {
+		cluster.Spec.ControlPlaneLoadBalancer.ExtraServices = []infrav1.LoadBalancerServiceSpec{
+			{
+				Protocol:        "https",
+				ListenPort:      8443,
+				DestinationPort: 8080,
+				HTTP: &infrav1.LoadBalancerServiceHTTPSpec{
+					Certificates: []infrav1.LoadBalancerCertificateSpec{
+						{DomainNames: []string{"example.com"}},
+						{ID: ptr.To(int64(1000))},
+					},
+				},
+			},
+		}
+		Expect(service.reconcileServices(ctx, getLoadBalancer())).To(Succeed())
+
+		certificates := listCertificates()
+		Expect(certificates).To(HaveLen(1))
+		Expect(certificates[0].Type).To(Equal(hcloud.CertificateTypeManaged))
+		Expect(certificates[0].DomainNames).To(Equal([]string{"example.com"}))
+		Expect(certificates[0].Labels).To(HaveKeyWithValue(cluster.ClusterTagKey(), string(infrav1.ResourceLifecycleOwned)))
+
+		lb := getLoadBalancer()
+		Expect(lb.Services).To(HaveLen(2))
+		for _, s := range lb.Services {
+			if s.ListenPort == 8443 {
+				Expect(certificateIDs(s.HTTP.Certificates)).To(Equal([]int64{certificates[0].ID, 1000}))
+			}
+		}
+
+		By("reconciling again without changes")
+		Expect(service.reconcileServices(ctx, getLoadBalancer())).To(Succeed())
+		Expect(listCertificates()).To(HaveLen(1))
+
+		By("changing the domain names")
+		cluster.Spec.ControlPlaneLoadBalancer.ExtraServices[0].HTTP.Certificates[0].DomainNames = []string{"example.org"}
+		Expect(service.reconcileServices(ctx, getLoadBalancer())).To(Succeed())
+
+		certificates = listCertificates()
+		Expect(certificates).To(HaveLen(1))
+		Expect(certificates[0].DomainNames).To(Equal([]string{"example.org"}))
+
+		By("removing the service")
+		cluster.Spec.ControlPlaneLoadBalancer.ExtraServices = nil
+		Expect(service.reconcileServices(ctx, getLoadBalancer())).To(Succeed())
+		Expect(listCertificates()).To(BeEmpty())
+		Expect(getLoadBalancer().Services).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("certificateName", func() {
+	It("depends on the cluster, type and content of the certificate", func() {
+		cluster := &infrav1.HetznerCluster{}
+		cluster.Name = "hetzner-cluster"
+
+		name := certificateName(cluster, hcloud.CertificateTypeManaged, "example.com")
+		Expect(name).To(HavePrefix("hetzner-cluster-managed-"))
+		Expect(certificateName(cluster, hcloud.CertificateTypeManaged, "example.com")).To(Equal(name))
+		Expect(certificateName(cluster, hcloud.CertificateTypeManaged, "example.org")).ToNot(Equal(name))
+	})
+})
@@ -79,6 +79,19 @@ func (s *Service) Reconcile(ctx context.Context) (err error) {
 		}
 	}
 
+	network, err = s.reconcileLabels(ctx, network)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile labels: %w", err)
+	}
+
+	if err := s.reconcileSubnets(ctx, network); err != nil {
+		return fmt.Errorf("failed to reconcile subnets: %w", err)
+	}
+
+	if err := s.reconcileRoutes(ctx, network); err != nil {
+		return fmt.Errorf("failed to reconcile routes: %w", err)
+	}
+
 	conditions.MarkTrue(s.scope.HetznerCluster, infrav1.NetworkReadyCondition)
 	s.scope.HetznerCluster.Status.Network = statusFromHCloudNetwork(network)
 
@@ -110,23 +123,244 @@ func (s *Service) createOpts() (hcloud.NetworkCreateOpts, error) {
 		return hcloud.NetworkCreateOpts{}, fmt.Errorf("invalid network %q: %w", spec.CIDRBlock, err)
 	}
 
-	_, subnet, err := net.ParseCIDR(spec.SubnetCIDRBlock)
+	subnets, err := s.desiredSubnets()
+	if err != nil {
+		return hcloud.NetworkCreateOpts{}, err
+	}
+
+	routes, err := s.desiredRoutes()
 	if err != nil {
-		return hcloud.NetworkCreateOpts{}, fmt.Errorf("invalid network %q: %w", spec.SubnetCIDRBlock, err)
+		return hcloud.NetworkCreateOpts{}, err
 	}
 
 	return hcloud.NetworkCreateOpts{
 		Name:    s.scope.HetznerCluster.Name,
 		IPRange: network,
-		Labels:  s.labels(),
-		Subnets: []hcloud.NetworkSubnet{
+		Labels:  s.hcloudLabels(),
+		Subnets: subnets,
+		Routes:  routes,
+		// bare metal servers in the vSwitch need the routes to reach other networks
+		ExposeRoutesToVSwitch: s.scope.HetznerCluster.Spec.RobotVSwitch != nil,
+	}, nil
+}
+
+// desiredSubnets returns the subnets defined in the spec and the subnet of the Robot vSwitch of the cluster.
+func (s *Service) desiredSubnets() ([]hcloud.NetworkSubnet, error) {
+	subnets, err := s.specSubnets()
+	if err != nil {
+		return nil, err
+	}
+
+	vSwitchSpec := s.scope.HetznerCluster.Spec.RobotVSwitch
+	vSwitchStatus := s.scope.HetznerCluster.Status.RobotVSwitch
+	if vSwitchSpec == nil || vSwitchStatus == nil {
+		return subnets, nil
+	}
+
+	_, ipRange, err := net.ParseCIDR(vSwitchSpec.IPRange)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vSwitch subnet %q: %w", vSwitchSpec.IPRange, err)
+	}
+
+	return append(subnets, hcloud.NetworkSubnet{
+		IPRange:     ipRange,
+		NetworkZone: hcloud.NetworkZone(s.scope.HetznerCluster.Spec.HCloudNetwork.NetworkZone),
+		Type:        hcloud.NetworkSubnetTypeVSwitch,
+		VSwitchID:   int64(vSwitchStatus.ID),
+	}), nil
+}
+
+// specSubnets returns the subnets defined in the spec. If no subnets are specified,
+// the single subnet of SubnetCIDRBlock is used for networks owned by the cluster.
+func (s *Service) specSubnets() ([]hcloud.NetworkSubnet, error) {
+	spec := s.scope.HetznerCluster.Spec.HCloudNetwork
+
+	if len(spec.Subnets) == 0 {
+		if spec.IsExisting() {
+			return nil, nil
+		}
+
+		_, subnet, err := net.ParseCIDR(spec.SubnetCIDRBlock)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q: %w", spec.SubnetCIDRBlock, err)
+		}
+
+		return []hcloud.NetworkSubnet{
 			{
 				IPRange:     subnet,
 				NetworkZone: hcloud.NetworkZone(spec.NetworkZone),
 				Type:        hcloud.NetworkSubnetTypeServer,
 			},
-		},
-	}, nil
+		}, nil
+	}
+
+	subnets := make([]hcloud.NetworkSubnet, 0, len(spec.Subnets))
+	for _, subnetSpec := range spec.Subnets {
+		_, ipRange, err := net.ParseCIDR(subnetSpec.IPRange)
+		if err != nil {
+			return nil, fmt.Errorf("invalid subnet %q: %w", subnetSpec.IPRange, err)
+		}
+
+		networkZone := subnetSpec.NetworkZone
+		if networkZone == "" {
+			networkZone = spec.NetworkZone
+		}
+
+		subnet := hcloud.NetworkSubnet{
+			IPRange:     ipRange,
+			NetworkZone: hcloud.NetworkZone(networkZone),
+			Type:        hcloud.NetworkSubnetTypeCloud,
+		}
+
+		if subnetSpec.Type == infrav1.HCloudNetworkSubnetTypeVSwitch {
+			subnet.Type = hcloud.NetworkSubnetTypeVSwitch
+			subnet.VSwitchID = subnetSpec.VSwitchID
+		}
+
+		subnets = append(subnets, subnet)
+	}
+
+	return subnets, nil
+}
+
+func (s *Service) desiredRoutes() ([]hcloud.NetworkRoute, error) {
+	spec := s.scope.HetznerCluster.Spec.HCloudNetwork
+
+	var routes []hcloud.NetworkRoute
+	for _, routeSpec := range spec.Routes {
+		_, destination, err := net.ParseCIDR(routeSpec.Destination)
+		if err != nil {
+			return nil, fmt.Errorf("invalid route destination %q: %w", routeSpec.Destination, err)
+		}
+
+		gateway := net.ParseIP(routeSpec.Gateway)
+		if gateway == nil {
+			return nil, fmt.Errorf("invalid route gateway %q", routeSpec.Gateway)
+		}
+
+		routes = append(routes, hcloud.NetworkRoute{
+			Destination: destination,
+			Gateway:     gateway,
+		})
+	}
+
+	return routes, nil
+}
+
+// reconcileLabels adds missing labels of the spec to networks owned by the cluster and updates changed ones.
+func (s *Service) reconcileLabels(ctx context.Context, network *hcloud.Network) (*hcloud.Network, error) {
+	if s.scope.HetznerCluster.Spec.HCloudNetwork.IsExisting() {
+		return network, nil
+	}
+
+	labels, changed := utils.UpdateLabels(network.Labels, s.hcloudLabels())
+	if !changed {
+		return network, nil
+	}
+
+	updated, err := s.scope.HCloudClient.UpdateNetwork(ctx, network, hcloud.NetworkUpdateOpts{Labels: labels})
+	if err != nil {
+		hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "UpdateNetwork")
+		record.Warnf(s.scope.HetznerCluster, "NetworkUpdateFailed", "Failed to update labels of network %v: %s", network.ID, err)
+		return nil, fmt.Errorf("failed to update labels of network %v: %w", network.ID, err)
+	}
+
+	record.Eventf(s.scope.HetznerCluster, "NetworkLabelsUpdated", "Updated labels of network %v", network.ID)
+	return updated, nil
+}
+
+// reconcileSubnets adds missing subnets to the network. Subnets that are not specified are only
+// removed if the network is owned by the cluster, as existing networks might be shared.
+func (s *Service) reconcileSubnets(ctx context.Context, network *hcloud.Network) error {
+	desired, err := s.desiredSubnets()
+	if err != nil {
+		return err
+	}
+
+	for _, subnet := range desired {
+		if slices.ContainsFunc(network.Subnets, func(actual hcloud.NetworkSubnet) bool {
+			return sameIPNet(actual.IPRange, subnet.IPRange)
+		}) {
+			continue
+		}
+
+		if err := s.scope.HCloudClient.AddSubnetToNetwork(ctx, network, subnet); err != nil {
+			hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "AddSubnetToNetwork")
+			record.Warnf(s.scope.HetznerCluster, "NetworkSubnetAddFailed", "Failed to add subnet %s to network %v: %s", subnet.IPRange, network.ID, err)
+			return fmt.Errorf("failed to add subnet %s to network %v: %w", subnet.IPRange, network.ID, err)
+		}
+
+		record.Eventf(s.scope.HetznerCluster, "NetworkSubnetAdded", "Added subnet %s to network %v", subnet.IPRange, network.ID)
+	}
+
+	if s.scope.HetznerCluster.Spec.HCloudNetwork.IsExisting() {
+		return nil
+	}
+
+	for _, subnet := range slices.Clone(network.Subnets) {
+		if slices.ContainsFunc(desired, func(d hcloud.NetworkSubnet) bool {
+			return sameIPNet(d.IPRange, subnet.IPRange)
+		}) {
+			continue
+		}
+
+		if err := s.scope.HCloudClient.DeleteSubnetFromNetwork(ctx, network, subnet); err != nil {
+			hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "DeleteSubnetFromNetwork")
+			record.Warnf(s.scope.HetznerCluster, "NetworkSubnetDeleteFailed", "Failed to delete subnet %s from network %v: %s", subnet.IPRange, network.ID, err)
+			return fmt.Errorf("failed to delete subnet %s from network %v: %w", subnet.IPRange, network.ID, err)
+		}
+
+		record.Eventf(s.scope.HetznerCluster, "NetworkSubnetDeleted", "Deleted subnet %s from network %v", subnet.IPRange, network.ID)
+	}
+
+	return nil
+}
+
+// reconcileRoutes adds missing routes to the network. Routes that are not specified are only
+// removed if the network is owned by the cluster, as existing networks might be shared.
+func (s *Service) reconcileRoutes(ctx context.Context, network *hcloud.Network) error {
+	desired, err := s.desiredRoutes()
+	if err != nil {
+		return err
+	}
+
+	for _, route := range desired {
+		if slices.ContainsFunc(network.Routes, func(actual hcloud.NetworkRoute) bool {
+			return sameRoute(actual, route)
+		}) {
+			continue
+		}
+
+		if err := s.scope.HCloudClient.AddRouteToNetwork(ctx, network, route); err != nil {
+			hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "AddRouteToNetwork")
+			record.Warnf(s.scope.HetznerCluster, "NetworkRouteAddFailed", "Failed to add route %s via %s to network %v: %s", route.Destination, route.Gateway, network.ID, err)
+			return fmt.Errorf("failed to add route %s via %s to network %v: %w", route.Destination, route.Gateway, network.ID, err)
+		}
+
+		record.Eventf(s.scope.HetznerCluster, "NetworkRouteAdded", "Added route %s via %s to network %v", route.Destination, route.Gateway, network.ID)
+	}
+
+	if s.scope.HetznerCluster.Spec.HCloudNetwork.IsExisting() {
+		return nil
+	}
+
+	for _, route := range slices.Clone(network.Routes) {
+		if slices.ContainsFunc(desired, func(d hcloud.NetworkRoute) bool {
+			return sameRoute(d, route)
+		}) {
+			continue
+		}
+
+		if err := s.scope.HCloudClient.DeleteRouteFromNetwork(ctx, network, route); err != nil {
+			hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "DeleteRouteFromNetwork")
+			record.Warnf(s.scope.HetznerCluster, "NetworkRouteDeleteFailed", "Failed to delete route %s via %s from network %v: %s", route.Destination, route.Gateway, network.ID, err)
+			return fmt.Errorf("failed to delete route %s via %s from network %v: %w", route.Destination, route.Gateway, network.ID, err)
+		}
+
+		record.Eventf(s.scope.HetznerCluster, "NetworkRouteDeleted", "Deleted route %s via %s from network %v", route.Destination, route.Gateway, network.ID)
+	}
+
+	return nil
 }
 
 // Delete implements deletion of the network.
@@ -136,6 +370,11 @@ func (s *Service) Delete(ctx context.Context) error {
 		return nil
 	}
 
+	if s.scope.HetznerCluster.Spec.HCloudNetwork.IsExisting() {
+		// the network is not owned by the cluster, only the subnet of the vSwitch has to be removed
+		return s.deleteVSwitchSubnet(ctx)
+	}
+
 	id := s.scope.HetznerCluster.Status.Network.ID
 
 	if err := s.scope.HCloudClient.DeleteNetwork(ctx, &hcloud.Network{ID: id}); err != nil {
@@ -153,9 +392,53 @@ func (s *Service) Delete(ctx context.Context) error {
 	return nil
 }
 
+// deleteVSwitchSubnet removes the subnet of the Robot vSwitch from the network, so that the vSwitch can be cancelled.
+func (s *Service) deleteVSwitchSubnet(ctx context.Context) error {
+	vSwitchSpec := s.scope.HetznerCluster.Spec.RobotVSwitch
+	if vSwitchSpec == nil || s.scope.HetznerCluster.Status.RobotVSwitch == nil {
+		return nil
+	}
+
+	_, ipRange, err := net.ParseCIDR(vSwitchSpec.IPRange)
+	if err != nil {
+		return fmt.Errorf("invalid vSwitch subnet %q: %w", vSwitchSpec.IPRange, err)
+	}
+
+	network := &hcloud.Network{ID: s.scope.HetznerCluster.Status.Network.ID}
+	if err := s.scope.HCloudClient.DeleteSubnetFromNetwork(ctx, network, hcloud.NetworkSubnet{IPRange: ipRange}); err != nil {
+		hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "DeleteSubnetFromNetwork")
+		if hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
+			return nil
+		}
+		record.Warnf(s.scope.HetznerCluster, "NetworkSubnetDeleteFailed", "Failed to delete subnet %s from network %v: %s", ipRange, network.ID, err)
+		return fmt.Errorf("failed to delete subnet %s from network %v: %w", ipRange, network.ID, err)
+	}
+
+	record.Eventf(s.scope.HetznerCluster, "NetworkSubnetDeleted", "Deleted subnet %s from network %v", ipRange, network.ID)
+	return nil
+}
+
 func (s *Service) findNetwork(ctx context.Context) (*hcloud.Network, error) {
+	spec := s.scope.HetznerCluster.Spec.HCloudNetwork
+
+	if spec.ID != nil {
+		network, err := s.scope.HCloudClient.GetNetwork(ctx, *spec.ID)
+		if err != nil {
+			hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "GetNetwork")
+			return nil, fmt.Errorf("failed to get network %v: %w", *spec.ID, err)
+		}
+		if network == nil {
+			return nil, fmt.Errorf("network with ID %v not found", *spec.ID)
+		}
+		return network, nil
+	}
+
 	opts := hcloud.NetworkListOpts{}
-	opts.LabelSelector = utils.LabelsToLabelSelector(s.labels())
+	if len(spec.Selector) > 0 {
+		opts.LabelSelector = utils.LabelsToLabelSelector(spec.Selector)
+	} else {
+		opts.LabelSelector = utils.LabelsToLabelSelector(s.labels())
+	}
 
 	networks, err := s.scope.HCloudClient.ListNetworks(ctx, opts)
 	if err != nil {
@@ -168,13 +451,12 @@ func (s *Service) findNetwork(ctx context.Context) (*hcloud.Network, error) {
 	}
 
 	if len(networks) == 0 {
+		if len(spec.Selector) > 0 {
+			return nil, fmt.Errorf("no network found with selector %q", opts.LabelSelector)
+		}
 		return nil, nil
 	}
 
-	if len(networks[0].Subnets) > 1 {
-		return nil, fmt.Errorf("multiple subnets not allowed")
-	}
-
 	return networks[0], nil
 }
 
@@ -194,6 +476,27 @@ func statusFromHCloudNetwork(network *hcloud.Network) *infrav1.NetworkStatus {
 	}
 }
 
+func sameIPNet(a, b *net.IPNet) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.String() == b.String()
+}
+
+func sameRoute(a, b hcloud.NetworkRoute) bool {
+	return sameIPNet(a.Destination, b.Destination) && a.Gateway.Equal(b.Gateway)
+}
+
+// hcloudLabels returns the labels of a network owned by the cluster. The label that identifies the network
+// takes precedence over the labels of the spec.
+func (s *Service) hcloudLabels() map[string]string {
+	return utils.MergeLabels(
+		s.scope.HetznerCluster.Spec.HCloudLabels,
+		s.scope.HetznerCluster.Spec.HCloudNetwork.HCloudLabels,
+		s.labels(),
+	)
+}
+
 func (s *Service) labels() map[string]string {
 	clusterTagKey := s.scope.HetznerCluster.ClusterTagKey()
 	return map[string]string{
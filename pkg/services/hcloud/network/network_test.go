@@ -17,15 +17,21 @@ limitations under the License.
 package network
 
 import (
+	"context"
 	"net"
 	"testing"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"k8s.io/klog/v2/klogr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api/util/conditions"
 
 	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
 	"github.com/syself/cluster-api-provider-hetzner/pkg/scope"
+	hcloudclient "github.com/syself/cluster-api-provider-hetzner/pkg/services/hcloud/client"
+	fakeclient "github.com/syself/cluster-api-provider-hetzner/pkg/services/hcloud/client/fake"
 )
 
 func TestNetwork(t *testing.T) {
@@ -84,3 +90,199 @@ var _ = Describe("Test createOpts", func() {
 		Expect(err).ToNot(BeNil())
 	})
 })
+
+var _ = Describe("Reconcile", func() {
+	var (
+		ctx          context.Context
+		hcloudClient hcloudclient.Client
+		service      *Service
+		cluster      *infrav1.HetznerCluster
+	)
+
+	ipRanges := func(subnets []hcloud.NetworkSubnet) []string {
+		ranges := make([]string, 0, len(subnets))
+		for _, subnet := range subnets {
+			ranges = append(ranges, subnet.IPRange.String())
+		}
+		return ranges
+	}
+
+	getNetwork := func(id int64) *hcloud.Network {
+		network, err := hcloudClient.GetNetwork(ctx, id)
+		Expect(err).To(Succeed())
+		Expect(network).ToNot(BeNil())
+		return network
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		hcloudClient = fakeclient.NewHCloudClientFactory().NewClient("")
+		hcloudClient.Close()
+
+		cluster = &infrav1.HetznerCluster{}
+		cluster.Name = "hetzner-cluster"
+		cluster.Spec.HCloudNetwork = infrav1.HCloudNetworkSpec{
+			Enabled:         true,
+			CIDRBlock:       "10.0.0.0/16",
+			SubnetCIDRBlock: "10.0.0.0/24",
+			NetworkZone:     "eu-central",
+		}
+
+		service = NewService(&scope.ClusterScope{Logger: klogr.New(), HCloudClient: hcloudClient, HetznerCluster: cluster})
+	})
+
+	It("creates a network with multiple subnets and routes", func() {
+		cluster.Spec.HCloudNetwork.Subnets = []infrav1.HCloudNetworkSubnetSpec{
+			{IPRange: "10.0.1.0/24", Type: infrav1.HCloudNetworkSubnetTypeCloud},
+			{IPRange: "10.0.2.0/24", Type: infrav1.HCloudNetworkSubnetTypeVSwitch, VSwitchID: 42},
+		}
+		cluster.Spec.HCloudNetwork.Routes = []infrav1.HCloudNetworkRouteSpec{
+			{Destination: "192.168.0.0/24", Gateway: "10.0.1.2"},
+		}
+
+		Expect(service.Reconcile(ctx)).To(Succeed())
+		Expect(conditions.IsTrue(cluster, infrav1.NetworkReadyCondition)).To(BeTrue())
+
+		network := getNetwork(cluster.Status.Network.ID)
+		Expect(ipRanges(network.Subnets)).To(Equal([]string{"10.0.1.0/24", "10.0.2.0/24"}))
+		Expect(network.Subnets[0].Type).To(Equal(hcloud.NetworkSubnetTypeCloud))
+		Expect(network.Subnets[0].NetworkZone).To(Equal(hcloud.NetworkZoneEUCentral))
+		Expect(network.Subnets[1].Type).To(Equal(hcloud.NetworkSubnetTypeVSwitch))
+		Expect(network.Subnets[1].VSwitchID).To(Equal(int64(42)))
+		Expect(network.Routes).To(HaveLen(1))
+		Expect(network.Routes[0].Destination.String()).To(Equal("192.168.0.0/24"))
+	})
+
+	It("adds and removes subnets and routes without recreating the network", func() {
+		cluster.Spec.HCloudNetwork.Subnets = []infrav1.HCloudNetworkSubnetSpec{
+			{IPRange: "10.0.1.0/24"},
+			{IPRange: "10.0.2.0/24"},
+		}
+		Expect(service.Reconcile(ctx)).To(Succeed())
+		networkID := cluster.Status.Network.ID
+
+		cluster.Spec.HCloudNetwork.Subnets = []infrav1.HCloudNetworkSubnetSpec{
+			{IPRange: "10.0.1.0/24"},
+			{IPRange: "10.0.3.0/24"},
+		}
+		cluster.Spec.HCloudNetwork.Routes = []infrav1.HCloudNetworkRouteSpec{
+			{Destination: "192.168.0.0/24", Gateway: "10.0.1.2"},
+		}
+		Expect(service.Reconcile(ctx)).To(Succeed())
+
+		Expect(cluster.Status.Network.ID).To(Equal(networkID))
+		network := getNetwork(networkID)
+		Expect(ipRanges(network.Subnets)).To(ConsistOf("10.0.1.0/24", "10.0.3.0/24"))
+		Expect(network.Routes).To(HaveLen(1))
+
+		cluster.Spec.HCloudNetwork.Routes = nil
+		Expect(service.Reconcile(ctx)).To(Succeed())
+		Expect(getNetwork(networkID).Routes).To(BeEmpty())
+	})
+
+	It("adds the subnet of the Robot vSwitch", func() {
+		cluster.Spec.RobotVSwitch = &infrav1.RobotVSwitchSpec{VLAN: 4000, IPRange: "10.0.1.0/24"}
+		cluster.Status.RobotVSwitch = &infrav1.RobotVSwitchStatus{ID: 7, VLAN: 4000}
+
+		Expect(service.Reconcile(ctx)).To(Succeed())
+
+		network := getNetwork(cluster.Status.Network.ID)
+		Expect(network.ExposeRoutesToVSwitch).To(BeTrue())
+		Expect(ipRanges(network.Subnets)).To(Equal([]string{"10.0.0.0/24", "10.0.1.0/24"}))
+		Expect(network.Subnets[1].Type).To(Equal(hcloud.NetworkSubnetTypeVSwitch))
+		Expect(network.Subnets[1].VSwitchID).To(Equal(int64(7)))
+	})
+
+	It("keeps the labels of the spec in sync", func() {
+		cluster.Spec.HCloudLabels = map[string]string{"team": "infra", "cost-center": "cluster"}
+		cluster.Spec.HCloudNetwork.HCloudLabels = map[string]string{"cost-center": "network"}
+
+		Expect(service.Reconcile(ctx)).To(Succeed())
+		networkID := cluster.Status.Network.ID
+		Expect(getNetwork(networkID).Labels).To(Equal(map[string]string{
+			cluster.ClusterTagKey(): string(infrav1.ResourceLifecycleOwned),
+			"team":                  "infra",
+			"cost-center":           "network",
+		}))
+
+		cluster.Spec.HCloudNetwork.HCloudLabels = map[string]string{"cost-center": "other"}
+		Expect(service.Reconcile(ctx)).To(Succeed())
+		Expect(cluster.Status.Network.ID).To(Equal(networkID))
+		Expect(getNetwork(networkID).Labels).To(HaveKeyWithValue("cost-center", "other"))
+		Expect(cluster.Status.Network.Labels).To(HaveKeyWithValue("cost-center", "other"))
+	})
+
+	Context("existing network", func() {
+		var existing *hcloud.Network
+
+		BeforeEach(func() {
+			_, ipRange, err := net.ParseCIDR("10.0.0.0/16")
+			Expect(err).To(Succeed())
+			_, subnet, err := net.ParseCIDR("10.0.100.0/24")
+			Expect(err).To(Succeed())
+
+			existing, err = hcloudClient.CreateNetwork(ctx, hcloud.NetworkCreateOpts{
+				Name:    "shared-network",
+				IPRange: ipRange,
+				Labels:  map[string]string{"team": "infra"},
+				Subnets: []hcloud.NetworkSubnet{
+					{IPRange: subnet, NetworkZone: hcloud.NetworkZoneEUCentral, Type: hcloud.NetworkSubnetTypeCloud},
+				},
+			})
+			Expect(err).To(Succeed())
+
+			cluster.Spec.HCloudNetwork.Subnets = []infrav1.HCloudNetworkSubnetSpec{
+				{IPRange: "10.0.1.0/24"},
+			}
+		})
+
+		It("attaches to a network by ID and only adds missing subnets", func() {
+			cluster.Spec.HCloudNetwork.ID = ptr.To(existing.ID)
+
+			Expect(service.Reconcile(ctx)).To(Succeed())
+			Expect(cluster.Status.Network.ID).To(Equal(existing.ID))
+			Expect(ipRanges(getNetwork(existing.ID).Subnets)).To(ConsistOf("10.0.100.0/24", "10.0.1.0/24"))
+
+			networks, err := hcloudClient.ListNetworks(ctx, hcloud.NetworkListOpts{})
+			Expect(err).To(Succeed())
+			Expect(networks).To(HaveLen(1))
+		})
+
+		It("attaches to a network by selector", func() {
+			cluster.Spec.HCloudNetwork.Selector = map[string]string{"team": "infra"}
+
+			Expect(service.Reconcile(ctx)).To(Succeed())
+			Expect(cluster.Status.Network.ID).To(Equal(existing.ID))
+		})
+
+		It("fails if no network matches the selector", func() {
+			cluster.Spec.HCloudNetwork.Selector = map[string]string{"team": "other"}
+
+			Expect(service.Reconcile(ctx)).ToNot(Succeed())
+			Expect(conditions.IsFalse(cluster, infrav1.NetworkReadyCondition)).To(BeTrue())
+		})
+
+		It("fails if the network with the ID does not exist", func() {
+			cluster.Spec.HCloudNetwork.ID = ptr.To(existing.ID + 1)
+
+			Expect(service.Reconcile(ctx)).ToNot(Succeed())
+		})
+
+		It("does not add the labels of the spec", func() {
+			cluster.Spec.HCloudNetwork.ID = ptr.To(existing.ID)
+			cluster.Spec.HCloudLabels = map[string]string{"cost-center": "cluster"}
+
+			Expect(service.Reconcile(ctx)).To(Succeed())
+			Expect(getNetwork(existing.ID).Labels).To(Equal(map[string]string{"team": "infra"}))
+		})
+
+		It("does not delete the network", func() {
+			cluster.Spec.HCloudNetwork.ID = ptr.To(existing.ID)
+
+			Expect(service.Reconcile(ctx)).To(Succeed())
+			Expect(service.Delete(ctx)).To(Succeed())
+			getNetwork(existing.ID)
+		})
+	})
+})
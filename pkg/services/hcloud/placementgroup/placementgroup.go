@@ -93,11 +93,10 @@ func (s *Service) Reconcile(ctx context.Context) (err error) {
 	// create new placement groups
 	for _, pgName := range toCreate {
 		name := fmt.Sprintf("%s-%s", s.scope.HetznerCluster.Name, pgName)
-		clusterTagKey := s.scope.HetznerCluster.ClusterTagKey()
 		opts := hcloud.PlacementGroupCreateOpts{
 			Name:   name,
 			Type:   hcloud.PlacementGroupType(placementGroupDesiredMap[pgName].Type),
-			Labels: map[string]string{clusterTagKey: string(infrav1.ResourceLifecycleOwned)},
+			Labels: s.hcloudLabels(),
 		}
 
 		if _, err := s.scope.HCloudClient.CreatePlacementGroup(ctx, opts); err != nil {
@@ -119,6 +118,18 @@ func (s *Service) Reconcile(ctx context.Context) (err error) {
 		return fmt.Errorf("aggregate error - creating/deleting placement groups: %w", multierr)
 	}
 
+	// labels only have to be reconciled for placement groups that have not been deleted
+	desiredPlacementGroups := make([]*hcloud.PlacementGroup, 0, len(placementGroups))
+	for i, pg := range placementGroups {
+		if _, ok := placementGroupDesiredMap[placementGroupsStatus[i].Name]; ok {
+			desiredPlacementGroups = append(desiredPlacementGroups, pg)
+		}
+	}
+
+	if err := s.reconcileLabels(ctx, desiredPlacementGroups); err != nil {
+		return fmt.Errorf("failed to reconcile labels: %w", err)
+	}
+
 	// Update status
 	if len(toCreate) > 0 || len(toDelete) > 0 {
 		// No need to update status if nothing changed
@@ -156,6 +167,35 @@ func (s *Service) Delete(ctx context.Context) (err error) {
 	return nil
 }
 
+// reconcileLabels adds missing labels of the spec to the placement groups and updates changed ones.
+func (s *Service) reconcileLabels(ctx context.Context, placementGroups []*hcloud.PlacementGroup) error {
+	desired := s.hcloudLabels()
+
+	var multierr error
+	for _, pg := range placementGroups {
+		labels, changed := utils.UpdateLabels(pg.Labels, desired)
+		if !changed {
+			continue
+		}
+
+		if _, err := s.scope.HCloudClient.UpdatePlacementGroup(ctx, pg, hcloud.PlacementGroupUpdateOpts{Labels: labels}); err != nil {
+			hcloudutil.HandleRateLimitExceeded(s.scope.HetznerCluster, err, "UpdatePlacementGroup")
+			multierr = errors.Join(multierr, fmt.Errorf("failed to update labels of placement group %v: %w", pg.ID, err))
+		}
+	}
+
+	return multierr
+}
+
+// hcloudLabels returns the labels of the placement groups. The label that identifies the placement groups takes
+// precedence over the labels of the spec.
+func (s *Service) hcloudLabels() map[string]string {
+	return utils.MergeLabels(
+		s.scope.HetznerCluster.Spec.HCloudLabels,
+		map[string]string{s.scope.HetznerCluster.ClusterTagKey(): string(infrav1.ResourceLifecycleOwned)},
+	)
+}
+
 func (s *Service) findPlacementGroups(ctx context.Context) ([]*hcloud.PlacementGroup, error) {
 	clusterTagKey := s.scope.HetznerCluster.ClusterTagKey()
 	labels := map[string]string{clusterTagKey: string(infrav1.ResourceLifecycleOwned)}
@@ -23,7 +23,10 @@ import (
 	"time"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	capi "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/record"
@@ -34,6 +37,10 @@ import (
 	hcloudutil "github.com/syself/cluster-api-provider-hetzner/pkg/services/hcloud/util"
 )
 
+// bootstrapTokenTTL is the default lifetime of the join token in the bootstrap data
+// generated by the kubeadm bootstrap provider.
+const bootstrapTokenTTL = 15 * time.Minute
+
 // Service defines struct with machine scope to reconcile HCloudRemediation.
 type Service struct {
 	scope *scope.HCloudRemediationScope
@@ -48,6 +55,24 @@ func NewService(scope *scope.HCloudRemediationScope) *Service {
 
 // Reconcile implements reconcilement of HCloudRemediation.
 func (s *Service) Reconcile(ctx context.Context) (res reconcile.Result, err error) {
+	remediationType := s.scope.HCloudRemediation.Spec.Strategy.Type
+
+	if remediationType != infrav1.RemediationTypeReboot && remediationType != infrav1.RemediationTypeRecreate {
+		s.scope.Info("unsupported remediation strategy")
+		record.Warnf(s.scope.HCloudRemediation, "UnsupportedRemdiationStrategy", "remediation strategy %q is unsupported", remediationType)
+		return res, nil
+	}
+
+	// If no phase set, default to running
+	if s.scope.HCloudRemediation.Status.Phase == "" {
+		s.scope.HCloudRemediation.Status.Phase = infrav1.PhaseRunning
+	}
+
+	// the server does not exist while it is being recreated, so we must not look for it
+	if s.scope.HCloudRemediation.Status.Phase == infrav1.PhaseRecreating {
+		return s.handlePhaseRecreating(ctx)
+	}
+
 	server, err := s.findServer(ctx)
 	if err != nil {
 		return res, fmt.Errorf("failed to find the server of unhealthy machine: %w", err)
@@ -63,21 +88,11 @@ func (s *Service) Reconcile(ctx context.Context) (res reconcile.Result, err erro
 		return res, nil
 	}
 
-	remediationType := s.scope.HCloudRemediation.Spec.Strategy.Type
-
-	if remediationType != infrav1.RemediationTypeReboot {
-		s.scope.Info("unsupported remediation strategy")
-		record.Warnf(s.scope.HCloudRemediation, "UnsupportedRemdiationStrategy", "remediation strategy %q is unsupported", remediationType)
-		return res, nil
-	}
-
-	// If no phase set, default to running
-	if s.scope.HCloudRemediation.Status.Phase == "" {
-		s.scope.HCloudRemediation.Status.Phase = infrav1.PhaseRunning
-	}
-
 	switch s.scope.HCloudRemediation.Status.Phase {
 	case infrav1.PhaseRunning:
+		if remediationType == infrav1.RemediationTypeRecreate {
+			return s.recreateServer(ctx, server)
+		}
 		return s.handlePhaseRunning(ctx, server)
 	case infrav1.PhaseWaiting:
 		return s.handlePhaseWaiting(ctx)
@@ -128,6 +143,86 @@ func (s *Service) handlePhaseRunning(ctx context.Context, server *hcloud.Server)
 	return res, nil
 }
 
+func (s *Service) recreateServer(ctx context.Context, server *hcloud.Server) (res reconcile.Result, err error) {
+	// the last recreated server had its chance and did not become healthy within the timeout
+	if s.scope.HCloudRemediation.Status.LastRemediated != nil && !s.scope.HasRetriesLeft() {
+		return s.handlePhaseWaiting(ctx)
+	}
+
+	// a server created with an expired join token cannot join the cluster. CAPI replaces the
+	// Machine instead, which comes with fresh bootstrap data.
+	expired, err := s.bootstrapDataExpired(ctx)
+	if err != nil {
+		return res, fmt.Errorf("failed to check bootstrap data: %w", err)
+	}
+	if expired {
+		record.Warnf(
+			s.scope.HCloudRemediation,
+			"BootstrapDataExpired",
+			"Bootstrap data of machine %s is older than %s. Not recreating server %d",
+			s.scope.Machine.Name, bootstrapTokenTTL, server.ID,
+		)
+		s.scope.HCloudRemediation.Status.Phase = infrav1.PhaseDeleting
+		if err := s.setOwnerRemediatedCondition(ctx); err != nil {
+			return res, fmt.Errorf("failed to set conditions on CAPI machine: %w", err)
+		}
+		return res, nil
+	}
+
+	// the HCloudMachine controller creates a new server as soon as it does not find the old one anymore
+	if err := s.scope.HCloudClient.DeleteServer(ctx, server); err != nil {
+		hcloudutil.HandleRateLimitExceeded(s.scope.HCloudMachine, err, "DeleteServer")
+		record.Warnf(s.scope.HCloudRemediation, "FailedDeleteHCloudServer", "Failed to delete HCloud server %d: %s", server.ID, err)
+		return res, fmt.Errorf("failed to delete server %v: %w", server.ID, err)
+	}
+
+	now := metav1.Now()
+	s.scope.HCloudRemediation.Status.LastRemediated = &now
+	s.scope.HCloudRemediation.Status.RetryCount++
+	s.scope.HCloudRemediation.Status.Phase = infrav1.PhaseRecreating
+
+	record.Eventf(
+		s.scope.HCloudRemediation,
+		"ServerRecreating",
+		"Deleted HCloud server %d of machine %s. Waiting for it to be recreated (attempt %d)",
+		server.ID, s.scope.HCloudMachine.Name, s.scope.HCloudRemediation.Status.RetryCount,
+	)
+
+	// changing the HCloudMachine triggers its reconciliation, which creates the new server
+	s.scope.HCloudMachine.Status.Ready = false
+	conditions.MarkFalse(
+		s.scope.HCloudMachine,
+		infrav1.ServerAvailableCondition,
+		infrav1.ServerRecreatingReason,
+		capi.ConditionSeverityWarning,
+		"server %d has been deleted by remediation and is going to be recreated",
+		server.ID,
+	)
+	if err := s.scope.PatchHCloudMachine(ctx); err != nil {
+		return res, fmt.Errorf("failed to patch hcloud machine: %w", err)
+	}
+
+	return reconcile.Result{RequeueAfter: s.timeUntilNextRemediation(now.Time)}, nil
+}
+
+func (s *Service) handlePhaseRecreating(ctx context.Context) (res reconcile.Result, err error) {
+	nextCheck := s.timeUntilNextRemediation(time.Now())
+
+	if nextCheck > 0 {
+		// Not yet time to check the recreated server, requeue
+		return reconcile.Result{RequeueAfter: nextCheck}, nil
+	}
+
+	// The recreated server did not make the machine healthy in time. If there are retries left,
+	// the server is recreated once more. Otherwise, control is handed over to CAPI.
+	if s.scope.HasRetriesLeft() {
+		s.scope.HCloudRemediation.Status.Phase = infrav1.PhaseRunning
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	return s.handlePhaseWaiting(ctx)
+}
+
 func (s *Service) handlePhaseWaiting(ctx context.Context) (res reconcile.Result, err error) {
 	nextCheck := s.timeUntilNextRemediation(time.Now())
 
@@ -149,6 +244,27 @@ func (s *Service) handlePhaseWaiting(ctx context.Context) (res reconcile.Result,
 	return res, nil
 }
 
+// bootstrapDataExpired checks whether the bootstrap data of the machine is too old to be
+// used for a new server. Missing bootstrap data counts as expired.
+func (s *Service) bootstrapDataExpired(ctx context.Context) (bool, error) {
+	dataSecretName := s.scope.Machine.Spec.Bootstrap.DataSecretName
+	if dataSecretName == nil {
+		return true, nil
+	}
+
+	// bootstrap data secrets are not part of the cache
+	var secret corev1.Secret
+	key := types.NamespacedName{Namespace: s.scope.Machine.Namespace, Name: *dataSecretName}
+	if err := s.scope.APIReader.Get(ctx, key, &secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get bootstrap data secret %s: %w", key, err)
+	}
+
+	return time.Since(secret.CreationTimestamp.Time) > bootstrapTokenTTL, nil
+}
+
 func (s *Service) findServer(ctx context.Context) (*hcloud.Server, error) {
 	serverID, err := s.scope.ServerIDFromProviderID()
 	if err != nil {
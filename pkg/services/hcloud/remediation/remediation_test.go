@@ -17,15 +17,26 @@ limitations under the License.
 package remediation
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/klog/v2/klogr"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
 	"github.com/syself/cluster-api-provider-hetzner/pkg/scope"
+	"github.com/syself/cluster-api-provider-hetzner/pkg/services/hcloud/client/fake"
 )
 
 func TestHCloudRemediation(t *testing.T) {
@@ -74,3 +85,130 @@ var _ = Describe("Test TimeUntilNextRemediation", func() {
 		}),
 	)
 })
+
+var _ = Describe("Test Recreate strategy", func() {
+	var (
+		ctx               context.Context
+		service           *Service
+		hcloudMachine     *infrav1.HCloudMachine
+		hcloudRemediation *infrav1.HCloudRemediation
+		server            *hcloud.Server
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		hcloudClient := fake.NewHCloudClientFactory().NewClient("")
+		DeferCleanup(hcloudClient.Close)
+
+		var err error
+		server, err = hcloudClient.CreateServer(ctx, hcloud.ServerCreateOpts{Name: "hcloud-machine"})
+		Expect(err).To(Succeed())
+
+		bootstrapData := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "bootstrap-data", Namespace: "default", CreationTimestamp: metav1.Now()},
+			Data:       map[string][]byte{"value": []byte("data")},
+		}
+		expiredBootstrapData := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "expired-bootstrap-data",
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-bootstrapTokenTTL - time.Minute)),
+			},
+			Data: map[string][]byte{"value": []byte("data")},
+		}
+		machine := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{Name: "machine", Namespace: "default"},
+			Spec: clusterv1.MachineSpec{
+				Bootstrap: clusterv1.Bootstrap{DataSecretName: ptr.To("bootstrap-data")},
+			},
+		}
+		hcloudMachine = &infrav1.HCloudMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "hcloud-machine", Namespace: "default"},
+			Spec:       infrav1.HCloudMachineSpec{ProviderID: ptr.To("hcloud://1")},
+			Status:     infrav1.HCloudMachineStatus{Ready: true},
+		}
+		hcloudRemediation = &infrav1.HCloudRemediation{
+			ObjectMeta: metav1.ObjectMeta{Name: "hcloud-remediation", Namespace: "default"},
+			Spec: infrav1.HCloudRemediationSpec{
+				Strategy: &infrav1.RemediationStrategy{
+					Type:       infrav1.RemediationTypeRecreate,
+					RetryLimit: 2,
+					Timeout:    &metav1.Duration{Duration: time.Minute},
+				},
+			},
+		}
+
+		scheme := runtime.NewScheme()
+		utilruntime.Must(corev1.AddToScheme(scheme))
+		utilruntime.Must(infrav1.AddToScheme(scheme))
+		utilruntime.Must(clusterv1.AddToScheme(scheme))
+		c := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(machine, hcloudMachine, hcloudRemediation, bootstrapData, expiredBootstrapData).
+			WithStatusSubresource(machine, hcloudMachine, hcloudRemediation).Build()
+
+		remediationScope, err := scope.NewHCloudRemediationScope(scope.HCloudRemediationScopeParams{
+			Logger:            klogr.New(),
+			Client:            c,
+			APIReader:         c,
+			HCloudClient:      hcloudClient,
+			Machine:           machine,
+			HCloudMachine:     hcloudMachine,
+			HCloudRemediation: hcloudRemediation,
+		})
+		Expect(err).To(Succeed())
+
+		service = NewService(remediationScope)
+	})
+
+	It("deletes the server and waits for it to be recreated", func() {
+		res, err := service.Reconcile(ctx)
+		Expect(err).To(Succeed())
+		Expect(res.RequeueAfter).To(BeNumerically(">", 0))
+
+		foundServer, err := service.scope.HCloudClient.GetServer(ctx, server.ID)
+		Expect(err).To(Succeed())
+		Expect(foundServer).To(BeNil())
+
+		Expect(hcloudRemediation.Status.Phase).To(Equal(infrav1.PhaseRecreating))
+		Expect(hcloudRemediation.Status.RetryCount).To(Equal(1))
+		Expect(hcloudMachine.Status.Ready).To(BeFalse())
+		Expect(conditions.GetReason(hcloudMachine, infrav1.ServerAvailableCondition)).To(Equal(infrav1.ServerRecreatingReason))
+	})
+
+	It("recreates the server again after the timeout if retries are left", func() {
+		hcloudRemediation.Status.Phase = infrav1.PhaseRecreating
+		hcloudRemediation.Status.RetryCount = 1
+		hcloudRemediation.Status.LastRemediated = &metav1.Time{Time: time.Now().Add(-2 * time.Minute)}
+
+		res, err := service.Reconcile(ctx)
+		Expect(err).To(Succeed())
+		Expect(res.Requeue).To(BeTrue())
+		Expect(hcloudRemediation.Status.Phase).To(Equal(infrav1.PhaseRunning))
+	})
+
+	It("hands over to CAPI after the timeout if no retries are left", func() {
+		hcloudRemediation.Status.Phase = infrav1.PhaseRecreating
+		hcloudRemediation.Status.RetryCount = 2
+		hcloudRemediation.Status.LastRemediated = &metav1.Time{Time: time.Now().Add(-2 * time.Minute)}
+
+		_, err := service.Reconcile(ctx)
+		Expect(err).To(Succeed())
+		Expect(hcloudRemediation.Status.Phase).To(Equal(infrav1.PhaseDeleting))
+		Expect(conditions.IsFalse(service.scope.Machine, clusterv1.MachineOwnerRemediatedCondition)).To(BeTrue())
+	})
+
+	It("hands over to CAPI instead of recreating the server if the bootstrap data has expired", func() {
+		service.scope.Machine.Spec.Bootstrap.DataSecretName = ptr.To("expired-bootstrap-data")
+
+		_, err := service.Reconcile(ctx)
+		Expect(err).To(Succeed())
+
+		foundServer, err := service.scope.HCloudClient.GetServer(ctx, server.ID)
+		Expect(err).To(Succeed())
+		Expect(foundServer).ToNot(BeNil())
+
+		Expect(hcloudRemediation.Status.Phase).To(Equal(infrav1.PhaseDeleting))
+		Expect(hcloudRemediation.Status.RetryCount).To(Equal(0))
+		Expect(conditions.IsFalse(service.scope.Machine, clusterv1.MachineOwnerRemediatedCondition)).To(BeTrue())
+	})
+})
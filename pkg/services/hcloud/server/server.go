@@ -21,11 +21,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"strings"
 	"time"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/version"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -110,9 +112,17 @@ func (s *Service) Reconcile(ctx context.Context) (res reconcile.Result, err erro
 
 	// update HCloudMachineStatus
 	c := s.scope.HCloudMachine.Status.Conditions.DeepCopy()
+	volumes := s.scope.HCloudMachine.Status.Volumes
+	imageID := s.scope.HCloudMachine.Status.ImageID
 	s.scope.HCloudMachine.Status = statusFromHCloudServer(server)
 	s.scope.SetRegion(failureDomain)
 	s.scope.HCloudMachine.Status.Conditions = c
+	s.scope.HCloudMachine.Status.Volumes = volumes
+
+	// the image of the server is not returned anymore once it has been deleted
+	if s.scope.HCloudMachine.Status.ImageID == 0 {
+		s.scope.HCloudMachine.Status.ImageID = imageID
+	}
 
 	// validate labels
 	if err := validateLabels(server, s.createLabels()); err != nil {
@@ -121,6 +131,10 @@ func (s *Service) Reconcile(ctx context.Context) (res reconcile.Result, err erro
 		return res, nil
 	}
 
+	if err := s.reconcileLabels(ctx, server); err != nil {
+		return res, fmt.Errorf("failed to reconcile labels: %w", err)
+	}
+
 	// analyze status of server
 	switch server.Status {
 	case hcloud.ServerStatusOff:
@@ -158,15 +172,21 @@ func (s *Service) Reconcile(ctx context.Context) (res reconcile.Result, err erro
 		return res, reterr
 	}
 
-	// nothing to do any more for worker nodes
-	if !s.scope.IsControlPlane() {
-		conditions.MarkTrue(s.scope.HCloudMachine, infrav1.ServerAvailableCondition)
-		s.scope.SetReady(true)
-		return res, nil
+	// check whether all volumes are attached to the server
+	if err := s.reconcileVolumeAttachment(ctx, server); err != nil {
+		reterr := fmt.Errorf("failed to reconcile volume attachment: %w", err)
+		conditions.MarkFalse(
+			s.scope.HCloudMachine,
+			infrav1.ServerAvailableCondition,
+			infrav1.VolumeAttachFailedReason,
+			clusterv1.ConditionSeverityError,
+			reterr.Error(),
+		)
+		return res, reterr
 	}
 
-	// all control planes have to be attached to the load balancer if it exists
-	if err := s.reconcileLoadBalancerAttachment(ctx, server); err != nil {
+	// all control planes and selected extra targets have to be attached to the load balancer if it exists
+	if err := s.reconcileLoadBalancerTarget(ctx, server); err != nil {
 		reterr := fmt.Errorf("failed to reconcile load balancer attachment: %w", err)
 		conditions.MarkFalse(
 			s.scope.HCloudMachine,
@@ -193,14 +213,20 @@ func (s *Service) Delete(ctx context.Context) (res reconcile.Result, err error)
 
 	// if no server has been found, then nothing can be deleted
 	if server == nil {
+		// volumes can only be deleted after the server that they were attached to is gone
+		if err := s.deleteVolumes(ctx); err != nil {
+			return res, fmt.Errorf("failed to delete volumes: %w", err)
+		}
+
 		msg := fmt.Sprintf("Unable to delete HCloud server. Could not find matching server for %s", s.scope.Name())
 		s.scope.V(1).Info(msg)
 		record.Warnf(s.scope.HCloudMachine, "NoInstanceFound", msg)
 		return res, nil
 	}
 
-	// control planes have to be deleted as targets of server
-	if s.scope.IsControlPlane() && s.scope.HetznerCluster.Spec.ControlPlaneLoadBalancer.Enabled {
+	// control planes and extra targets have to be deleted as targets of server. The machine might not be
+	// selected anymore, so it is deleted as well if it is a target.
+	if s.scope.HetznerCluster.Spec.ControlPlaneLoadBalancer.Enabled && (s.scope.IsLoadBalancerTarget() || s.isTargetOfLoadBalancer(server)) {
 		if err := s.deleteServerOfLoadBalancer(ctx, server); err != nil {
 			return res, fmt.Errorf("failed to delete attached server of loadbalancer: %w", err)
 		}
@@ -247,6 +273,18 @@ func (s *Service) reconcileNetworkAttachment(ctx context.Context, server *hcloud
 	return nil
 }
 
+// reconcileLoadBalancerTarget attaches the server to the load balancer if the machine is a target of it and
+// detaches the server if the machine is not selected anymore, e.g. because its labels or the selector changed.
+func (s *Service) reconcileLoadBalancerTarget(ctx context.Context, server *hcloud.Server) error {
+	if s.scope.IsLoadBalancerTarget() {
+		return s.reconcileLoadBalancerAttachment(ctx, server)
+	}
+	if s.isTargetOfLoadBalancer(server) {
+		return s.deleteServerOfLoadBalancer(ctx, server)
+	}
+	return nil
+}
+
 func (s *Service) reconcileLoadBalancerAttachment(ctx context.Context, server *hcloud.Server) error {
 	if s.scope.HetznerCluster.Status.ControlPlaneLoadBalancer == nil {
 		return nil
@@ -265,6 +303,11 @@ func (s *Service) reconcileLoadBalancerAttachment(ctx context.Context, server *h
 		hasPrivateIP = true
 	}
 
+	// a private load balancer can only reach servers via their private IP
+	if s.scope.HetznerCluster.Spec.ControlPlaneLoadBalancer.Private && !hasPrivateIP {
+		return nil
+	}
+
 	// if load balancer has not been attached to a network, then it cannot add a server with private IP
 	if hasPrivateIP && conditions.IsFalse(s.scope.HetznerCluster, infrav1.LoadBalancerReadyCondition) {
 		return nil
@@ -318,7 +361,7 @@ func (s *Service) createServer(ctx context.Context) (*hcloud.Server, error) {
 	startAfterCreate := true
 	opts := hcloud.ServerCreateOpts{
 		Name:   s.scope.Name(),
-		Labels: s.createLabels(),
+		Labels: s.hcloudLabels(),
 		Image:  image,
 		Location: &hcloud.Location{
 			Name: string(s.scope.HCloudMachine.Status.Region),
@@ -399,6 +442,22 @@ func (s *Service) createServer(ctx context.Context) (*hcloud.Server, error) {
 		opts.PublicNet.EnableIPv4 = true
 	}
 
+	// control plane servers take over the primary IP that is used as control plane endpoint if it is free
+	if s.scope.IsControlPlane() {
+		if primaryIP := s.freeControlPlanePrimaryIP(); primaryIP != nil {
+			opts.PublicNet.EnableIPv4 = true
+			opts.PublicNet.IPv4 = &hcloud.PrimaryIP{ID: primaryIP.ID}
+			opts.Location = nil
+			opts.Datacenter = &hcloud.Datacenter{Name: primaryIP.Datacenter}
+		}
+	}
+
+	// create or reuse volumes, so that they are attached before the node bootstraps
+	opts.Volumes, err = s.ensureVolumes(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ensure volumes: %w", err)
+	}
+
 	// Create the server
 	server, err := s.scope.HCloudClient.CreateServer(ctx, opts)
 	if err != nil {
@@ -433,6 +492,204 @@ func (s *Service) createServer(ctx context.Context) (*hcloud.Server, error) {
 	return server, nil
 }
 
+// freeControlPlanePrimaryIP returns the unassigned primary IP used as control plane endpoint
+// if it is in the region of the machine.
+func (s *Service) freeControlPlanePrimaryIP() *infrav1.ControlPlaneFloatingIPStatus {
+	floatingIPSpec := s.scope.HetznerCluster.Spec.ControlPlaneLoadBalancer.FloatingIP
+	if floatingIPSpec == nil || floatingIPSpec.Type != infrav1.ControlPlaneFloatingIPTypePrimary {
+		return nil
+	}
+
+	primaryIP := s.scope.HetznerCluster.Status.ControlPlaneFloatingIP
+	if primaryIP == nil || primaryIP.ID == 0 || primaryIP.ServerID != 0 {
+		return nil
+	}
+
+	// primary IPs can only be assigned to servers in the same datacenter
+	if !strings.HasPrefix(primaryIP.Datacenter, string(s.scope.HCloudMachine.Status.Region)+"-") {
+		return nil
+	}
+	return primaryIP
+}
+
+// ensureVolumes creates the volumes of the machine that do not exist yet in the region of the machine
+// and returns all of them. Existing volumes must not be attached to any server other than the given one.
+func (s *Service) ensureVolumes(ctx context.Context, server *hcloud.Server) ([]*hcloud.Volume, error) {
+	if len(s.scope.HCloudMachine.Spec.Volumes) == 0 {
+		return nil, nil
+	}
+
+	existingVolumes, err := s.findVolumes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find volumes: %w", err)
+	}
+
+	existingVolumeMap := make(map[string]*hcloud.Volume, len(existingVolumes))
+	for i, volume := range existingVolumes {
+		existingVolumeMap[volume.Labels[infrav1.VolumeNameTagKey]] = existingVolumes[i]
+	}
+
+	region := string(s.scope.HCloudMachine.Status.Region)
+
+	volumes := make([]*hcloud.Volume, 0, len(s.scope.HCloudMachine.Spec.Volumes))
+	for _, volumeSpec := range s.scope.HCloudMachine.Spec.Volumes {
+		volume, found := existingVolumeMap[volumeSpec.Name]
+		if found {
+			// volumes can only be attached to servers in the same location
+			if volume.Location != nil && volume.Location.Name != region {
+				return nil, fmt.Errorf("volume %s exists in location %s, but machine is in region %s", volumeSpec.Name, volume.Location.Name, region)
+			}
+			if volume.Server != nil && (server == nil || volume.Server.ID != server.ID) {
+				return nil, fmt.Errorf("volume %s is attached to another server with id %d", volumeSpec.Name, volume.Server.ID)
+			}
+			volumes = append(volumes, volume)
+			continue
+		}
+
+		automount := false
+		opts := hcloud.VolumeCreateOpts{
+			Name:      fmt.Sprintf("%s-%s", s.scope.Name(), volumeSpec.Name),
+			Size:      volumeSpec.Size,
+			Location:  &hcloud.Location{Name: region},
+			Labels:    s.createVolumeLabels(volumeSpec.Name),
+			Automount: &automount,
+			Format:    volumeSpec.Format,
+		}
+
+		volume, err = s.scope.HCloudClient.CreateVolume(ctx, opts)
+		if err != nil {
+			hcloudutil.HandleRateLimitExceeded(s.scope.HCloudMachine, err, "CreateVolume")
+			record.Warnf(s.scope.HCloudMachine,
+				"FailedCreateHCloudVolume",
+				"Failed to create HCloud volume %s: %s",
+				opts.Name,
+				err,
+			)
+			return nil, fmt.Errorf("failed to create volume %s: %w", volumeSpec.Name, err)
+		}
+
+		record.Eventf(s.scope.HCloudMachine, "SuccessfulCreateVolume", "Created new volume with id %d", volume.ID)
+		volumes = append(volumes, volume)
+	}
+
+	return volumes, nil
+}
+
+// reconcileVolumeAttachment attaches all volumes of the machine to the server and reports them in the status.
+func (s *Service) reconcileVolumeAttachment(ctx context.Context, server *hcloud.Server) error {
+	volumes, err := s.ensureVolumes(ctx, server)
+	if err != nil {
+		return err
+	}
+
+	for _, volume := range volumes {
+		// if it is already attached to the server, then do nothing
+		if volume.Server != nil {
+			continue
+		}
+
+		if err := s.scope.HCloudClient.AttachVolume(ctx, volume, server); err != nil {
+			hcloudutil.HandleRateLimitExceeded(s.scope.HCloudMachine, err, "AttachVolume")
+			return fmt.Errorf("failed to attach volume %d to server: %w", volume.ID, err)
+		}
+	}
+
+	s.scope.HCloudMachine.Status.Volumes = statusFromHCloudVolumes(volumes, s.scope.HCloudMachine.Spec.Volumes)
+	return nil
+}
+
+// deleteVolumes deletes all volumes of the machine that should be deleted together with the machine.
+func (s *Service) deleteVolumes(ctx context.Context) error {
+	if !s.hasVolumesToDelete() {
+		return nil
+	}
+
+	volumes, err := s.findVolumes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to find volumes: %w", err)
+	}
+
+	var multierr error
+	for _, volume := range volumes {
+		volumeSpec := findVolumeSpec(s.scope.HCloudMachine.Spec.Volumes, volume.Labels[infrav1.VolumeNameTagKey])
+		if volumeSpec == nil || !volumeSpec.DeleteOnMachineDelete {
+			continue
+		}
+
+		if err := s.scope.HCloudClient.DeleteVolume(ctx, volume); err != nil {
+			hcloudutil.HandleRateLimitExceeded(s.scope.HCloudMachine, err, "DeleteVolume")
+			if !hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
+				multierr = errors.Join(multierr, fmt.Errorf("failed to delete volume %d: %w", volume.ID, err))
+			}
+			continue
+		}
+
+		record.Eventf(s.scope.HCloudMachine, "HCloudVolumeDeleted", "HCloud volume %s deleted", volume.Name)
+	}
+
+	return multierr
+}
+
+func (s *Service) hasVolumesToDelete() bool {
+	for _, volumeSpec := range s.scope.HCloudMachine.Spec.Volumes {
+		if volumeSpec.DeleteOnMachineDelete {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Service) findVolumes(ctx context.Context) ([]*hcloud.Volume, error) {
+	opts := hcloud.VolumeListOpts{}
+	opts.LabelSelector = utils.LabelsToLabelSelector(s.createVolumeLabels(""))
+
+	volumes, err := s.scope.HCloudClient.ListVolumes(ctx, opts)
+	if err != nil {
+		hcloudutil.HandleRateLimitExceeded(s.scope.HCloudMachine, err, "ListVolumes")
+		return nil, fmt.Errorf("failed to list volumes: %w", err)
+	}
+	return volumes, nil
+}
+
+// createVolumeLabels returns the labels of a volume of the machine. If no name is given,
+// the labels can be used to select all volumes of the machine.
+func (s *Service) createVolumeLabels(name string) map[string]string {
+	labels := map[string]string{
+		s.scope.HetznerCluster.ClusterTagKey(): string(infrav1.ResourceLifecycleOwned),
+		infrav1.MachineNameTagKey:              s.scope.Name(),
+	}
+	if name != "" {
+		labels[infrav1.VolumeNameTagKey] = name
+	}
+	return labels
+}
+
+func findVolumeSpec(volumeSpecs []infrav1.HCloudVolumeSpec, name string) *infrav1.HCloudVolumeSpec {
+	for i, volumeSpec := range volumeSpecs {
+		if volumeSpec.Name == name {
+			return &volumeSpecs[i]
+		}
+	}
+	return nil
+}
+
+func statusFromHCloudVolumes(volumes []*hcloud.Volume, volumeSpecs []infrav1.HCloudVolumeSpec) []infrav1.HCloudVolumeStatus {
+	status := make([]infrav1.HCloudVolumeStatus, 0, len(volumes))
+	for _, volume := range volumes {
+		name := volume.Labels[infrav1.VolumeNameTagKey]
+		volumeStatus := infrav1.HCloudVolumeStatus{
+			Name:        name,
+			ID:          volume.ID,
+			LinuxDevice: volume.LinuxDevice,
+		}
+		if volumeSpec := findVolumeSpec(volumeSpecs, name); volumeSpec != nil {
+			volumeStatus.MountPath = volumeSpec.MountPath
+		}
+		status = append(status, volumeStatus)
+	}
+	return status
+}
+
 func (s *Service) getServerImage(ctx context.Context) (*hcloud.Image, error) {
 	key := fmt.Sprintf("%s%s", infrav1.NameHetznerProviderPrefix, "image-name")
 
@@ -453,6 +710,10 @@ func (s *Service) getServerImage(ctx context.Context) (*hcloud.Image, error) {
 		return nil, errServerCreateNotPossible
 	}
 
+	if selector := s.scope.HCloudMachine.Spec.ImageSelector; selector != nil {
+		return s.getServerImageBySelector(ctx, selector, serverType.Architecture)
+	}
+
 	// query for an existing image by label
 	// this is needed because snapshots don't have a name, only descriptions and labels
 	listOpts := hcloud.ImageListOpts{
@@ -493,20 +754,99 @@ func (s *Service) getServerImage(ctx context.Context) (*hcloud.Image, error) {
 		return nil, errServerCreateNotPossible
 	}
 	if len(images) == 0 {
-		err := fmt.Errorf("no image found with name %s", s.scope.HCloudMachine.Spec.ImageName)
-		record.Warnf(s.scope.HCloudMachine, "ImageNotFound", err.Error())
-		conditions.MarkFalse(s.scope.HCloudMachine,
-			infrav1.ServerCreateSucceededCondition,
-			infrav1.ImageNotFoundReason,
-			clusterv1.ConditionSeverityError,
-			err.Error(),
-		)
-		return nil, errServerCreateNotPossible
+		return nil, s.handleImageNotFound(fmt.Errorf("no image found with name %s", s.scope.HCloudMachine.Spec.ImageName))
 	}
 
 	return images[0], nil
 }
 
+// getServerImageBySelector returns the image with the ID of the selector or the image that the policy of the selector
+// chooses from all images that match its label selector.
+func (s *Service) getServerImageBySelector(
+	ctx context.Context,
+	selector *infrav1.ImageSelector,
+	architecture hcloud.Architecture,
+) (*hcloud.Image, error) {
+	if selector.ID != nil {
+		image, err := s.scope.HCloudClient.GetImage(ctx, *selector.ID)
+		if err != nil {
+			hcloudutil.HandleRateLimitExceeded(s.scope.HCloudMachine, err, "GetImage")
+			return nil, fmt.Errorf("failed to get image %d in HCloud: %w", *selector.ID, err)
+		}
+		if image == nil {
+			return nil, s.handleImageNotFound(fmt.Errorf("no image found with id %d", *selector.ID))
+		}
+		if image.Architecture != "" && image.Architecture != architecture {
+			return nil, s.handleImageNotFound(fmt.Errorf("image %d has architecture %s, but the server type needs %s",
+				image.ID, image.Architecture, architecture))
+		}
+		return image, nil
+	}
+
+	images, err := s.scope.HCloudClient.ListImages(ctx, hcloud.ImageListOpts{
+		ListOpts: hcloud.ListOpts{
+			LabelSelector: selector.LabelSelector,
+		},
+		Architecture: []hcloud.Architecture{architecture},
+	})
+	if err != nil {
+		hcloudutil.HandleRateLimitExceeded(s.scope.HCloudMachine, err, "ListImages")
+		return nil, fmt.Errorf("failed to list images by label selector in HCloud: %w", err)
+	}
+
+	image := selectImage(images, selector)
+	if image == nil {
+		return nil, s.handleImageNotFound(fmt.Errorf("no image found with label selector %q", selector.LabelSelector))
+	}
+	return image, nil
+}
+
+// handleImageNotFound reports that no image could be found for the machine.
+func (s *Service) handleImageNotFound(err error) error {
+	record.Warn(s.scope.HCloudMachine, "ImageNotFound", err.Error())
+	conditions.MarkFalse(s.scope.HCloudMachine,
+		infrav1.ServerCreateSucceededCondition,
+		infrav1.ImageNotFoundReason,
+		clusterv1.ConditionSeverityError,
+		err.Error(),
+	)
+	return errServerCreateNotPossible
+}
+
+// selectImage returns the image that the policy of the selector chooses. For the policy HighestSemver, images
+// without a valid semantic version in the SemverLabel are ignored. Ties are resolved by the higher image ID.
+func selectImage(images []*hcloud.Image, selector *infrav1.ImageSelector) *hcloud.Image {
+	var (
+		selected        *hcloud.Image
+		selectedVersion *version.Version
+	)
+
+	for _, image := range images {
+		switch selector.Policy {
+		case infrav1.ImageSelectionPolicyHighestSemver:
+			v, err := version.ParseSemantic(strings.TrimPrefix(image.Labels[selector.SemverLabel], "v"))
+			if err != nil {
+				continue
+			}
+			if selected != nil {
+				if v.LessThan(selectedVersion) || (!selectedVersion.LessThan(v) && image.ID < selected.ID) {
+					continue
+				}
+			}
+			selected, selectedVersion = image, v
+		default:
+			if selected != nil {
+				if image.Created.Before(selected.Created) || (image.Created.Equal(selected.Created) && image.ID < selected.ID) {
+					continue
+				}
+			}
+			selected = image
+		}
+	}
+
+	return selected
+}
+
 func (s *Service) handleServerStatusOff(ctx context.Context, server *hcloud.Server) (res reconcile.Result, err error) {
 	// Check if server is in ServerStatusOff and turn it on. This is to avoid a bug of Hetzner where
 	// sometimes machines are created and not turned on
@@ -582,6 +922,11 @@ func (s *Service) handleDeleteServerStatusRunning(ctx context.Context, server *h
 	}
 
 	record.Eventf(s.scope.HCloudMachine, "HCloudServerDeleted", "HCloud server %s deleted", s.scope.Name())
+
+	// requeue to delete the volumes once they are detached from the deleted server
+	if s.hasVolumesToDelete() {
+		return reconcile.Result{RequeueAfter: 5 * time.Second}, nil
+	}
 	return res, nil
 }
 
@@ -594,9 +939,27 @@ func (s *Service) handleDeleteServerStatusOff(ctx context.Context, server *hclou
 	}
 
 	record.Eventf(s.scope.HCloudMachine, "HCloudServerDeleted", "HCloud server %s deleted", s.scope.Name())
+
+	// requeue to delete the volumes once they are detached from the deleted server
+	if s.hasVolumesToDelete() {
+		return reconcile.Result{RequeueAfter: 5 * time.Second}, nil
+	}
 	return res, nil
 }
 
+// isTargetOfLoadBalancer returns true if the server is a target of the load balancer.
+func (s *Service) isTargetOfLoadBalancer(server *hcloud.Server) bool {
+	if s.scope.HetznerCluster.Status.ControlPlaneLoadBalancer == nil {
+		return false
+	}
+	for _, target := range s.scope.HetznerCluster.Status.ControlPlaneLoadBalancer.Target {
+		if target.Type == infrav1.LoadBalancerTargetTypeServer && target.ServerID == server.ID {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Service) deleteServerOfLoadBalancer(ctx context.Context, server *hcloud.Server) error {
 	lb := &hcloud.LoadBalancer{ID: s.scope.HetznerCluster.Status.ControlPlaneLoadBalancer.ID}
 
@@ -691,6 +1054,8 @@ func statusFromHCloudServer(server *hcloud.Server) infrav1.HCloudMachineStatus {
 	}
 
 	if ip := server.PublicNet.IPv6.IP; ip.IsGlobalUnicast() {
+		// copy the IP to not modify the server
+		ip = append(net.IP(nil), ip...)
 		ip[15]++
 		addresses = append(
 			addresses,
@@ -711,9 +1076,15 @@ func statusFromHCloudServer(server *hcloud.Server) infrav1.HCloudMachineStatus {
 		)
 	}
 
+	var imageID int64
+	if server.Image != nil {
+		imageID = server.Image.ID
+	}
+
 	return infrav1.HCloudMachineStatus{
 		InstanceState: &instanceState,
 		Addresses:     addresses,
+		ImageID:       imageID,
 	}
 }
 
@@ -732,6 +1103,32 @@ func (s *Service) createLabels() map[string]string {
 	}
 }
 
+// hcloudLabels returns the labels of the server. The labels that identify the server take precedence over the
+// labels of the spec.
+func (s *Service) hcloudLabels() map[string]string {
+	return utils.MergeLabels(
+		s.scope.HetznerCluster.Spec.HCloudLabels,
+		s.scope.HCloudMachine.Spec.HCloudLabels,
+		s.createLabels(),
+	)
+}
+
+// reconcileLabels adds missing labels of the spec to the server and updates changed ones.
+func (s *Service) reconcileLabels(ctx context.Context, server *hcloud.Server) error {
+	labels, changed := utils.UpdateLabels(server.Labels, s.hcloudLabels())
+	if !changed {
+		return nil
+	}
+
+	if _, err := s.scope.HCloudClient.UpdateServer(ctx, server, hcloud.ServerUpdateOpts{Labels: labels}); err != nil {
+		hcloudutil.HandleRateLimitExceeded(s.scope.HCloudMachine, err, "UpdateServer")
+		return fmt.Errorf("failed to update labels of server %d: %w", server.ID, err)
+	}
+
+	record.Eventf(s.scope.HCloudMachine, "ServerLabelsUpdated", "Updated labels of server %d", server.ID)
+	return nil
+}
+
 func filterHCloudSSHKeys(sshKeysAPI []*hcloud.SSHKey, sshKeysSpec []infrav1.SSHKey) ([]*hcloud.SSHKey, error) {
 	sshKeysAPIMap := make(map[string]*hcloud.SSHKey)
 	for i, sshKey := range sshKeysAPI {
@@ -192,7 +192,7 @@ var server *hcloud.Server
 
 const instanceState = hcloud.ServerStatusRunning
 
-var ips = []string{"1.2.3.4", "2001:db8::3", "10.0.0.2"}
+var ips = []string{"1.2.3.4", "2001:db8::1", "10.0.0.2"}
 var addressTypes = []clusterv1.MachineAddressType{clusterv1.MachineExternalIP, clusterv1.MachineExternalIP, clusterv1.MachineInternalIP}
 
 func TestServer(t *testing.T) {
@@ -18,6 +18,7 @@ package server
 
 import (
 	"context"
+	"net"
 	"time"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
@@ -32,6 +33,7 @@ import (
 	infrav1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
 	"github.com/syself/cluster-api-provider-hetzner/pkg/scope"
 	fakeclient "github.com/syself/cluster-api-provider-hetzner/pkg/services/hcloud/client/fake"
+	"github.com/syself/cluster-api-provider-hetzner/pkg/utils"
 )
 
 var _ = Describe("statusFromHCloudServer", func() {
@@ -55,6 +57,12 @@ var _ = Describe("statusFromHCloudServer", func() {
 			Expect(addr.Type).To(Equal(addressTypes[i]))
 		}
 	})
+	It("should have the ID of the image", func() {
+		Expect(sts.ImageID).To(Equal(int64(42)))
+	})
+	It("should not modify the server", func() {
+		Expect(statusFromHCloudServer(server)).To(Equal(sts))
+	})
 })
 
 type testCaseStatusFromHCloudServer struct {
@@ -300,6 +308,40 @@ var _ = Describe("handleServerStatusOff", func() {
 	})
 })
 
+var _ = Describe("selectImage", func() {
+	now := time.Now()
+	images := []*hcloud.Image{
+		{ID: 1, Created: now.Add(-time.Hour), Labels: map[string]string{"version": "v1.28.10"}},
+		{ID: 2, Created: now, Labels: map[string]string{"version": "v1.28.9"}},
+		{ID: 3, Created: now.Add(-2 * time.Hour), Labels: map[string]string{"version": "invalid"}},
+		{ID: 4, Created: now.Add(-time.Hour), Labels: map[string]string{}},
+	}
+
+	DescribeTable("selectImage",
+		func(images []*hcloud.Image, selector infrav1.ImageSelector, expectedID int64) {
+			image := selectImage(images, &selector)
+			if expectedID == 0 {
+				Expect(image).To(BeNil())
+			} else {
+				Expect(image).ToNot(BeNil())
+				Expect(image.ID).To(Equal(expectedID))
+			}
+		},
+		Entry("newest image", images, infrav1.ImageSelector{Policy: infrav1.ImageSelectionPolicyNewest}, int64(2)),
+		Entry("newest image without policy", images, infrav1.ImageSelector{}, int64(2)),
+		Entry("higher ID with the same creation time", []*hcloud.Image{images[1], {ID: 5, Created: now}}, infrav1.ImageSelector{}, int64(5)),
+		Entry("highest semantic version", images, infrav1.ImageSelector{
+			Policy:      infrav1.ImageSelectionPolicyHighestSemver,
+			SemverLabel: "version",
+		}, int64(1)),
+		Entry("no image with semantic version", images[2:], infrav1.ImageSelector{
+			Policy:      infrav1.ImageSelectionPolicyHighestSemver,
+			SemverLabel: "version",
+		}, int64(0)),
+		Entry("no images", nil, infrav1.ImageSelector{}, int64(0)),
+	)
+})
+
 var _ = Describe("Test ValidateLabels", func() {
 	type testCaseValidateLabels struct {
 		gotLabels   map[string]string
@@ -339,3 +381,241 @@ var _ = Describe("Test ValidateLabels", func() {
 		}),
 	)
 })
+
+var _ = Describe("reconcileLabels", func() {
+	var (
+		service *Service
+		server  *hcloud.Server
+	)
+
+	client := fakeclient.NewHCloudClientFactory().NewClient("")
+
+	BeforeEach(func() {
+		hcloudMachine := &infrav1.HCloudMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "labels-machine",
+				Namespace: "default",
+			},
+			Spec: infrav1.HCloudMachineSpec{
+				ImageName:    "fedora-control-plane",
+				Type:         "cpx31",
+				HCloudLabels: map[string]string{"cost-center": "machine"},
+			},
+		}
+
+		service = newTestService(hcloudMachine, client)
+		service.scope.Machine = &clusterv1.Machine{}
+		service.scope.HetznerCluster = &infrav1.HetznerCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "labels-cluster"},
+			Spec: infrav1.HetznerClusterSpec{
+				HCloudLabels: map[string]string{"team": "infra", "cost-center": "cluster"},
+			},
+		}
+
+		var err error
+		server, err = client.CreateServer(context.Background(), hcloud.ServerCreateOpts{
+			Name:   "labels-machine",
+			Labels: utils.MergeLabels(service.createLabels(), map[string]string{"team": "old", "other": "external"}),
+		})
+		Expect(err).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.DeleteServer(context.Background(), server)).To(Succeed())
+	})
+
+	It("adds and updates the labels of the spec and keeps other labels", func() {
+		Expect(service.reconcileLabels(context.Background(), server)).To(Succeed())
+
+		server, err := client.GetServer(context.Background(), server.ID)
+		Expect(err).To(Succeed())
+		Expect(server.Labels).To(Equal(utils.MergeLabels(service.createLabels(), map[string]string{
+			"team":        "infra",
+			"cost-center": "machine",
+			"other":       "external",
+		})))
+	})
+
+	It("does not override the labels that identify the server", func() {
+		service.scope.HCloudMachine.Spec.HCloudLabels[infrav1.MachineNameTagKey] = "other-machine"
+
+		Expect(service.reconcileLabels(context.Background(), server)).To(Succeed())
+
+		server, err := client.GetServer(context.Background(), server.ID)
+		Expect(err).To(Succeed())
+		Expect(server.Labels[infrav1.MachineNameTagKey]).To(Equal("labels-machine"))
+	})
+})
+
+var _ = Describe("Volumes", func() {
+	var (
+		hcloudMachine *infrav1.HCloudMachine
+		service       *Service
+		server        *hcloud.Server
+	)
+
+	client := fakeclient.NewHCloudClientFactory().NewClient("")
+
+	BeforeEach(func() {
+		hcloudMachine = &infrav1.HCloudMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "volume-machine",
+				Namespace: "default",
+			},
+			Spec: infrav1.HCloudMachineSpec{
+				ImageName: "fedora-control-plane",
+				Type:      "cpx31",
+				Volumes: []infrav1.HCloudVolumeSpec{
+					{Name: "etcd", Size: 10, Format: ptr.To("ext4"), MountPath: "/var/lib/etcd", DeleteOnMachineDelete: true},
+					{Name: "images", Size: 50, MountPath: "/var/lib/containerd"},
+				},
+			},
+			Status: infrav1.HCloudMachineStatus{
+				Region: "fsn1",
+			},
+		}
+
+		service = newTestService(hcloudMachine, client)
+		service.scope.HetznerCluster = &infrav1.HetznerCluster{ObjectMeta: metav1.ObjectMeta{Name: "volume-cluster"}}
+
+		var err error
+		server, err = client.CreateServer(context.Background(), hcloud.ServerCreateOpts{Name: "volume-machine"})
+		Expect(err).To(Succeed())
+	})
+
+	AfterEach(func() {
+		// the fake client is shared with other tests, so only the resources of these tests are removed
+		servers, err := client.ListServers(context.Background(), hcloud.ServerListOpts{})
+		Expect(err).To(Succeed())
+		for _, s := range servers {
+			if s.Name == "volume-machine" || s.Name == "other-machine" {
+				Expect(client.DeleteServer(context.Background(), s)).To(Succeed())
+			}
+		}
+
+		volumes, err := service.findVolumes(context.Background())
+		Expect(err).To(Succeed())
+		for _, volume := range volumes {
+			Expect(client.DeleteVolume(context.Background(), volume)).To(Succeed())
+		}
+	})
+
+	It("creates the volumes, attaches them and reports them in the status", func() {
+		Expect(service.reconcileVolumeAttachment(context.Background(), server)).To(Succeed())
+
+		volumes, err := service.findVolumes(context.Background())
+		Expect(err).To(Succeed())
+		Expect(volumes).To(HaveLen(2))
+		for _, volume := range volumes {
+			Expect(volume.Server).ToNot(BeNil())
+			Expect(volume.Server.ID).To(Equal(server.ID))
+		}
+
+		Expect(hcloudMachine.Status.Volumes).To(HaveLen(2))
+		Expect(hcloudMachine.Status.Volumes[0].Name).To(Equal("etcd"))
+		Expect(hcloudMachine.Status.Volumes[0].MountPath).To(Equal("/var/lib/etcd"))
+		Expect(hcloudMachine.Status.Volumes[0].LinuxDevice).ToNot(BeEmpty())
+	})
+
+	It("reuses existing volumes", func() {
+		Expect(service.reconcileVolumeAttachment(context.Background(), server)).To(Succeed())
+		Expect(service.reconcileVolumeAttachment(context.Background(), server)).To(Succeed())
+
+		volumes, err := service.findVolumes(context.Background())
+		Expect(err).To(Succeed())
+		Expect(volumes).To(HaveLen(2))
+	})
+
+	It("fails if a volume is attached to another server", func() {
+		Expect(service.reconcileVolumeAttachment(context.Background(), server)).To(Succeed())
+
+		otherServer, err := client.CreateServer(context.Background(), hcloud.ServerCreateOpts{Name: "other-machine"})
+		Expect(err).To(Succeed())
+
+		Expect(service.reconcileVolumeAttachment(context.Background(), otherServer)).ToNot(Succeed())
+	})
+
+	It("deletes only the volumes that follow the lifecycle of the machine", func() {
+		Expect(service.reconcileVolumeAttachment(context.Background(), server)).To(Succeed())
+		Expect(client.DeleteServer(context.Background(), server)).To(Succeed())
+
+		Expect(service.deleteVolumes(context.Background())).To(Succeed())
+
+		volumes, err := service.findVolumes(context.Background())
+		Expect(err).To(Succeed())
+		Expect(volumes).To(HaveLen(1))
+		Expect(volumes[0].Labels[infrav1.VolumeNameTagKey]).To(Equal("images"))
+	})
+})
+
+var _ = Describe("reconcileLoadBalancerTarget", func() {
+	var (
+		service *Service
+		server  *hcloud.Server
+		lb      *hcloud.LoadBalancer
+	)
+
+	client := fakeclient.NewHCloudClientFactory().NewClient("")
+
+	BeforeEach(func() {
+		var err error
+		lb, err = client.CreateLoadBalancer(context.Background(), hcloud.LoadBalancerCreateOpts{
+			Name:      "targets-lb",
+			Algorithm: &hcloud.LoadBalancerAlgorithm{Type: hcloud.LoadBalancerAlgorithmTypeRoundRobin},
+		})
+		Expect(err).To(Succeed())
+
+		server, err = client.CreateServer(context.Background(), hcloud.ServerCreateOpts{Name: "targets-machine"})
+		Expect(err).To(Succeed())
+		server.PublicNet.IPv4.IP = net.ParseIP("192.0.2.1")
+
+		service = newTestService(&infrav1.HCloudMachine{ObjectMeta: metav1.ObjectMeta{Name: "targets-machine"}}, client)
+		service.scope.Machine = &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"role": "ingress"}}}
+		service.scope.HetznerCluster = &infrav1.HetznerCluster{
+			Spec: infrav1.HetznerClusterSpec{
+				ControlPlaneLoadBalancer: infrav1.LoadBalancerSpec{
+					ExtraTargetSelector: map[string]string{"role": "ingress"},
+				},
+			},
+			Status: infrav1.HetznerClusterStatus{
+				ControlPlaneLoadBalancer: &infrav1.LoadBalancerStatus{ID: lb.ID},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(client.DeleteServer(context.Background(), server)).To(Succeed())
+		Expect(client.DeleteLoadBalancer(context.Background(), lb.ID)).To(Succeed())
+	})
+
+	It("adds selected machines as targets and removes them once they are not selected anymore", func() {
+		Expect(service.reconcileLoadBalancerTarget(context.Background(), server)).To(Succeed())
+
+		loadBalancer, err := client.GetLoadBalancer(context.Background(), lb.ID)
+		Expect(err).To(Succeed())
+		Expect(loadBalancer.Targets).To(HaveLen(1))
+		Expect(loadBalancer.Targets[0].Server.Server.ID).To(Equal(server.ID))
+
+		// the status of the load balancer is updated by the HetznerCluster controller
+		service.scope.HetznerCluster.Status.ControlPlaneLoadBalancer.Target = []infrav1.LoadBalancerTarget{
+			{Type: infrav1.LoadBalancerTargetTypeServer, ServerID: server.ID},
+		}
+		service.scope.Machine.Labels = map[string]string{"role": "other"}
+
+		Expect(service.reconcileLoadBalancerTarget(context.Background(), server)).To(Succeed())
+
+		loadBalancer, err = client.GetLoadBalancer(context.Background(), lb.ID)
+		Expect(err).To(Succeed())
+		Expect(loadBalancer.Targets).To(BeEmpty())
+	})
+
+	It("does not add machines that are not selected", func() {
+		service.scope.Machine.Labels = nil
+
+		Expect(service.reconcileLoadBalancerTarget(context.Background(), server)).To(Succeed())
+
+		loadBalancer, err := client.GetLoadBalancer(context.Background(), lb.ID)
+		Expect(err).To(Succeed())
+		Expect(loadBalancer.Targets).To(BeEmpty())
+	})
+})
@@ -62,6 +62,32 @@ func LabelSelectorToLabels(str string) (map[string]string, error) {
 	return labels, nil
 }
 
+// MergeLabels returns a new map with the labels of all given maps. Labels of later maps take precedence.
+func MergeLabels(labels ...map[string]string) map[string]string {
+	merged := make(map[string]string)
+	for _, l := range labels {
+		for key, val := range l {
+			merged[key] = val
+		}
+	}
+	return merged
+}
+
+// UpdateLabels returns a new map with the existing labels and the desired labels, which take precedence.
+// It reports whether this differs from the existing labels. Existing labels that are not desired are kept, so
+// labels that have been removed from the spec stay on the resource, as they cannot be told apart from labels that
+// are set by other tools.
+func UpdateLabels(existing, desired map[string]string) (map[string]string, bool) {
+	changed := false
+	for key, val := range desired {
+		if existingVal, found := existing[key]; !found || existingVal != val {
+			changed = true
+			break
+		}
+	}
+	return MergeLabels(existing, desired), changed
+}
+
 // RemoveOwnerRefFromList removes the owner reference of a Kubernetes object.
 func RemoveOwnerRefFromList(refList []metav1.OwnerReference, name, kind, apiVersion string) []metav1.OwnerReference {
 	if len(refList) == 0 {
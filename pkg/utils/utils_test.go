@@ -72,6 +72,39 @@ var _ = DescribeTable("LabelSelectorToLabels",
 	}),
 )
 
+var _ = Describe("MergeLabels", func() {
+	It("gives precedence to later labels", func() {
+		Expect(utils.MergeLabels(
+			map[string]string{"key1": "a", "key2": "a"},
+			nil,
+			map[string]string{"key2": "b", "key3": "b"},
+		)).To(Equal(map[string]string{"key1": "a", "key2": "b", "key3": "b"}))
+	})
+
+	It("does not modify the given labels", func() {
+		labels := map[string]string{"key1": "a"}
+		merged := utils.MergeLabels(labels)
+		merged["key2"] = "b"
+		Expect(labels).To(Equal(map[string]string{"key1": "a"}))
+	})
+})
+
+var _ = DescribeTable("UpdateLabels",
+	func(existing, desired, expected map[string]string, expectedChanged bool) {
+		labels, changed := utils.UpdateLabels(existing, desired)
+		Expect(labels).To(Equal(expected))
+		Expect(changed).To(Equal(expectedChanged))
+	},
+	Entry("no change", map[string]string{"key1": "a", "other": "x"}, map[string]string{"key1": "a"},
+		map[string]string{"key1": "a", "other": "x"}, false),
+	Entry("new label", map[string]string{"other": "x"}, map[string]string{"key1": "a"},
+		map[string]string{"key1": "a", "other": "x"}, true),
+	Entry("changed label", map[string]string{"key1": "b"}, map[string]string{"key1": "a"},
+		map[string]string{"key1": "a"}, true),
+	Entry("no desired labels", map[string]string{"other": "x"}, nil,
+		map[string]string{"other": "x"}, false),
+)
+
 var _ = Describe("DifferenceOfStringSlices", func() {
 	type testCaseDifferenceOfStringSlices struct {
 		a       []string